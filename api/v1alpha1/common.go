@@ -4,11 +4,49 @@ type IronicStatusConditionType string
 
 const (
 	// Ready indicates that Ironic is fully available.
+	// It is derived from the per-component conditions below.
 	IronicStatusReady IronicStatusConditionType = "Ready"
 
-	IronicReasonFailed     = "DeploymentFailed"
-	IronicReasonInProgress = "DeploymentInProgress"
-	IronicReasonAvailable  = "DeploymentAvailable"
+	// NetworkingServiceReady indicates that the Ironic Networking Service
+	// is available or is not enabled.
+	IronicStatusNetworkingServiceReady IronicStatusConditionType = "NetworkingServiceReady"
+	// DatabaseMigrated indicates that the database schema and data have
+	// been migrated to the requested version or that no external database
+	// is used.
+	IronicStatusDatabaseMigrated IronicStatusConditionType = "DatabaseMigrated"
+	// WorkloadAvailable indicates that the Ironic Deployment or DaemonSet
+	// is up-to-date and available.
+	IronicStatusWorkloadAvailable IronicStatusConditionType = "WorkloadAvailable"
+	// ServiceReady indicates that the Ironic Service has been created.
+	IronicStatusServiceReady IronicStatusConditionType = "ServiceReady"
+	// IngressReady indicates that the Ironic Ingress has been created or
+	// that no ingress is configured.
+	IronicStatusIngressReady IronicStatusConditionType = "IngressReady"
+	// MonitoringReady indicates that the ServiceMonitor has been created
+	// or that it is not required.
+	IronicStatusMonitoringReady IronicStatusConditionType = "MonitoringReady"
+	// ClientCertificatesVerified indicates that the running Ironic API has
+	// been verified to reject requests without a client certificate, except
+	// for the agent endpoints, or that no client CA is configured.
+	IronicStatusClientCertificatesVerified IronicStatusConditionType = "ClientCertificatesVerified"
+
+	IronicReasonFailed      = "DeploymentFailed"
+	IronicReasonInProgress  = "DeploymentInProgress"
+	IronicReasonAvailable   = "DeploymentAvailable"
+	IronicReasonNotRequired = "NotRequired"
+
+	// Reasons reported when the database connectivity check fails.
+	IronicReasonDatabaseUnreachable = "DatabaseUnreachable"
+	IronicReasonDatabaseAuthFailed  = "DatabaseAuthFailed"
+	IronicReasonDatabaseTLSError    = "DatabaseTLSError"
+
+	// IronicReasonUpgradeBlocked is reported while an upgrade waits for
+	// nodes to leave transient provision states.
+	IronicReasonUpgradeBlocked = "UpgradeBlocked"
+
+	// Reasons why changes to the Ironic workload are held.
+	IronicReasonPaused                   = "Paused"
+	IronicReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
 
 	IronicLabelPrefix = "ironic.metal3.io"
 
@@ -25,6 +63,22 @@ var (
 	IronicAppLabel     = IronicLabelPrefix + "/app"
 	IronicServiceLabel = IronicLabelPrefix + "/ironic"
 	IronicVersionLabel = IronicLabelPrefix + "/version"
+	// IronicCertificateLabel marks secrets with certificates generated by the operator.
+	IronicCertificateLabel = IronicLabelPrefix + "/certificate"
+
+	// IronicRetryUpgradeAnnotation requests removing the failed jobs of the
+	// current upgrade so that they are started again. The operator removes
+	// the annotation once processed.
+	IronicRetryUpgradeAnnotation = IronicLabelPrefix + "/retry-upgrade"
+	// IronicRollbackUpgradeAnnotation requests restoring the database from
+	// the backup taken before the current upgrade and returning to the
+	// installed version. The operator removes the annotation once processed.
+	IronicRollbackUpgradeAnnotation = IronicLabelPrefix + "/rollback-upgrade"
+	// IronicForceUpgradeAnnotation allows an upgrade to proceed without
+	// waiting for nodes to leave transient provision states.
+	IronicForceUpgradeAnnotation = IronicLabelPrefix + "/force-upgrade"
+	// IronicPausedAnnotation has the same effect as the Paused field.
+	IronicPausedAnnotation = IronicLabelPrefix + "/paused"
 )
 
 // ResourceReference references a ConfigMap or Secret resource.
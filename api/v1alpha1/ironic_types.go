@@ -358,7 +358,18 @@ type DeployRamdisk struct {
 	SSHKey string `json:"sshKey,omitempty"`
 }
 
+// TLSVersion is a version of the TLS protocol. The names match the values
+// of the operator's --tls-min-version and --tls-max-version flags.
+// +kubebuilder:validation:Enum=VersionTLS12;VersionTLS13
+type TLSVersion string
+
+const (
+	TLSVersion12 TLSVersion = "VersionTLS12"
+	TLSVersion13 TLSVersion = "VersionTLS13"
+)
+
 // TLS defines the TLS settings.
+// +kubebuilder:validation:XValidation:rule="!has(self.selfSigned) || !has(self.certificateIssuer)",message="selfSigned and certificateIssuer cannot be used together"
 type TLS struct {
 	// BMCCA is a reference to a ConfigMap or Secret containing the CA certificate(s)
 	// to use when validating TLS connections to BMCs.
@@ -366,6 +377,16 @@ type TLS struct {
 	// +optional
 	BMCCA *ResourceReference `json:"bmcCA,omitempty"`
 
+	// AdditionalBMCCAs are references to more ConfigMaps or Secrets with
+	// CA certificates to use when validating TLS connections to BMCs.
+	// When set, the operator concatenates them with BMCCA into a generated
+	// ConfigMap and uses it instead. All keys of each resource are
+	// included unless Key is set. Only PEM certificates are copied, other
+	// data such as private keys is ignored.
+	// +listType=atomic
+	// +optional
+	AdditionalBMCCAs []ResourceReferenceWithKey `json:"additionalBMCCAs,omitempty"`
+
 	// BMCCAName is a reference to the secret with the CA certificate(s)
 	// to use when validating TLS connections to BMC's.
 	// Supported in Ironic 32.0 or newer.
@@ -385,6 +406,16 @@ type TLS struct {
 	// +optional
 	TrustedCA *ResourceReferenceWithKey `json:"trustedCA,omitempty"`
 
+	// AdditionalTrustedCAs are references to more ConfigMaps or Secrets with
+	// CA certificates to use when validating TLS connections to image
+	// servers and other services. When set, the operator concatenates them
+	// with TrustedCA into a generated ConfigMap and uses it instead.
+	// All keys of each resource are included unless Key is set. Only PEM
+	// certificates are copied, other data such as private keys is ignored.
+	// +listType=atomic
+	// +optional
+	AdditionalTrustedCAs []ResourceReferenceWithKey `json:"additionalTrustedCAs,omitempty"`
+
 	// TrustedCAName is a reference to the configmap with the CA certificate(s)
 	// to use when validating TLS connections to image servers and other services.
 	// The configmap should contain one or more CA certificates in PEM format.
@@ -395,6 +426,41 @@ type TLS struct {
 	// +optional
 	TrustedCAName string `json:"trustedCAName,omitempty"`
 
+	// ClientCA is a reference to a ConfigMap or Secret containing the CA
+	// certificate(s) used to validate client certificates on the Ironic API.
+	// When set, httpd requires clients to present a certificate signed by
+	// this CA, except on the lookup and heartbeat endpoints used by the
+	// ramdisk agent. Requires TLS to be enabled and an Ironic image that
+	// supports the IRONIC_CLIENT_CA_FILE and IRONIC_CLIENT_CA_EXEMPT_PATHS
+	// variables. The operator verifies this on the running Ironic and
+	// reports the result in the ClientCertificatesVerified condition.
+	// +optional
+	ClientCA *ResourceReferenceWithKey `json:"clientCA,omitempty"`
+
+	// ClientCertificateOnly disables HTTP basic authentication on the
+	// Ironic API when ClientCA is set, so that a valid client certificate
+	// is sufficient. By default, clients need both a certificate and
+	// valid API credentials. Basic authentication is only disabled once
+	// the ClientCertificatesVerified condition is true, and is restored
+	// if a later verification fails.
+	// +optional
+	ClientCertificateOnly bool `json:"clientCertificateOnly,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted by the Ironic API,
+	// the image server and the internal RPC. Defaults to TLS 1.2.
+	// +optional
+	MinVersion TLSVersion `json:"minVersion,omitempty"`
+
+	// CipherSuites is a list of TLS 1.2 cipher suites accepted by the Ironic
+	// API, the image server and the internal RPC. Uses the same IANA names as
+	// the operator's --tls-cipher-suites flag, except that the suites it lists
+	// as insecure (e.g. RC4, 3DES or RSA key exchange) are rejected. Ignored
+	// when MinVersion is VersionTLS13. If omitted, the defaults of the Ironic
+	// image are used.
+	// +listType=atomic
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
 	// DisableVirtualMediaTLS turns off TLS on the virtual media server,
 	// which may be required for hardware that cannot accept HTTPS links.
 	// +optional
@@ -407,6 +473,69 @@ type TLS struct {
 	// HighAvailability feature gate to be set.
 	// +optional
 	InsecureRPC *bool `json:"insecureRPC,omitempty"`
+
+	// SelfSigned enables TLS with a CA and a serving certificate generated
+	// and renewed by the operator. The serving certificate is valid for the
+	// service host name, the provisioning and external IP addresses, the
+	// keepalived VIPs and the ingress host. Only used when CertificateName
+	// is empty, the spec is not modified: the serving certificate is stored
+	// in the <name>-tls secret and the CA in the <name>-ca secret.
+	// The CA is valid for 10 years and is replaced, together with the serving
+	// certificate, when it expires within RenewBefore. A CAGenerated event is
+	// emitted in this case, and clients that trust the old CA have to be
+	// updated with the new one from the <name>-ca secret.
+	// +optional
+	SelfSigned *SelfSignedCertificate `json:"selfSigned,omitempty"`
+
+	// CertificateIssuer requests the serving certificate from cert-manager
+	// using this issuer. The operator creates a Certificate with the same
+	// host names and IP addresses as for SelfSigned, and waits for it to be
+	// ready before deploying Ironic. Only used when CertificateName is empty,
+	// the spec is not modified: the certificate is stored in the <name>-tls
+	// secret. The Certificate and its secret are deleted when this field is
+	// unset. Requires cert-manager to be installed.
+	// +optional
+	CertificateIssuer *IssuerReference `json:"certificateIssuer,omitempty"`
+
+	// CertificateExpirationThresholds are the times before the expiration of
+	// a certificate at which a warning event is emitted. The serving
+	// certificate, the CAs and the database certificate are checked.
+	// Defaults to 30 days, 7 days and 1 day.
+	// +optional
+	CertificateExpirationThresholds []metav1.Duration `json:"certificateExpirationThresholds,omitempty"`
+}
+
+// IssuerReference references a cert-manager issuer.
+type IssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind of the issuer: Issuer or ClusterIssuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, defaults to cert-manager.io.
+	// Can be changed to use an external issuer.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// SelfSignedCertificate configures a CA and a serving certificate generated
+// by the operator.
+type SelfSignedCertificate struct {
+	// Duration is the validity of the serving certificate.
+	// +kubebuilder:default="2160h"
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before its expiration the serving certificate
+	// is renewed. Must be shorter than the duration.
+	// +kubebuilder:default="720h"
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 // SwitchportMode defines the switchport mode for network interfaces.
@@ -508,6 +637,11 @@ type Images struct {
 	// Not used if networking.ipAddressManager is not set to keepalived.
 	// +optional
 	Keepalived string `json:"keepalived,omitempty"`
+
+	// DatabaseBackup is the image with the MariaDB client tools used for database backups.
+	// Not used if database.backup is not set.
+	// +optional
+	DatabaseBackup string `json:"databaseBackup,omitempty"`
 }
 
 // ExtraConfig allows overriding any Ironic configuration options.
@@ -547,6 +681,57 @@ type Database struct {
 	// and the server configuration may forbid it.
 	// +optional
 	TLSCertificateName string `json:"tlsCertificateName,omitempty"`
+
+	// Name of a secret with a TLS client certificate and key (tls.crt and
+	// tls.key) for authenticating to the database with mutual TLS.
+	// Requires TLSCertificateName.
+	// +optional
+	ClientCertificateName string `json:"clientCertificateName,omitempty"`
+
+	// Backup configures a backup of the database before each schema upgrade.
+	// +optional
+	Backup *DatabaseBackup `json:"backup,omitempty"`
+}
+
+// DatabaseBackup configures where database backups are stored.
+// +kubebuilder:validation:XValidation:rule="has(self.persistentVolumeClaimName) != has(self.objectStorage)",message="exactly one of persistentVolumeClaimName and objectStorage must be set"
+type DatabaseBackup struct {
+	// Name of a persistent volume claim to store backups in.
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// ObjectStorage configures an S3-compatible object storage to upload backups to.
+	// +optional
+	ObjectStorage *ObjectStorage `json:"objectStorage,omitempty"`
+
+	// Number of the most recent backups to keep. Older backups are removed
+	// after a new backup succeeds. Defaults to 3, at most 50.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	Retain int32 `json:"retain,omitempty"`
+}
+
+// ObjectStorage is a bucket in an S3-compatible object storage.
+type ObjectStorage struct {
+	// URL of the object storage endpoint, e.g. https://s3.example.com.
+	// Objects are addressed using the path style.
+	Endpoint string `json:"endpoint"`
+
+	// Name of the bucket to use.
+	Bucket string `json:"bucket"`
+
+	// Prefix to add to the object names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Name of a secret with the access key (accessKeyID) and
+	// the secret key (secretAccessKey).
+	CredentialsName string `json:"credentialsName"`
 }
 
 type Overrides struct {
@@ -624,13 +809,127 @@ type PrometheusExporter struct {
 	SensorCollectionInterval int `json:"sensorCollectionInterval,omitempty"`
 }
 
+// CloudsYAML configures generation of a Secret with an OpenStack clouds.yaml
+// for accessing Ironic.
+type CloudsYAML struct {
+	// SecretName is the name of the Secret to create.
+	// Defaults to the name of the Ironic object with the -clouds-yaml suffix.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CloudName is the name of the cloud entry in clouds.yaml.
+	// +kubebuilder:default=ironic
+	// +optional
+	CloudName string `json:"cloudName,omitempty"`
+
+	// ExternalEndpoint causes the external URL of the API (ingress, external callback URL or
+	// external IP) to be used instead of the in-cluster service URL.
+	// The in-cluster URL is used if no external URL is configured.
+	// +optional
+	ExternalEndpoint bool `json:"externalEndpoint,omitempty"`
+
+	// CACertPath is the path where the CA certificate will be available to clients.
+	// The CA certificate is stored in the same Secret under the ca.crt key, the default value
+	// assumes that the Secret is mounted to /etc/openstack.
+	// Only used when TLS is enabled and the TLS secret contains a ca.crt key.
+	// +kubebuilder:default=/etc/openstack/ca.crt
+	// +optional
+	CACertPath string `json:"caCertPath,omitempty"`
+}
+
+// CredentialsRotation defines a policy for automatic rotation of credentials.
+type CredentialsRotation struct {
+	// Interval between two rotations, e.g. 720h for 30 days.
+	// The first rotation happens one interval after the credentials secret has been created.
+	Interval metav1.Duration `json:"interval"`
+}
+
+// MaintenanceWindow defines when disruptive changes to Ironic may be applied.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression marking the start of each window,
+	// e.g. "0 2 * * 6" for 2am every Saturday. The time zone is UTC unless
+	// a different one is set with the CRON_TZ= prefix.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration of each window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UpgradePolicy defines how upgrades of Ironic are carried out.
+type UpgradePolicy struct {
+	// NodeOperationsTimeout is the maximum time to wait for nodes to leave
+	// transient provision states (e.g. deploying, cleaning or inspecting)
+	// before Ironic is restarted with a new version or image. Once it
+	// passes, the upgrade proceeds regardless.
+	// Node states cannot be checked when TLS.ClientCA is set since the
+	// operator has no client certificate. The upgrade then always waits for
+	// the full timeout, set it to "0s" to upgrade right away instead.
+	// +kubebuilder:default="1h"
+	// +optional
+	NodeOperationsTimeout *metav1.Duration `json:"nodeOperationsTimeout,omitempty"`
+
+	// HistoryLimit is the number of finished upgrades to keep in the status.
+	// Defaults to 10, at most 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit int32 `json:"historyLimit,omitempty"`
+
+	// IntermediateImages maps versions to the Ironic images used to migrate
+	// the database when an upgrade with an external database skips them,
+	// e.g. {"37.0": "registry.example.com/metal3-io/ironic:release-37.0"}.
+	// Versions without an image here use their default image, which is only
+	// allowed when the target Ironic image is the default one as well.
+	// +optional
+	IntermediateImages map[string]string `json:"intermediateImages,omitempty"`
+}
+
 // IronicSpec defines the desired state of Ironic.
+// The most common mistakes are also validated in the CRD itself so that they
+// are rejected even when the validating webhook is not deployed.
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || !has(self.networking.disableHostNetwork) || !self.networking.disableHostNetwork || !((has(self.networking.bindInterface) && self.networking.bindInterface) || has(self.networking.dhcp) || (has(self.networking.interface) && size(self.networking.interface) > 0) || (has(self.networking.ipAddress) && size(self.networking.ipAddress) > 0) || (has(self.networking.macAddresses) && size(self.networking.macAddresses) > 0) || has(self.networking.keepalived))",message="networking.disableHostNetwork cannot be set to true together with networking.bindInterface or networking.dhcp or networking.interface or networking.ipAddress or networking.macAddresses or networking.keepalived"
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || !has(self.networking.externalIP) || size(self.networking.externalIP) == 0 || !(has(self.networking.ingress) || (has(self.networking.externalCallbackURL) && size(self.networking.externalCallbackURL) > 0) || (has(self.networking.imageServerExternalURL) && size(self.networking.imageServerExternalURL) > 0))",message="networking.externalIP cannot be set together with networking.ingress or networking.externalCallbackURL or networking.imageServerExternalURL"
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || has(self.networking.ingress) || ((has(self.networking.externalCallbackURL) && size(self.networking.externalCallbackURL) > 0) == (has(self.networking.imageServerExternalURL) && size(self.networking.imageServerExternalURL) > 0))",message="when networking.ingress is not set, networking.externalCallbackURL and networking.imageServerExternalURL must be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.tls) || !has(self.tls.insecureRPC) || (has(self.highAvailability) && self.highAvailability)",message="insecureRPC makes no sense without highAvailability"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.database) || !has(self.database) || (self.database.host == oldSelf.database.host && self.database.name == oldSelf.database.name)",message="cannot change to a new database"
 type IronicSpec struct {
 	// APICredentialsName is a reference to the secret with Ironic API credentials.
 	// A new secret will be created if this field is empty.
 	// +optional
 	APICredentialsName string `json:"apiCredentialsName,omitempty"`
 
+	// APICredentialsRotation enables automatic rotation of the API credentials.
+	// On each rotation, a new password is generated while the user name is
+	// kept. This also applies to a secret provided in APICredentialsName.
+	// Ironic reads the credentials from a mounted file that is updated in
+	// place, so the previous password stops working within a minute or two
+	// and clients must re-read the password from the secret. Ironic is only
+	// restarted when the credentials are also used for JSON RPC (e.g. with
+	// HighAvailability). Rotations are only applied inside the maintenance
+	// window and are postponed while nodes are in transient provision states,
+	// up to the node operations timeout of the upgrade policy.
+	// +optional
+	APICredentialsRotation *CredentialsRotation `json:"apiCredentialsRotation,omitempty"`
+
+	// ExtraAPICredentialsNames is a list of secrets with additional Ironic API
+	// credentials (username and password), e.g. for monitoring tools.
+	// Each user can be revoked separately by removing its secret from the list.
+	// The secrets are not modified, the htpasswd of all users is stored in
+	// the <name>-htpasswd secret managed by the operator.
+	// All users have the same access to the API as the main user: Ironic does
+	// not apply its policy to users authenticated with HTTP basic
+	// authentication, so read-only users are not supported.
+	// +listType=set
+	// +optional
+	ExtraAPICredentialsNames []string `json:"extraAPICredentialsNames,omitempty"`
+
+	// CloudsYAML enables generation of a Secret with an OpenStack clouds.yaml file
+	// that uses the API credentials and the effective API endpoint.
+	// The Secret is kept in sync when the API credentials change.
+	// +optional
+	CloudsYAML *CloudsYAML `json:"cloudsYAML,omitempty"`
+
 	// Database is a reference to a MariaDB database to use for persisting Ironic data.
 	// Must be provided for a highly available architecture, optional otherwise.
 	// If missing, a local SQLite database will be used, and the Ironic state will be reset on each pod restart.
@@ -660,6 +959,12 @@ type IronicSpec struct {
 	// +optional
 	Inspection Inspection `json:"inspection,omitempty"`
 
+	// MaintenanceWindow restricts when version changes and changes to the
+	// Ironic pods are applied. Outside of the window, such changes are held
+	// and reported in the status. The initial deployment is not affected.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
 	// Networking defines networking settings for Ironic.
 	// +optional
 	Networking Networking `json:"networking,omitempty"`
@@ -679,6 +984,12 @@ type IronicSpec struct {
 	// +optional
 	Overrides *Overrides `json:"overrides,omitempty"`
 
+	// Paused stops all changes to the Ironic workloads and the database
+	// migrations, while the status is still updated. The same effect can be
+	// achieved with the ironic.metal3.io/paused annotation.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
 	// PrometheusExporter configures sensor data collection and Prometheus metrics export.
 	// When enabled, this configures Ironic to collect sensor data and deploys the
 	// ironic-prometheus-exporter container.
@@ -689,6 +1000,10 @@ type IronicSpec struct {
 	// +optional
 	TLS TLS `json:"tls,omitempty"`
 
+	// UpgradePolicy defines how upgrades of Ironic are carried out.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+
 	// Version is the version of Ironic to be installed.
 	// Must be either "latest" or a MAJOR.MINOR pair, e.g. "27.0".
 	// The default version depends on the operator branch.
@@ -696,6 +1011,184 @@ type IronicSpec struct {
 	Version string `json:"version,omitempty"`
 }
 
+// IronicEndpoints describes how the Ironic API and the image server can be reached.
+type IronicEndpoints struct {
+	// APIURLs are the URLs of the Ironic API. The first URL is always
+	// the in-cluster URL of the Ironic service, the others (if any) can be
+	// used from outside of the cluster.
+	// +optional
+	APIURLs []string `json:"apiURLs,omitempty"`
+
+	// ImageServerURLs are the URLs of the image server. The in-cluster
+	// URL of the Ironic service comes first (if the service exposes the
+	// image server), followed by the externally accessible URLs (if any).
+	// +optional
+	ImageServerURLs []string `json:"imageServerURLs,omitempty"`
+
+	// TLS is true when the Ironic API is served over HTTPS.
+	TLS bool `json:"tls"`
+
+	// CACertificate references the CA certificate that can be used to
+	// validate the Ironic API TLS certificate.
+	// Only set when TLS is active and such a certificate is known.
+	// +optional
+	CACertificate *ResourceReferenceWithKey `json:"caCertificate,omitempty"`
+}
+
+// APICredentialsStatus describes the state of the API credentials.
+type APICredentialsStatus struct {
+	// LastRotationTime is the time the credentials were last rotated by the operator.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime is the time of the next automatic rotation.
+	// Only set when automatic rotation is enabled.
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+}
+
+// CertificateType is the purpose of a certificate used by Ironic.
+type CertificateType string
+
+const (
+	// CertificateTypeServing is the certificate of the Ironic API and the image server.
+	CertificateTypeServing CertificateType = "Serving"
+	// CertificateTypeBMCCA is the CA used to validate BMC connections.
+	CertificateTypeBMCCA CertificateType = "BMCCA"
+	// CertificateTypeTrustedCA is the CA used to validate image servers and other services.
+	CertificateTypeTrustedCA CertificateType = "TrustedCA"
+	// CertificateTypeDatabase is the certificate used to connect to the database.
+	CertificateTypeDatabase CertificateType = "Database"
+	// CertificateTypeClientCA is the CA used to validate client certificates.
+	CertificateTypeClientCA CertificateType = "ClientCA"
+	// CertificateTypeDatabaseClient is the client certificate used to authenticate to the database.
+	CertificateTypeDatabaseClient CertificateType = "DatabaseClient"
+)
+
+// DatabaseBackupStatus describes a database backup made before an upgrade.
+type DatabaseBackupStatus struct {
+	// Location of the backup: a pvc://<claim>/<file> path or
+	// an object storage URL.
+	Location string `json:"location"`
+
+	// FromVersion is the version of Ironic before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the version of Ironic the database was upgraded to.
+	ToVersion string `json:"toVersion"`
+
+	// CompletionTime is the time when the backup was completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// CertificateStatus describes the expiration of a certificate used by Ironic.
+type CertificateStatus struct {
+	// Type of the certificate.
+	Type CertificateType `json:"type"`
+
+	// Kind of the resource with the certificate (ConfigMap or Secret).
+	Kind string `json:"kind"`
+
+	// Name of the resource with the certificate.
+	Name string `json:"name"`
+
+	// NotAfter is the earliest expiration time of the certificates in the resource.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// WarningThreshold is the smallest expiration threshold for which
+	// a warning has been emitted for this certificate.
+	// +optional
+	WarningThreshold *metav1.Duration `json:"warningThreshold,omitempty"`
+}
+
+// PendingChanges describes changes to the Ironic workload that have not been
+// applied yet.
+type PendingChanges struct {
+	// Reason why the changes are held: Paused or OutsideMaintenanceWindow.
+	Reason string `json:"reason"`
+
+	// Changes is a human-readable list of the held changes.
+	// +listType=atomic
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+
+	// NextWindowTime is the start of the next maintenance window.
+	// +optional
+	NextWindowTime *metav1.Time `json:"nextWindowTime,omitempty"`
+}
+
+// UpgradeOutcome is the result of a finished upgrade.
+// +kubebuilder:validation:Enum=Succeeded;Failed;RolledBack
+type UpgradeOutcome string
+
+const (
+	// UpgradeSucceeded means that the new version is up and running.
+	UpgradeSucceeded UpgradeOutcome = "Succeeded"
+	// UpgradeFailed means that one of the upgrade steps has failed.
+	UpgradeFailed UpgradeOutcome = "Failed"
+	// UpgradeRolledBack means that the database has been restored from
+	// the backup taken before the upgrade.
+	UpgradeRolledBack UpgradeOutcome = "RolledBack"
+)
+
+// UpgradeStatus describes an upgrade of Ironic to a new version or image.
+type UpgradeStatus struct {
+	// FromVersion is the version of Ironic installed before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the requested version of Ironic.
+	ToVersion string `json:"toVersion"`
+
+	// FromIronicImage is the Ironic image running before the upgrade.
+	// +optional
+	FromIronicImage string `json:"fromIronicImage,omitempty"`
+
+	// IronicImage is the requested Ironic image.
+	IronicImage string `json:"ironicImage"`
+
+	// RequestTime is when the upgrade has been requested.
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// StartTime is when the upgrade has been allowed to proceed. Unset while
+	// the upgrade is blocked by nodes in transient provision states.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Steps are the intermediate versions that the database is migrated
+	// through before migrating to the requested version.
+	// +listType=atomic
+	// +optional
+	Steps []UpgradeStep `json:"steps,omitempty"`
+
+	// CompletionTime is when the upgrade has finished or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Outcome of the upgrade, unset while it is in progress.
+	// +optional
+	Outcome UpgradeOutcome `json:"outcome,omitempty"`
+
+	// Jobs are the names of the jobs run during the upgrade.
+	// +listType=atomic
+	// +optional
+	Jobs []string `json:"jobs,omitempty"`
+}
+
+// UpgradeStep describes a migration of the database to an intermediate
+// version during an upgrade that skips versions.
+type UpgradeStep struct {
+	// Version of Ironic used for the migration.
+	Version string `json:"version"`
+
+	// IronicImage used for the migration.
+	IronicImage string `json:"ironicImage"`
+
+	// CompletionTime is when both the schema upgrade and the online data
+	// migrations have finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
 // IronicStatus defines the observed state of Ironic.
 type IronicStatus struct {
 	// Conditions describe the state of the Ironic deployment.
@@ -712,10 +1205,51 @@ type IronicStatus struct {
 	// InstalledVersion identifies which version of Ironic was installed.
 	// +optional
 	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// RolledBackVersion is the version whose failed upgrade has been rolled
+	// back. The installed version is kept until a different version is
+	// requested or the upgrade is retried.
+	// +optional
+	RolledBackVersion string `json:"rolledBackVersion,omitempty"`
+
+	// PendingChanges describes the changes to the Ironic workload that are
+	// held because reconciliation is paused or outside the maintenance window.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+
+	// Upgrade describes the most recent upgrade of Ironic.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// UpgradeHistory lists the most recent finished upgrades, oldest first.
+	// +listType=atomic
+	// +optional
+	UpgradeHistory []UpgradeStatus `json:"upgradeHistory,omitempty"`
+
+	// Endpoints describes how the Ironic API and the image server can be
+	// reached. Populated once the Ironic service has been created.
+	// +optional
+	Endpoints *IronicEndpoints `json:"endpoints,omitempty"`
+
+	// APICredentials describes the state of the API credentials.
+	// +optional
+	APICredentials *APICredentialsStatus `json:"apiCredentials,omitempty"`
+
+	// Certificates describes the expiration of the certificates used by Ironic.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// DatabaseBackups lists the most recent database backups, oldest first.
+	// +listType=atomic
+	// +optional
+	DatabaseBackups []DatabaseBackupStatus `json:"databaseBackups,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Requested Version",type="string",JSONPath=".status.requestedVersion",description="Currently requested version",priority=1
 //+kubebuilder:printcolumn:name="Installed Version",type="string",JSONPath=".status.installedVersion",description="Currently installed version"
 //+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Is ready"
@@ -21,11 +21,34 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APICredentialsStatus) DeepCopyInto(out *APICredentialsStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICredentialsStatus.
+func (in *APICredentialsStatus) DeepCopy() *APICredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APICredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentImages) DeepCopyInto(out *AgentImages) {
 	*out = *in
@@ -41,6 +64,58 @@ func (in *AgentImages) DeepCopy() *AgentImages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsYAML) DeepCopyInto(out *CloudsYAML) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsYAML.
+func (in *CloudsYAML) DeepCopy() *CloudsYAML {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsYAML)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsRotation) DeepCopyInto(out *CredentialsRotation) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsRotation.
+func (in *CredentialsRotation) DeepCopy() *CredentialsRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DHCP) DeepCopyInto(out *DHCP) {
 	*out = *in
@@ -89,6 +164,11 @@ func (in *DHCPRange) DeepCopy() *DHCPRange {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Database) DeepCopyInto(out *Database) {
 	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(DatabaseBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Database.
@@ -101,6 +181,42 @@ func (in *Database) DeepCopy() *Database {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackup) DeepCopyInto(out *DatabaseBackup) {
+	*out = *in
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(ObjectStorage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackup.
+func (in *DatabaseBackup) DeepCopy() *DatabaseBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupStatus) DeepCopyInto(out *DatabaseBackupStatus) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupStatus.
+func (in *DatabaseBackupStatus) DeepCopy() *DatabaseBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeployRamdisk) DeepCopyInto(out *DeployRamdisk) {
 	*out = *in
@@ -220,6 +336,36 @@ func (in *Ironic) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IronicEndpoints) DeepCopyInto(out *IronicEndpoints) {
+	*out = *in
+	if in.APIURLs != nil {
+		in, out := &in.APIURLs, &out.APIURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageServerURLs != nil {
+		in, out := &in.ImageServerURLs, &out.ImageServerURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = new(ResourceReferenceWithKey)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IronicEndpoints.
+func (in *IronicEndpoints) DeepCopy() *IronicEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(IronicEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IronicList) DeepCopyInto(out *IronicList) {
 	*out = *in
@@ -255,10 +401,25 @@ func (in *IronicList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IronicSpec) DeepCopyInto(out *IronicSpec) {
 	*out = *in
+	if in.APICredentialsRotation != nil {
+		in, out := &in.APICredentialsRotation, &out.APICredentialsRotation
+		*out = new(CredentialsRotation)
+		**out = **in
+	}
+	if in.ExtraAPICredentialsNames != nil {
+		in, out := &in.ExtraAPICredentialsNames, &out.ExtraAPICredentialsNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudsYAML != nil {
+		in, out := &in.CloudsYAML, &out.CloudsYAML
+		*out = new(CloudsYAML)
+		**out = **in
+	}
 	if in.Database != nil {
 		in, out := &in.Database, &out.Database
 		*out = new(Database)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	out.DeployRamdisk = in.DeployRamdisk
 	if in.ExtraConfig != nil {
@@ -268,6 +429,11 @@ func (in *IronicSpec) DeepCopyInto(out *IronicSpec) {
 	}
 	out.Images = in.Images
 	in.Inspection.DeepCopyInto(&out.Inspection)
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 	in.Networking.DeepCopyInto(&out.Networking)
 	if in.NetworkingService != nil {
 		in, out := &in.NetworkingService, &out.NetworkingService
@@ -292,6 +458,11 @@ func (in *IronicSpec) DeepCopyInto(out *IronicSpec) {
 		**out = **in
 	}
 	in.TLS.DeepCopyInto(&out.TLS)
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IronicSpec.
@@ -309,7 +480,48 @@ func (in *IronicStatus) DeepCopyInto(out *IronicStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]UpgradeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(IronicEndpoints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APICredentials != nil {
+		in, out := &in.APICredentials, &out.APICredentials
+		*out = new(APICredentialsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DatabaseBackups != nil {
+		in, out := &in.DatabaseBackups, &out.DatabaseBackups
+		*out = make([]DatabaseBackupStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -326,6 +538,21 @@ func (in *IronicStatus) DeepCopy() *IronicStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerReference) DeepCopyInto(out *IssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerReference.
+func (in *IssuerReference) DeepCopy() *IssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeepalivedConfig) DeepCopyInto(out *KeepalivedConfig) {
 	*out = *in
@@ -368,6 +595,22 @@ func (in *KeepalivedIP) DeepCopy() *KeepalivedIP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Networking) DeepCopyInto(out *Networking) {
 	*out = *in
@@ -425,6 +668,21 @@ func (in *NetworkingService) DeepCopy() *NetworkingService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorage) DeepCopyInto(out *ObjectStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorage.
+func (in *ObjectStorage) DeepCopy() *ObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Overrides) DeepCopyInto(out *Overrides) {
 	*out = *in
@@ -442,24 +700,24 @@ func (in *Overrides) DeepCopyInto(out *Overrides) {
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.HttpdLivenessProbe != nil {
 		in, out := &in.HttpdLivenessProbe, &out.HttpdLivenessProbe
-		*out = new(v1.Probe)
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HttpdReadinessProbe != nil {
 		in, out := &in.HttpdReadinessProbe, &out.HttpdReadinessProbe
-		*out = new(v1.Probe)
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -473,7 +731,7 @@ func (in *Overrides) DeepCopyInto(out *Overrides) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -490,6 +748,30 @@ func (in *Overrides) DeepCopy() *Overrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChanges) DeepCopyInto(out *PendingChanges) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextWindowTime != nil {
+		in, out := &in.NextWindowTime, &out.NextWindowTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChanges.
+func (in *PendingChanges) DeepCopy() *PendingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusExporter) DeepCopyInto(out *PrometheusExporter) {
 	*out = *in
@@ -556,6 +838,31 @@ func (in *ResourceReferenceWithKey) DeepCopy() *ResourceReferenceWithKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfSignedCertificate) DeepCopyInto(out *SelfSignedCertificate) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfSignedCertificate.
+func (in *SelfSignedCertificate) DeepCopy() *SelfSignedCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfSignedCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -564,16 +871,51 @@ func (in *TLS) DeepCopyInto(out *TLS) {
 		*out = new(ResourceReference)
 		**out = **in
 	}
+	if in.AdditionalBMCCAs != nil {
+		in, out := &in.AdditionalBMCCAs, &out.AdditionalBMCCAs
+		*out = make([]ResourceReferenceWithKey, len(*in))
+		copy(*out, *in)
+	}
 	if in.TrustedCA != nil {
 		in, out := &in.TrustedCA, &out.TrustedCA
 		*out = new(ResourceReferenceWithKey)
 		**out = **in
 	}
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]ResourceReferenceWithKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCA != nil {
+		in, out := &in.ClientCA, &out.ClientCA
+		*out = new(ResourceReferenceWithKey)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.InsecureRPC != nil {
 		in, out := &in.InsecureRPC, &out.InsecureRPC
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SelfSigned != nil {
+		in, out := &in.SelfSigned, &out.SelfSigned
+		*out = new(SelfSignedCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(IssuerReference)
+		**out = **in
+	}
+	if in.CertificateExpirationThresholds != nil {
+		in, out := &in.CertificateExpirationThresholds, &out.CertificateExpirationThresholds
+		*out = make([]v1.Duration, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
@@ -586,6 +928,88 @@ func (in *TLS) DeepCopy() *TLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	*out = *in
+	if in.NodeOperationsTimeout != nil {
+		in, out := &in.NodeOperationsTimeout, &out.NodeOperationsTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IntermediateImages != nil {
+		in, out := &in.IntermediateImages, &out.IntermediateImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]UpgradeStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStep) DeepCopyInto(out *UpgradeStep) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStep.
+func (in *UpgradeStep) DeepCopy() *UpgradeStep {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
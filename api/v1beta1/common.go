@@ -0,0 +1,29 @@
+package v1beta1
+
+const (
+	// ResourceKindConfigMap is the kind for ConfigMap resources.
+	ResourceKindConfigMap = "ConfigMap"
+	// ResourceKindSecret is the kind for Secret resources.
+	ResourceKindSecret = "Secret"
+)
+
+// ResourceReference references a ConfigMap or Secret resource.
+type ResourceReference struct {
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Kind of the resource (ConfigMap or Secret).
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+}
+
+// ResourceReferenceWithKey references a ConfigMap or Secret resource and
+// targets a specific key from it.
+type ResourceReferenceWithKey struct {
+	ResourceReference `json:",inline"`
+
+	// Key within the resource to use. If not specified and the resource contains multiple keys,
+	// the first (alphabetically) key will be used and a warning will be logged for other keys.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
@@ -0,0 +1,478 @@
+package v1beta1
+
+import (
+	"github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// The conversion functions below rely on Go struct conversions for types
+// that have the same fields in both versions. Adding a field to only one
+// of the versions breaks the build instead of silently losing data.
+
+// ConvertToHub converts a v1beta1 Ironic into the v1alpha1 hub version.
+func ConvertToHub(src *Ironic, dst *v1alpha1.Ironic) {
+	src = src.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = v1alpha1.IronicSpec{
+		APICredentialsName:       src.Spec.APICredentialsName,
+		APICredentialsRotation:   (*v1alpha1.CredentialsRotation)(src.Spec.APICredentialsRotation),
+		ExtraAPICredentialsNames: src.Spec.ExtraAPICredentialsNames,
+		CloudsYAML:               (*v1alpha1.CloudsYAML)(src.Spec.CloudsYAML),
+		Database:                 databaseToHub(src.Spec.Database),
+		DeployRamdisk:            v1alpha1.DeployRamdisk(src.Spec.DeployRamdisk),
+		ExtraConfig:              convertSlice(src.Spec.ExtraConfig, func(in ExtraConfig) v1alpha1.ExtraConfig { return v1alpha1.ExtraConfig(in) }),
+		HighAvailability:         src.Spec.HighAvailability,
+		Images:                   v1alpha1.Images(src.Spec.Images),
+		Inspection:               v1alpha1.Inspection(src.Spec.Inspection),
+		MaintenanceWindow:        (*v1alpha1.MaintenanceWindow)(src.Spec.MaintenanceWindow),
+		Networking:               networkingToHub(&src.Spec.Networking),
+		NodeSelector:             src.Spec.NodeSelector,
+		Paused:                   src.Spec.Paused,
+		PrometheusExporter:       (*v1alpha1.PrometheusExporter)(src.Spec.PrometheusExporter),
+		TLS:                      tlsToHub(&src.Spec.TLS),
+		UpgradePolicy:            (*v1alpha1.UpgradePolicy)(src.Spec.UpgradePolicy),
+		Version:                  src.Spec.Version,
+	}
+	if ns := src.Spec.NetworkingService; ns != nil {
+		dst.Spec.NetworkingService = &v1alpha1.NetworkingService{
+			Enabled: ns.Enabled,
+			ProviderNetworks: convertSlice(ns.ProviderNetworks, func(in ProviderNetworkConfig) v1alpha1.ProviderNetworkConfig {
+				return v1alpha1.ProviderNetworkConfig{
+					Type:         v1alpha1.ProviderNetworkType(in.Type),
+					Mode:         v1alpha1.SwitchportMode(in.Mode),
+					NativeVLAN:   in.NativeVLAN,
+					AllowedVLANs: in.AllowedVLANs,
+					MTU:          in.MTU,
+				}
+			}),
+			SwitchConfigSecretName:      ns.SwitchConfigSecretName,
+			SwitchCredentialsSecretName: ns.SwitchCredentialsSecretName,
+		}
+	}
+	if overrides := src.Spec.Overrides; overrides != nil {
+		dst.Spec.Overrides = &v1alpha1.Overrides{
+			Annotations: overrides.Annotations,
+			AgentImages: convertSlice(overrides.AgentImages, func(in AgentImages) v1alpha1.AgentImages {
+				return v1alpha1.AgentImages{
+					Kernel:       in.Kernel,
+					Initramfs:    in.Initramfs,
+					Architecture: v1alpha1.CPUArchitecture(in.Architecture),
+				}
+			}),
+			Containers:          overrides.Containers,
+			HttpdLivenessProbe:  overrides.HttpdLivenessProbe,
+			HttpdReadinessProbe: overrides.HttpdReadinessProbe,
+			InitContainers:      overrides.InitContainers,
+			Labels:              overrides.Labels,
+			Volumes:             overrides.Volumes,
+		}
+	}
+
+	dst.Status = v1alpha1.IronicStatus{
+		Conditions:        src.Status.Conditions,
+		RequestedVersion:  src.Status.RequestedVersion,
+		InstalledVersion:  src.Status.InstalledVersion,
+		RolledBackVersion: src.Status.RolledBackVersion,
+		PendingChanges:    (*v1alpha1.PendingChanges)(src.Status.PendingChanges),
+		Upgrade:           upgradeStatusToHub(src.Status.Upgrade),
+		UpgradeHistory:    convertSlice(src.Status.UpgradeHistory, upgradeStatusValueToHub),
+		APICredentials:    (*v1alpha1.APICredentialsStatus)(src.Status.APICredentials),
+		DatabaseBackups: convertSlice(src.Status.DatabaseBackups, func(in DatabaseBackupStatus) v1alpha1.DatabaseBackupStatus {
+			return v1alpha1.DatabaseBackupStatus(in)
+		}),
+	}
+	if src.Status.Certificates != nil {
+		dst.Status.Certificates = make([]v1alpha1.CertificateStatus, 0, len(src.Status.Certificates))
+		for _, cert := range src.Status.Certificates {
+			dst.Status.Certificates = append(dst.Status.Certificates, v1alpha1.CertificateStatus{
+				Type:             v1alpha1.CertificateType(cert.Type),
+				Kind:             cert.Kind,
+				Name:             cert.Name,
+				NotAfter:         cert.NotAfter,
+				WarningThreshold: cert.WarningThreshold,
+			})
+		}
+	}
+	if endpoints := src.Status.Endpoints; endpoints != nil {
+		dst.Status.Endpoints = &v1alpha1.IronicEndpoints{
+			APIURLs:         endpoints.APIURLs,
+			ImageServerURLs: endpoints.ImageServerURLs,
+			TLS:             endpoints.TLS,
+			CACertificate:   referenceWithKeyToHub(endpoints.CACertificate),
+		}
+	}
+}
+
+// ConvertFromHub converts the v1alpha1 hub version into a v1beta1 Ironic.
+// Deprecated fields that no longer exist in v1beta1 are replaced with their
+// modern counterparts, unless the latter are already set.
+func ConvertFromHub(src *v1alpha1.Ironic, dst *Ironic) {
+	src = src.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = IronicSpec{
+		APICredentialsName:       src.Spec.APICredentialsName,
+		APICredentialsRotation:   (*CredentialsRotation)(src.Spec.APICredentialsRotation),
+		ExtraAPICredentialsNames: src.Spec.ExtraAPICredentialsNames,
+		CloudsYAML:               (*CloudsYAML)(src.Spec.CloudsYAML),
+		Database:                 databaseFromHub(src.Spec.Database),
+		DeployRamdisk:            DeployRamdisk(src.Spec.DeployRamdisk),
+		ExtraConfig:              convertSlice(src.Spec.ExtraConfig, func(in v1alpha1.ExtraConfig) ExtraConfig { return ExtraConfig(in) }),
+		HighAvailability:         src.Spec.HighAvailability,
+		Images:                   Images(src.Spec.Images),
+		Inspection:               Inspection(src.Spec.Inspection),
+		MaintenanceWindow:        (*MaintenanceWindow)(src.Spec.MaintenanceWindow),
+		Networking:               networkingFromHub(&src.Spec.Networking),
+		NodeSelector:             src.Spec.NodeSelector,
+		Paused:                   src.Spec.Paused,
+		PrometheusExporter:       (*PrometheusExporter)(src.Spec.PrometheusExporter),
+		TLS:                      tlsFromHub(&src.Spec.TLS),
+		UpgradePolicy:            (*UpgradePolicy)(src.Spec.UpgradePolicy),
+		Version:                  src.Spec.Version,
+	}
+	if ns := src.Spec.NetworkingService; ns != nil {
+		dst.Spec.NetworkingService = &NetworkingService{
+			Enabled: ns.Enabled,
+			ProviderNetworks: convertSlice(ns.ProviderNetworks, func(in v1alpha1.ProviderNetworkConfig) ProviderNetworkConfig {
+				return ProviderNetworkConfig{
+					Type:         ProviderNetworkType(in.Type),
+					Mode:         SwitchportMode(in.Mode),
+					NativeVLAN:   in.NativeVLAN,
+					AllowedVLANs: in.AllowedVLANs,
+					MTU:          in.MTU,
+				}
+			}),
+			SwitchConfigSecretName:      ns.SwitchConfigSecretName,
+			SwitchCredentialsSecretName: ns.SwitchCredentialsSecretName,
+		}
+	}
+	if overrides := src.Spec.Overrides; overrides != nil {
+		dst.Spec.Overrides = &Overrides{
+			Annotations: overrides.Annotations,
+			AgentImages: convertSlice(overrides.AgentImages, func(in v1alpha1.AgentImages) AgentImages {
+				return AgentImages{
+					Kernel:       in.Kernel,
+					Initramfs:    in.Initramfs,
+					Architecture: CPUArchitecture(in.Architecture),
+				}
+			}),
+			Containers:          overrides.Containers,
+			HttpdLivenessProbe:  overrides.HttpdLivenessProbe,
+			HttpdReadinessProbe: overrides.HttpdReadinessProbe,
+			InitContainers:      overrides.InitContainers,
+			Labels:              overrides.Labels,
+			Volumes:             overrides.Volumes,
+		}
+	}
+
+	dst.Status = IronicStatus{
+		Conditions:        src.Status.Conditions,
+		RequestedVersion:  src.Status.RequestedVersion,
+		InstalledVersion:  src.Status.InstalledVersion,
+		RolledBackVersion: src.Status.RolledBackVersion,
+		PendingChanges:    (*PendingChanges)(src.Status.PendingChanges),
+		Upgrade:           upgradeStatusFromHub(src.Status.Upgrade),
+		UpgradeHistory:    convertSlice(src.Status.UpgradeHistory, upgradeStatusValueFromHub),
+		APICredentials:    (*APICredentialsStatus)(src.Status.APICredentials),
+		DatabaseBackups: convertSlice(src.Status.DatabaseBackups, func(in v1alpha1.DatabaseBackupStatus) DatabaseBackupStatus {
+			return DatabaseBackupStatus(in)
+		}),
+	}
+	if src.Status.Certificates != nil {
+		dst.Status.Certificates = make([]CertificateStatus, 0, len(src.Status.Certificates))
+		for _, cert := range src.Status.Certificates {
+			dst.Status.Certificates = append(dst.Status.Certificates, CertificateStatus{
+				Type:             CertificateType(cert.Type),
+				Kind:             cert.Kind,
+				Name:             cert.Name,
+				NotAfter:         cert.NotAfter,
+				WarningThreshold: cert.WarningThreshold,
+			})
+		}
+	}
+	if endpoints := src.Status.Endpoints; endpoints != nil {
+		dst.Status.Endpoints = &IronicEndpoints{
+			APIURLs:         endpoints.APIURLs,
+			ImageServerURLs: endpoints.ImageServerURLs,
+			TLS:             endpoints.TLS,
+			CACertificate:   referenceWithKeyFromHub(endpoints.CACertificate),
+		}
+	}
+}
+
+func networkingToHub(src *Networking) v1alpha1.Networking {
+	dst := v1alpha1.Networking{
+		APIPort:                src.Ports.API,
+		BindInterface:          src.BindInterface,
+		DisableHostNetwork:     src.DisableHostNetwork,
+		ExternalCallbackURL:    src.External.APIURL,
+		ExternalIP:             src.External.IP,
+		ImageServerExternalURL: src.External.ImageServerURL,
+		ImageServerPort:        src.Ports.ImageServer,
+		ImageServerTLSPort:     src.Ports.ImageServerTLS,
+		Ingress:                (*v1alpha1.Ingress)(src.External.Ingress),
+		Interface:              src.Interface,
+		IPAddress:              src.IPAddress,
+		MACAddresses:           src.MACAddresses,
+		PrometheusExporterPort: src.Ports.PrometheusExporter,
+		RPCPort:                src.Ports.RPC,
+	}
+	if dhcp := src.DHCP; dhcp != nil {
+		dst.DHCP = &v1alpha1.DHCP{
+			DNSAddress:     dhcp.DNSAddress,
+			ExtraRanges:    convertSlice(dhcp.ExtraRanges, func(in DHCPRange) v1alpha1.DHCPRange { return v1alpha1.DHCPRange(in) }),
+			GatewayAddress: dhcp.GatewayAddress,
+			Hosts:          dhcp.Hosts,
+			Ignore:         dhcp.Ignore,
+			NetworkCIDR:    dhcp.NetworkCIDR,
+			RangeBegin:     dhcp.RangeBegin,
+			RangeEnd:       dhcp.RangeEnd,
+			ServeDNS:       dhcp.ServeDNS,
+		}
+	}
+	if keepalived := src.Keepalived; keepalived != nil {
+		dst.Keepalived = &v1alpha1.KeepalivedConfig{
+			Enabled:        keepalived.Enabled,
+			AdditionalVIPs: convertSlice(keepalived.AdditionalVIPs, func(in KeepalivedIP) v1alpha1.KeepalivedIP { return v1alpha1.KeepalivedIP(in) }),
+		}
+	}
+	return dst
+}
+
+func networkingFromHub(src *v1alpha1.Networking) Networking {
+	dst := Networking{
+		BindInterface:      src.BindInterface,
+		DisableHostNetwork: src.DisableHostNetwork,
+		External: ExternalAccess{
+			APIURL:         src.ExternalCallbackURL,
+			ImageServerURL: src.ImageServerExternalURL,
+			Ingress:        (*Ingress)(src.Ingress),
+			IP:             src.ExternalIP,
+		},
+		Interface:    src.Interface,
+		IPAddress:    src.IPAddress,
+		MACAddresses: src.MACAddresses,
+		Ports: Ports{
+			API:                src.APIPort,
+			ImageServer:        src.ImageServerPort,
+			ImageServerTLS:     src.ImageServerTLSPort,
+			PrometheusExporter: src.PrometheusExporterPort,
+			RPC:                src.RPCPort,
+		},
+	}
+	if dhcp := src.DHCP; dhcp != nil {
+		dst.DHCP = &DHCP{
+			DNSAddress:     dhcp.DNSAddress,
+			ExtraRanges:    convertSlice(dhcp.ExtraRanges, func(in v1alpha1.DHCPRange) DHCPRange { return DHCPRange(in) }),
+			GatewayAddress: dhcp.GatewayAddress,
+			Hosts:          dhcp.Hosts,
+			Ignore:         dhcp.Ignore,
+			NetworkCIDR:    dhcp.NetworkCIDR,
+			RangeBegin:     dhcp.RangeBegin,
+			RangeEnd:       dhcp.RangeEnd,
+			ServeDNS:       dhcp.ServeDNS,
+		}
+	}
+	if keepalived := src.Keepalived; keepalived != nil {
+		dst.Keepalived = &KeepalivedConfig{
+			Enabled:        keepalived.Enabled,
+			AdditionalVIPs: convertSlice(keepalived.AdditionalVIPs, func(in v1alpha1.KeepalivedIP) KeepalivedIP { return KeepalivedIP(in) }),
+		}
+	}
+	// The operator starts keepalived when either of the fields enables it.
+	if src.IPAddressManager == v1alpha1.IPAddressManagerKeepalived { //nolint:staticcheck // backward compat
+		if dst.Keepalived == nil {
+			dst.Keepalived = &KeepalivedConfig{}
+		}
+		dst.Keepalived.Enabled = true
+	}
+	return dst
+}
+
+func databaseToHub(src *Database) *v1alpha1.Database {
+	if src == nil {
+		return nil
+	}
+	dst := &v1alpha1.Database{
+		CredentialsName:       src.CredentialsName,
+		Host:                  src.Host,
+		Name:                  src.Name,
+		TLSCertificateName:    src.TLSCertificateName,
+		ClientCertificateName: src.ClientCertificateName,
+	}
+	if backup := src.Backup; backup != nil {
+		dst.Backup = &v1alpha1.DatabaseBackup{
+			PersistentVolumeClaimName: backup.PersistentVolumeClaimName,
+			ObjectStorage:             (*v1alpha1.ObjectStorage)(backup.ObjectStorage),
+			Retain:                    backup.Retain,
+		}
+	}
+	return dst
+}
+
+func databaseFromHub(src *v1alpha1.Database) *Database {
+	if src == nil {
+		return nil
+	}
+	dst := &Database{
+		CredentialsName:       src.CredentialsName,
+		Host:                  src.Host,
+		Name:                  src.Name,
+		TLSCertificateName:    src.TLSCertificateName,
+		ClientCertificateName: src.ClientCertificateName,
+	}
+	if backup := src.Backup; backup != nil {
+		dst.Backup = &DatabaseBackup{
+			PersistentVolumeClaimName: backup.PersistentVolumeClaimName,
+			ObjectStorage:             (*ObjectStorage)(backup.ObjectStorage),
+			Retain:                    backup.Retain,
+		}
+	}
+	return dst
+}
+
+func upgradeStatusToHub(src *UpgradeStatus) *v1alpha1.UpgradeStatus {
+	if src == nil {
+		return nil
+	}
+
+	result := upgradeStatusValueToHub(*src)
+	return &result
+}
+
+func upgradeStatusValueToHub(src UpgradeStatus) v1alpha1.UpgradeStatus {
+	return v1alpha1.UpgradeStatus{
+		FromVersion:     src.FromVersion,
+		ToVersion:       src.ToVersion,
+		FromIronicImage: src.FromIronicImage,
+		IronicImage:     src.IronicImage,
+		RequestTime:     src.RequestTime,
+		StartTime:       src.StartTime,
+		Steps:           convertSlice(src.Steps, func(in UpgradeStep) v1alpha1.UpgradeStep { return v1alpha1.UpgradeStep(in) }),
+		CompletionTime:  src.CompletionTime,
+		Outcome:         v1alpha1.UpgradeOutcome(src.Outcome),
+		Jobs:            src.Jobs,
+	}
+}
+
+func upgradeStatusFromHub(src *v1alpha1.UpgradeStatus) *UpgradeStatus {
+	if src == nil {
+		return nil
+	}
+
+	result := upgradeStatusValueFromHub(*src)
+	return &result
+}
+
+func upgradeStatusValueFromHub(src v1alpha1.UpgradeStatus) UpgradeStatus {
+	return UpgradeStatus{
+		FromVersion:     src.FromVersion,
+		ToVersion:       src.ToVersion,
+		FromIronicImage: src.FromIronicImage,
+		IronicImage:     src.IronicImage,
+		RequestTime:     src.RequestTime,
+		StartTime:       src.StartTime,
+		Steps:           convertSlice(src.Steps, func(in v1alpha1.UpgradeStep) UpgradeStep { return UpgradeStep(in) }),
+		CompletionTime:  src.CompletionTime,
+		Outcome:         UpgradeOutcome(src.Outcome),
+		Jobs:            src.Jobs,
+	}
+}
+
+func tlsToHub(src *TLS) v1alpha1.TLS {
+	return v1alpha1.TLS{
+		BMCCA:                  (*v1alpha1.ResourceReference)(src.CA.BMC),
+		CertificateName:        src.CertificateName,
+		DisableVirtualMediaTLS: src.DisableVirtualMediaTLS,
+		InsecureRPC:            src.InsecureRPC,
+		SelfSigned:             (*v1alpha1.SelfSignedCertificate)(src.SelfSigned),
+		CertificateIssuer:      (*v1alpha1.IssuerReference)(src.CertificateIssuer),
+		ClientCA:               referenceWithKeyToHub(src.ClientCA),
+		ClientCertificateOnly:  src.ClientCertificateOnly,
+		MinVersion:             v1alpha1.TLSVersion(src.MinVersion),
+		CipherSuites:           src.CipherSuites,
+
+		CertificateExpirationThresholds: src.CertificateExpirationThresholds,
+		TrustedCA:                       referenceWithKeyToHub(src.CA.Trusted),
+		AdditionalBMCCAs:                convertSlice(src.CA.AdditionalBMC, referenceWithKeyValueToHub),
+		AdditionalTrustedCAs:            convertSlice(src.CA.AdditionalTrusted, referenceWithKeyValueToHub),
+	}
+}
+
+func tlsFromHub(src *v1alpha1.TLS) TLS {
+	dst := TLS{
+		CertificateName:        src.CertificateName,
+		DisableVirtualMediaTLS: src.DisableVirtualMediaTLS,
+		InsecureRPC:            src.InsecureRPC,
+		SelfSigned:             (*SelfSignedCertificate)(src.SelfSigned),
+		CertificateIssuer:      (*IssuerReference)(src.CertificateIssuer),
+		ClientCA:               referenceWithKeyFromHub(src.ClientCA),
+		ClientCertificateOnly:  src.ClientCertificateOnly,
+		MinVersion:             TLSVersion(src.MinVersion),
+		CipherSuites:           src.CipherSuites,
+
+		CertificateExpirationThresholds: src.CertificateExpirationThresholds,
+		CA: CACertificates{
+			BMC:     (*ResourceReference)(src.BMCCA),
+			Trusted: referenceWithKeyFromHub(src.TrustedCA),
+
+			AdditionalBMC:     convertSlice(src.AdditionalBMCCAs, referenceWithKeyValueFromHub),
+			AdditionalTrusted: convertSlice(src.AdditionalTrustedCAs, referenceWithKeyValueFromHub),
+		},
+	}
+	// Same preference as in the operator: the new fields win over the deprecated ones.
+	if dst.CA.BMC == nil && src.BMCCAName != "" {
+		dst.CA.BMC = &ResourceReference{
+			Name: src.BMCCAName,
+			Kind: ResourceKindSecret,
+		}
+	}
+	if dst.CA.Trusted == nil && src.TrustedCAName != "" {
+		dst.CA.Trusted = &ResourceReferenceWithKey{
+			ResourceReference: ResourceReference{
+				Name: src.TrustedCAName,
+				Kind: ResourceKindConfigMap,
+			},
+		}
+	}
+	return dst
+}
+
+func referenceWithKeyToHub(src *ResourceReferenceWithKey) *v1alpha1.ResourceReferenceWithKey {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.ResourceReferenceWithKey{
+		ResourceReference: v1alpha1.ResourceReference(src.ResourceReference),
+		Key:               src.Key,
+	}
+}
+
+func referenceWithKeyValueToHub(src ResourceReferenceWithKey) v1alpha1.ResourceReferenceWithKey {
+	return *referenceWithKeyToHub(&src)
+}
+
+func referenceWithKeyValueFromHub(src v1alpha1.ResourceReferenceWithKey) ResourceReferenceWithKey {
+	return *referenceWithKeyFromHub(&src)
+}
+
+func referenceWithKeyFromHub(src *v1alpha1.ResourceReferenceWithKey) *ResourceReferenceWithKey {
+	if src == nil {
+		return nil
+	}
+	return &ResourceReferenceWithKey{
+		ResourceReference: ResourceReference(src.ResourceReference),
+		Key:               src.Key,
+	}
+}
+
+func convertSlice[S, D any](src []S, convert func(S) D) []D {
+	if src == nil {
+		return nil
+	}
+	dst := make([]D, 0, len(src))
+	for _, item := range src {
+		dst = append(dst, convert(item))
+	}
+	return dst
+}
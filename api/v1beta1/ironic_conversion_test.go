@@ -0,0 +1,250 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/randfill"
+
+	"github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const fuzzIterations = 500
+
+func newFiller(seed int64) *randfill.Filler {
+	return randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3).MaxDepth(8).Funcs(
+		// Metadata is copied as a whole, no need to fuzz the time fields
+		func(meta *metav1.ObjectMeta, c randfill.Continue) {
+			meta.Name = c.String(0)
+			meta.Namespace = c.String(0)
+			c.Fill(&meta.Labels)
+			c.Fill(&meta.Annotations)
+		},
+		func(cond *metav1.Condition, c randfill.Continue) {
+			cond.Type = c.String(0)
+			cond.Status = metav1.ConditionStatus(c.String(0))
+			cond.Reason = c.String(0)
+			cond.Message = c.String(0)
+			cond.ObservedGeneration = c.Int63()
+		},
+	)
+}
+
+func TestRoundTripFromSpoke(t *testing.T) {
+	for seed := range int64(fuzzIterations) {
+		original := &Ironic{}
+		newFiller(seed).Fill(&original.ObjectMeta)
+		newFiller(seed).Fill(&original.Spec)
+		newFiller(seed).Fill(&original.Status)
+
+		hub := &v1alpha1.Ironic{}
+		ConvertToHub(original, hub)
+		result := &Ironic{}
+		ConvertFromHub(hub, result)
+
+		if !assert.Equal(t, original, result, "seed %d", seed) {
+			return
+		}
+	}
+}
+
+func TestRoundTripFromHub(t *testing.T) {
+	for seed := range int64(fuzzIterations) {
+		original := &v1alpha1.Ironic{}
+		newFiller(seed).Fill(&original.ObjectMeta)
+		newFiller(seed).Fill(&original.Spec)
+		newFiller(seed).Fill(&original.Status)
+		// Deprecated fields do not exist in v1beta1, see TestConvertDeprecatedFields
+		original.Spec.Networking.IPAddressManager = v1alpha1.IPAddressManagerNone //nolint:staticcheck // backward compat
+		original.Spec.TLS.BMCCAName = ""
+		original.Spec.TLS.TrustedCAName = ""
+
+		spoke := &Ironic{}
+		ConvertFromHub(original, spoke)
+		result := &v1alpha1.Ironic{}
+		ConvertToHub(spoke, result)
+
+		if !assert.Equal(t, original, result, "seed %d", seed) {
+			return
+		}
+	}
+}
+
+func TestConvertFromHub(t *testing.T) {
+	hub := &v1alpha1.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.IronicSpec{
+			Networking: v1alpha1.Networking{
+				APIPort:                6385,
+				ExternalCallbackURL:    "https://ironic.example.com:6385",
+				ImageServerExternalURL: "http://ironic.example.com:6180",
+				ImageServerPort:        6180,
+				ImageServerTLSPort:     6183,
+				Interface:              "eth0",
+				IPAddress:              "192.0.2.1",
+				PrometheusExporterPort: 9608,
+				RPCPort:                6189,
+			},
+			TLS: v1alpha1.TLS{
+				BMCCA: &v1alpha1.ResourceReference{
+					Name: "bmc-ca",
+					Kind: v1alpha1.ResourceKindSecret,
+				},
+				CertificateName: "ironic-tls",
+				InsecureRPC:     ptr.To(true),
+			},
+		},
+	}
+
+	expected := &Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: IronicSpec{
+			Networking: Networking{
+				External: ExternalAccess{
+					APIURL:         "https://ironic.example.com:6385",
+					ImageServerURL: "http://ironic.example.com:6180",
+				},
+				Interface: "eth0",
+				IPAddress: "192.0.2.1",
+				Ports: Ports{
+					API:                6385,
+					ImageServer:        6180,
+					ImageServerTLS:     6183,
+					PrometheusExporter: 9608,
+					RPC:                6189,
+				},
+			},
+			TLS: TLS{
+				CA: CACertificates{
+					BMC: &ResourceReference{
+						Name: "bmc-ca",
+						Kind: ResourceKindSecret,
+					},
+				},
+				CertificateName: "ironic-tls",
+				InsecureRPC:     ptr.To(true),
+			},
+		},
+	}
+
+	result := &Ironic{}
+	ConvertFromHub(hub, result)
+	assert.Equal(t, expected, result)
+}
+
+func TestConvertDeprecatedFields(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		Networking v1alpha1.Networking
+		TLS        v1alpha1.TLS
+
+		ExpectedNetworking Networking
+		ExpectedTLS        TLS
+		ExpectedHub        v1alpha1.IronicSpec
+	}{
+		{
+			Scenario: "ipAddressManager",
+			Networking: v1alpha1.Networking{
+				IPAddressManager: v1alpha1.IPAddressManagerKeepalived,
+			},
+			ExpectedNetworking: Networking{
+				Keepalived: &KeepalivedConfig{Enabled: true},
+			},
+			ExpectedHub: v1alpha1.IronicSpec{
+				Networking: v1alpha1.Networking{
+					Keepalived: &v1alpha1.KeepalivedConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			Scenario: "ipAddressManager with disabled keepalived",
+			Networking: v1alpha1.Networking{
+				IPAddressManager: v1alpha1.IPAddressManagerKeepalived,
+				Keepalived: &v1alpha1.KeepalivedConfig{
+					AdditionalVIPs: []v1alpha1.KeepalivedIP{{IPAddress: "192.0.2.2", Interface: "eth1"}},
+				},
+			},
+			ExpectedNetworking: Networking{
+				Keepalived: &KeepalivedConfig{
+					Enabled:        true,
+					AdditionalVIPs: []KeepalivedIP{{IPAddress: "192.0.2.2", Interface: "eth1"}},
+				},
+			},
+			ExpectedHub: v1alpha1.IronicSpec{
+				Networking: v1alpha1.Networking{
+					Keepalived: &v1alpha1.KeepalivedConfig{
+						Enabled:        true,
+						AdditionalVIPs: []v1alpha1.KeepalivedIP{{IPAddress: "192.0.2.2", Interface: "eth1"}},
+					},
+				},
+			},
+		},
+		{
+			Scenario: "bmcCAName and trustedCAName",
+			TLS: v1alpha1.TLS{
+				BMCCAName:     "bmc-ca",
+				TrustedCAName: "trusted-ca",
+			},
+			ExpectedTLS: TLS{
+				CA: CACertificates{
+					BMC: &ResourceReference{Name: "bmc-ca", Kind: ResourceKindSecret},
+					Trusted: &ResourceReferenceWithKey{
+						ResourceReference: ResourceReference{Name: "trusted-ca", Kind: ResourceKindConfigMap},
+					},
+				},
+			},
+			ExpectedHub: v1alpha1.IronicSpec{
+				TLS: v1alpha1.TLS{
+					BMCCA: &v1alpha1.ResourceReference{Name: "bmc-ca", Kind: v1alpha1.ResourceKindSecret},
+					TrustedCA: &v1alpha1.ResourceReferenceWithKey{
+						ResourceReference: v1alpha1.ResourceReference{Name: "trusted-ca", Kind: v1alpha1.ResourceKindConfigMap},
+					},
+				},
+			},
+		},
+		{
+			Scenario: "new fields take precedence",
+			TLS: v1alpha1.TLS{
+				BMCCA:         &v1alpha1.ResourceReference{Name: "new-bmc-ca", Kind: v1alpha1.ResourceKindConfigMap},
+				BMCCAName:     "bmc-ca",
+				TrustedCA:     &v1alpha1.ResourceReferenceWithKey{ResourceReference: v1alpha1.ResourceReference{Name: "new-trusted-ca", Kind: v1alpha1.ResourceKindSecret}},
+				TrustedCAName: "trusted-ca",
+			},
+			ExpectedTLS: TLS{
+				CA: CACertificates{
+					BMC:     &ResourceReference{Name: "new-bmc-ca", Kind: ResourceKindConfigMap},
+					Trusted: &ResourceReferenceWithKey{ResourceReference: ResourceReference{Name: "new-trusted-ca", Kind: ResourceKindSecret}},
+				},
+			},
+			ExpectedHub: v1alpha1.IronicSpec{
+				TLS: v1alpha1.TLS{
+					BMCCA:     &v1alpha1.ResourceReference{Name: "new-bmc-ca", Kind: v1alpha1.ResourceKindConfigMap},
+					TrustedCA: &v1alpha1.ResourceReferenceWithKey{ResourceReference: v1alpha1.ResourceReference{Name: "new-trusted-ca", Kind: v1alpha1.ResourceKindSecret}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			hub := &v1alpha1.Ironic{
+				Spec: v1alpha1.IronicSpec{
+					Networking: tc.Networking,
+					TLS:        tc.TLS,
+				},
+			}
+
+			spoke := &Ironic{}
+			ConvertFromHub(hub, spoke)
+			assert.Equal(t, tc.ExpectedNetworking, spoke.Spec.Networking)
+			assert.Equal(t, tc.ExpectedTLS, spoke.Spec.TLS)
+
+			result := &v1alpha1.Ironic{}
+			ConvertToHub(spoke, result)
+			assert.Equal(t, tc.ExpectedHub, result.Spec)
+		})
+	}
+}
@@ -0,0 +1,1235 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Inspection defines inspection settings.
+type Inspection struct {
+	// Collectors is a list of inspection collectors to enable.
+	// See https://docs.openstack.org/ironic-python-agent/latest/admin/how_it_works.html#inspection-data for details.
+	// +optional
+	Collectors []string `json:"collectors,omitempty"`
+
+	// List of interfaces to inspect for VLANs.
+	// This can be interface names (to collect all VLANs using LLDP) or pairs <interface>.<vlan ID>.
+	// +optional
+	VLANInterfaces []string `json:"vlanInterfaces,omitempty"`
+}
+
+// DHCPRange defines a single additional DHCP address range with per-range options.
+// Each range is assigned an auto-generated dnsmasq tag `range_N`, where N is
+// its 1-based position in the extraRanges list.
+type DHCPRange struct {
+	// NetworkCIDR is the CIDR of the provisioning network for this range.
+	NetworkCIDR string `json:"networkCIDR"`
+
+	// RangeBegin is the first IP that can be given to hosts. Must be inside NetworkCIDR.
+	RangeBegin string `json:"rangeBegin"`
+
+	// RangeEnd is the last IP that can be given to hosts. Must be inside NetworkCIDR.
+	RangeEnd string `json:"rangeEnd"`
+
+	// GatewayAddress is the IPv4 gateway advertised to clients in this range.
+	// When unset, no router is advertised to this range. Must be inside
+	// NetworkCIDR when set. IPv6 gateways are not supported here.
+	// +optional
+	GatewayAddress string `json:"gatewayAddress,omitempty"`
+}
+
+type DHCP struct {
+	// DNSAddress is the IP address of the DNS server to pass to hosts via DHCP.
+	// Must not be set together with ServeDNS.
+	// +optional
+	DNSAddress string `json:"dnsAddress,omitempty"`
+
+	// ExtraRanges is a list of additional DHCP address ranges served alongside
+	// the main range, e.g. for subnets reached via a DHCP relay. When set, the
+	// main networkCIDR/rangeBegin/rangeEnd fields become optional: leave them
+	// unset to serve only these ranges. Requires Ironic 37.0 or newer.
+	// +optional
+	ExtraRanges []DHCPRange `json:"extraRanges,omitempty"`
+
+	// GatewayAddress is the IP address of the gateway to pass to hosts via DHCP.
+	// It only applies to the main range: each ExtraRanges entry advertises a
+	// router only when its own gatewayAddress is set.
+	// +optional
+	GatewayAddress string `json:"gatewayAddress,omitempty"`
+
+	// Hosts is a set of DHCP host records to pass to dnsmasq.
+	// Check the dnsmasq documentation on dhcp-host for an explanation of the format.
+	// There is no API-side validation. Most users will leave this unset.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Ignore is set of dnsmasq tags to ignore and not provide any DHCP.
+	// Check the dnsmasq documentation on dhcp-ignore for an explanation of the format.
+	// There is no API-side validation. Most users will leave this unset.
+	// +optional
+	Ignore []string `json:"ignore,omitempty"`
+
+	// NetworkCIDR is a CIDR of the provisioning network. Required unless
+	// extraRanges is set, in which case it must be set together with
+	// rangeBegin and rangeEnd or left unset to serve only extraRanges.
+	// +optional
+	NetworkCIDR string `json:"networkCIDR,omitempty"`
+
+	// RangeBegin is the first IP that can be given to hosts. Must be inside NetworkCIDR.
+	// Required unless extraRanges is set. Must be set together with networkCIDR and rangeEnd.
+	// +optional
+	RangeBegin string `json:"rangeBegin,omitempty"`
+
+	// RangeEnd is the last IP that can be given to hosts. Must be inside NetworkCIDR.
+	// Required unless extraRanges is set. Must be set together with networkCIDR and rangeBegin.
+	// +optional
+	RangeEnd string `json:"rangeEnd,omitempty"`
+
+	// ServeDNS is set to true to pass the provisioning host as the DNS server on the provisioning network.
+	// Must not be set together with DNSAddress.
+	// +optional
+	ServeDNS bool `json:"serveDNS,omitempty"`
+}
+
+// Ingress defines ingress resource for Ironic services.
+type Ingress struct {
+	// Annotations to be added to Ingress resource
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// IngressClass of Ingress resource
+	// +optional
+	IngressClassName string `json:"ingressClassName,omitempty"`
+
+	// Host is the fully qualified domain name of a network host.
+	// This defines the hostname that the Ingress resource will route traffic for.
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
+// KeepalivedIP defines a virtual IP address to be managed by Keepalived.
+type KeepalivedIP struct {
+	// IPAddress is the virtual IP address to manage.
+	// +kubebuilder:validation:MinLength=1
+	IPAddress string `json:"ipAddress"`
+
+	// Interface is the Linux network interface on which to manage the IP.
+	// +kubebuilder:validation:MinLength=1
+	Interface string `json:"interface"`
+
+	// Prefix is the prefix length of the IP address (e.g. 24 for a /24 subnet).
+	// When not set, keepalived uses /32 for IPv4 and /128 for IPv6.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	Prefix *int32 `json:"prefix,omitempty"`
+}
+
+// KeepalivedConfig defines the Keepalived configuration for managing virtual IPs.
+type KeepalivedConfig struct {
+	// Enabled indicates whether Keepalived should be started to manage the virtual IP.
+	// When enabled, the main ipAddress and interface from the networking configuration
+	// are always included automatically.
+	Enabled bool `json:"enabled"`
+
+	// AdditionalVIPs is a list of additional virtual IPs to be managed by Keepalived,
+	// beyond the main ipAddress/interface from the networking configuration.
+	// Use this when you need Keepalived to manage IPs on additional network interfaces.
+	// +optional
+	AdditionalVIPs []KeepalivedIP `json:"additionalVIPs,omitempty"`
+}
+
+// Ports defines the ports used by the Ironic services.
+type Ports struct {
+	// API is the public port used for Ironic.
+	// +kubebuilder:default=6385
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	API int32 `json:"api,omitempty"`
+
+	// ImageServer is the public port used for serving images.
+	// +kubebuilder:default=6180
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ImageServer int32 `json:"imageServer,omitempty"`
+
+	// ImageServerTLS is the public port used for serving virtual media images over TLS.
+	// +kubebuilder:default=6183
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ImageServerTLS int32 `json:"imageServerTLS,omitempty"`
+
+	// PrometheusExporter is the port used for the Ironic Prometheus Exporter metrics endpoint.
+	// Only used when spec.prometheusExporter.enabled is true.
+	// +kubebuilder:default=9608
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PrometheusExporter int32 `json:"prometheusExporter,omitempty"`
+
+	// RPC is the internal RPC port used for Ironic.
+	// Only change this if the default value causes a conflict on your deployment.
+	// +kubebuilder:default=6189
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RPC int32 `json:"rpc,omitempty"`
+}
+
+// ExternalAccess defines how Ironic is accessed from outside of the cluster.
+// All settings here should only be used with virtual media deployments.
+type ExternalAccess struct {
+	// APIURL is the external URL of the Ironic API server.
+	// Set this option when your Ironic API server is not directly accessible.
+	// Setting this option, will override URL set by ingress.host.
+	// Must be set together with imageServerURL or ingress.
+	// Cannot be set at the same time with ip.
+	// +kubebuilder:validation:Format=uri
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// ImageServerURL is the external HTTP URL of the image server.
+	// Set this option when your image server is not directly accessible.
+	// Setting this option, will override URL set by ingress.host.
+	// Must be set together with apiURL or ingress.
+	// Cannot be set at the same time with ip.
+	// +kubebuilder:validation:Format=uri
+	// +optional
+	ImageServerURL string `json:"imageServerURL,omitempty"`
+
+	// Ingress configures an Ingress resource for Ironic services.
+	// Set this option when you are planning to deploy Ironic in a public cluster and willing to use Ingress instead of IP address on the Host Network.
+	// The API and the image server will be accessible via the hostname specified in the ingress configuration.
+	// Cannot be set at the same time with ip.
+	// +optional
+	Ingress *Ingress `json:"ingress,omitempty"`
+
+	// IP is used for accessing API and the image server from remote hosts.
+	// The IP will not be accessed from the cluster itself.
+	// Cannot be set at the same time with ingress, apiURL, or imageServerURL.
+	// +optional
+	IP string `json:"ip,omitempty"`
+}
+
+// Networking defines networking settings for Ironic.
+type Networking struct {
+	// BindInterface makes Ironic API bound to only one interface.
+	// Requires DisableHostNetwork to be false.
+	// +optional
+	BindInterface bool `json:"bindInterface,omitempty"`
+
+	// DHCP is a configuration of DHCP for the network boot service (dnsmasq).
+	// The service is only deployed when this is set.
+	// This setting is currently incompatible with the highly available architecture.
+	// Requires DisableHostNetwork to be false.
+	// +optional
+	DHCP *DHCP `json:"dhcp,omitempty"`
+
+	// DisableHostNetwork disables the use of host networking for Ironic pods.
+	// Disabling host networking makes network boot impossible.
+	// This should only be used with virtual media deployments.
+	// +kubebuilder:default=false
+	// +optional
+	DisableHostNetwork bool `json:"disableHostNetwork,omitempty"`
+
+	// External defines how Ironic is accessed from outside of the cluster.
+	// +optional
+	External ExternalAccess `json:"external,omitempty"`
+
+	// Interface is a Linux network device to listen on.
+	// Detected from IPAddress if missing.
+	// Requires DisableHostNetwork to be false.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+
+	// IPAddress is the main IP address to listen on and use for communication.
+	// Detected from Interface if missing. Cannot be provided for a highly available architecture.
+	// Requires DisableHostNetwork to be false.
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// Keepalived configures Keepalived to manage virtual IPs on the specified interfaces.
+	// When enabled, a Keepalived container will be started to manage the main ipAddress
+	// on the main interface, plus any additional VIPs listed in additionalVIPs.
+	// Requires DisableHostNetwork to be false.
+	// Warning: keepalived is not compatible with the highly available architecture.
+	// +optional
+	Keepalived *KeepalivedConfig `json:"keepalived,omitempty"`
+
+	// MACAddresses can be provided to make the start script pick the interface matching any of these addresses.
+	// Only set if no other options can be used.
+	// Requires DisableHostNetwork to be false.
+	// +optional
+	MACAddresses []string `json:"macAddresses,omitempty"`
+
+	// Ports defines the ports used by the Ironic services.
+	// +optional
+	Ports Ports `json:"ports,omitempty"`
+}
+
+// CPUArchitecture represents a CPU architecture supported by IPA.
+// +kubebuilder:validation:Enum="";x86_64;aarch64
+type CPUArchitecture string
+
+const (
+	ArchX86_64  CPUArchitecture = "x86_64"
+	ArchAarch64 CPUArchitecture = "aarch64"
+)
+
+// AgentImages defines a single IPA (Ironic Python Agent) image configuration.
+type AgentImages struct {
+	// Kernel is the URL of the IPA kernel image.
+	// Supported schemes: file://, http://, https://, oci://.
+	// file:// URLs must use absolute paths (e.g. "file:///shared/html/images/ironic-python-agent.kernel").
+	// +kubebuilder:validation:Format=uri
+	Kernel string `json:"kernel"`
+
+	// Initramfs is the URL of the IPA initramfs/ramdisk image.
+	// Supported schemes: file://, http://, https://, oci://.
+	// file:// URLs must use absolute paths (e.g. "file:///shared/html/images/ironic-python-agent.initramfs").
+	// +kubebuilder:validation:Format=uri
+	Initramfs string `json:"initramfs"`
+
+	// Architecture is the target CPU architecture.
+	// When empty, sets the default DEPLOY_KERNEL_URL/DEPLOY_RAMDISK_URL.
+	// +optional
+	Architecture CPUArchitecture `json:"architecture,omitempty"`
+}
+
+// DeployRamdisk defines IPA ramdisk settings.
+type DeployRamdisk struct {
+	// DisableDownloader tells the operator not to start the IPA downloader as the init container.
+	// The user will be responsible for providing the right image to BareMetal Operator.
+	// +optional
+	DisableDownloader bool `json:"disableDownloader,omitempty"`
+
+	// ExtraKernelParams is a string with kernel parameters to pass to the provisioning/inspection ramdisk.
+	// Will not take effect if the host uses a pre-built ISO (either through its PreprovisioningImage or via the DEPLOY_ISO_URL baremetal-operator parameter).
+	// +optional
+	ExtraKernelParams string `json:"extraKernelParams,omitempty"`
+
+	// SSHKey is the contents of the public key to inject into the ramdisk for debugging purposes.
+	// +optional
+	SSHKey string `json:"sshKey,omitempty"`
+}
+
+// CACertificates groups the CA certificates used to validate outgoing TLS connections.
+type CACertificates struct {
+	// BMC is a reference to a ConfigMap or Secret containing the CA certificate(s)
+	// to use when validating TLS connections to BMCs.
+	// Supported in Ironic 32.0 or newer.
+	// +optional
+	BMC *ResourceReference `json:"bmc,omitempty"`
+
+	// AdditionalBMC are references to more ConfigMaps or Secrets with CA
+	// certificates to use when validating TLS connections to BMCs.
+	// When set, the operator concatenates them with BMC into a generated
+	// ConfigMap and uses it instead. All keys of each resource are
+	// included unless Key is set. Only PEM certificates are copied, other
+	// data such as private keys is ignored.
+	// +listType=atomic
+	// +optional
+	AdditionalBMC []ResourceReferenceWithKey `json:"additionalBMC,omitempty"`
+
+	// Trusted is a reference to a ConfigMap or Secret containing the CA certificate(s)
+	// to use when validating TLS connections to image servers and other services.
+	// The resource should contain one or more CA certificates in PEM format.
+	// +optional
+	Trusted *ResourceReferenceWithKey `json:"trusted,omitempty"`
+
+	// AdditionalTrusted are references to more ConfigMaps or Secrets with
+	// CA certificates to use when validating TLS connections to image
+	// servers and other services. When set, the operator concatenates them
+	// with Trusted into a generated ConfigMap and uses it instead.
+	// All keys of each resource are included unless Key is set. Only PEM
+	// certificates are copied, other data such as private keys is ignored.
+	// +listType=atomic
+	// +optional
+	AdditionalTrusted []ResourceReferenceWithKey `json:"additionalTrusted,omitempty"`
+}
+
+// TLSVersion is a version of the TLS protocol. The names match the values
+// of the operator's --tls-min-version and --tls-max-version flags.
+// +kubebuilder:validation:Enum=VersionTLS12;VersionTLS13
+type TLSVersion string
+
+const (
+	TLSVersion12 TLSVersion = "VersionTLS12"
+	TLSVersion13 TLSVersion = "VersionTLS13"
+)
+
+// TLS defines the TLS settings.
+// +kubebuilder:validation:XValidation:rule="!has(self.selfSigned) || !has(self.certificateIssuer)",message="selfSigned and certificateIssuer cannot be used together"
+type TLS struct {
+	// CA groups the CA certificates used to validate outgoing TLS connections.
+	// +optional
+	CA CACertificates `json:"ca,omitempty"`
+
+	// CertificateName is a reference to the secret with the TLS certificate.
+	// Must contains both the certificate and the private key parts.
+	// +optional
+	CertificateName string `json:"certificateName,omitempty"`
+
+	// ClientCA is a reference to a ConfigMap or Secret containing the CA
+	// certificate(s) used to validate client certificates on the Ironic API.
+	// When set, httpd requires clients to present a certificate signed by
+	// this CA, except on the lookup and heartbeat endpoints used by the
+	// ramdisk agent. Requires TLS to be enabled and an Ironic image that
+	// supports the IRONIC_CLIENT_CA_FILE and IRONIC_CLIENT_CA_EXEMPT_PATHS
+	// variables. The operator verifies this on the running Ironic and
+	// reports the result in the ClientCertificatesVerified condition.
+	// +optional
+	ClientCA *ResourceReferenceWithKey `json:"clientCA,omitempty"`
+
+	// ClientCertificateOnly disables HTTP basic authentication on the
+	// Ironic API when ClientCA is set, so that a valid client certificate
+	// is sufficient. By default, clients need both a certificate and
+	// valid API credentials. Basic authentication is only disabled once
+	// the ClientCertificatesVerified condition is true, and is restored
+	// if a later verification fails.
+	// +optional
+	ClientCertificateOnly bool `json:"clientCertificateOnly,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted by the Ironic API,
+	// the image server and the internal RPC. Defaults to TLS 1.2.
+	// +optional
+	MinVersion TLSVersion `json:"minVersion,omitempty"`
+
+	// CipherSuites is a list of TLS 1.2 cipher suites accepted by the Ironic
+	// API, the image server and the internal RPC. Uses the same IANA names as
+	// the operator's --tls-cipher-suites flag, except that the suites it lists
+	// as insecure (e.g. RC4, 3DES or RSA key exchange) are rejected. Ignored
+	// when MinVersion is VersionTLS13. If omitted, the defaults of the Ironic
+	// image are used.
+	// +listType=atomic
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// DisableVirtualMediaTLS turns off TLS on the virtual media server,
+	// which may be required for hardware that cannot accept HTTPS links.
+	// +optional
+	DisableVirtualMediaTLS bool `json:"disableVirtualMediaTLS,omitempty"`
+
+	// InsecureRPC disables TLS validation for the internal RPC.
+	// Without it, the certificate must be valid for all IP addresses on
+	// which Ironic replicas may end up running.
+	// Has no effect when HighAvailability is false and requires the
+	// HighAvailability feature gate to be set.
+	// +optional
+	InsecureRPC *bool `json:"insecureRPC,omitempty"`
+
+	// SelfSigned enables TLS with a CA and a serving certificate generated
+	// and renewed by the operator. The serving certificate is valid for the
+	// service host name, the provisioning and external IP addresses, the
+	// keepalived VIPs and the ingress host. Only used when CertificateName
+	// is empty, the spec is not modified: the serving certificate is stored
+	// in the <name>-tls secret and the CA in the <name>-ca secret.
+	// The CA is valid for 10 years and is replaced, together with the serving
+	// certificate, when it expires within RenewBefore. A CAGenerated event is
+	// emitted in this case, and clients that trust the old CA have to be
+	// updated with the new one from the <name>-ca secret.
+	// +optional
+	SelfSigned *SelfSignedCertificate `json:"selfSigned,omitempty"`
+
+	// CertificateIssuer requests the serving certificate from cert-manager
+	// using this issuer. The operator creates a Certificate with the same
+	// host names and IP addresses as for SelfSigned, and waits for it to be
+	// ready before deploying Ironic. Only used when CertificateName is empty,
+	// the spec is not modified: the certificate is stored in the <name>-tls
+	// secret. The Certificate and its secret are deleted when this field is
+	// unset. Requires cert-manager to be installed.
+	// +optional
+	CertificateIssuer *IssuerReference `json:"certificateIssuer,omitempty"`
+
+	// CertificateExpirationThresholds are the times before the expiration of
+	// a certificate at which a warning event is emitted. The serving
+	// certificate, the CAs and the database certificate are checked.
+	// Defaults to 30 days, 7 days and 1 day.
+	// +optional
+	CertificateExpirationThresholds []metav1.Duration `json:"certificateExpirationThresholds,omitempty"`
+}
+
+// IssuerReference references a cert-manager issuer.
+type IssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind of the issuer: Issuer or ClusterIssuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, defaults to cert-manager.io.
+	// Can be changed to use an external issuer.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// SelfSignedCertificate configures a CA and a serving certificate generated
+// by the operator.
+type SelfSignedCertificate struct {
+	// Duration is the validity of the serving certificate.
+	// +kubebuilder:default="2160h"
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before its expiration the serving certificate
+	// is renewed. Must be shorter than the duration.
+	// +kubebuilder:default="720h"
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// SwitchportMode defines the switchport mode for network interfaces.
+type SwitchportMode string
+
+const (
+	// SwitchportModeAccess sets the interface to access mode (single VLAN).
+	SwitchportModeAccess SwitchportMode = "access"
+	// SwitchportModeTrunk sets the interface to trunk mode (multiple VLANs).
+	SwitchportModeTrunk SwitchportMode = "trunk"
+	// SwitchportModeHybrid sets the interface to hybrid mode (access + trunk).
+	SwitchportModeHybrid SwitchportMode = "hybrid"
+)
+
+// ProviderNetworkType defines the type of provider network.
+type ProviderNetworkType string
+
+const (
+	ProviderNetworkIdle         ProviderNetworkType = "idle"
+	ProviderNetworkInspection   ProviderNetworkType = "inspection"
+	ProviderNetworkCleaning     ProviderNetworkType = "cleaning"
+	ProviderNetworkRescuing     ProviderNetworkType = "rescuing"
+	ProviderNetworkServicing    ProviderNetworkType = "servicing"
+	ProviderNetworkProvisioning ProviderNetworkType = "provisioning"
+)
+
+// ProviderNetworkConfig defines the network configuration for Ironic service operations.
+type ProviderNetworkConfig struct {
+	// Type specifies which provider network this configuration applies to.
+	// +kubebuilder:validation:Enum=idle;inspection;cleaning;rescuing;servicing;provisioning
+	Type ProviderNetworkType `json:"type"`
+
+	// Mode specifies the switch port mode for service operations
+	// +kubebuilder:validation:Enum=access;trunk;hybrid
+	// +kubebuilder:default=access
+	Mode SwitchportMode `json:"mode"`
+
+	// NativeVLAN specifies the native VLAN ID for service operations
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	NativeVLAN int32 `json:"nativeVLAN"`
+
+	// AllowedVLANs specifies the list of allowed VLANs for trunk/hybrid modes.
+	// Each entry can be a single VLAN ID (e.g., "100") or a range (e.g., "100-200").
+	// +optional
+	AllowedVLANs []string `json:"allowedVLANs,omitempty"`
+
+	// MTU defines the MTU to be applied to the switch port configuration.
+	// +kubebuilder:validation:Minimum=1280
+	// +kubebuilder:validation:Maximum=9216
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+}
+
+// NetworkingService defines configuration for the Ironic Networking Service.
+type NetworkingService struct {
+	// Enabled enables the Ironic Networking Service integration
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// ProviderNetworks defines the provider network configurations for Ironic
+	ProviderNetworks []ProviderNetworkConfig `json:"providerNetworks,omitempty"`
+
+	// SwitchConfigSecretName optionally specifies the name of the secret containing
+	// switch configuration. If not specified, defaults to "<ironic-name>-switch-config".
+	// The secret must have the environment label to be used by the operator.
+	// The operator creates an empty secret if it does not exist, and the Baremetal
+	// Operator populates it from BareMetalSwitch CRDs.
+	// +optional
+	SwitchConfigSecretName string `json:"switchConfigSecretName,omitempty"`
+
+	// SwitchCredentialsSecretName optionally specifies the name of the secret containing
+	// additional switch credentials (e.g., SSH private keys for switches using publickey
+	// authentication). If not specified, defaults to "<ironic-name>-switch-credentials".
+	// The secret must have the environment label to be used by the operator.
+	// The operator creates an empty secret if it does not exist, and the Baremetal
+	// Operator populates it from BareMetalSwitch CRDs.
+	// +optional
+	SwitchCredentialsSecretName string `json:"switchCredentialsSecretName,omitempty"`
+}
+
+type Images struct {
+	// DeployRamdiskBranch is the branch of IPA to download. The main branch is used by default.
+	// Not used if deployRamdisk.disableDownloader is true.
+	// +optional
+	DeployRamdiskBranch string `json:"deployRamdiskBranch,omitempty"`
+
+	// DeployRamdiskDownloader is the image to be used at pod initialization to download the IPA ramdisk.
+	// Not used if deployRamdisk.disableDownloader is true.
+	// +optional
+	DeployRamdiskDownloader string `json:"deployRamdiskDownloader,omitempty"`
+
+	// Ironic is the Ironic image (including httpd).
+	// +optional
+	Ironic string `json:"ironic,omitempty"`
+
+	// Keepalived is the Keepalived image.
+	// Not used if networking.keepalived is not enabled.
+	// +optional
+	Keepalived string `json:"keepalived,omitempty"`
+
+	// DatabaseBackup is the image with the MariaDB client tools used for database backups.
+	// Not used if database.backup is not set.
+	// +optional
+	DatabaseBackup string `json:"databaseBackup,omitempty"`
+}
+
+// ExtraConfig allows overriding any Ironic configuration options.
+// See the entire listing of available options in the Ironic documentation:
+// https://docs.openstack.org/ironic/latest/configuration/config.html
+// (note that some options may not be available in earlier releases).
+//
+// Warning: modifying arbitrary options may cause your Ironic installation to
+// fail or misbehave. Do not modify anything you don't understand well.
+type ExtraConfig struct {
+	// The group that config belongs to.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// The name of the config.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The value of the config.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// Database is a reference to a MariaDB database to use.
+type Database struct {
+	// Name of a secret with database credentials.
+	CredentialsName string `json:"credentialsName"`
+
+	// IP address or host name of the database instance.
+	Host string `json:"host"`
+
+	// Database name.
+	Name string `json:"name"`
+
+	// Name of a secret with the a TLS certificate or a CA for verifying the database host.
+	// If unset, Ironic will request an unencrypted connections, which is insecure,
+	// and the server configuration may forbid it.
+	// +optional
+	TLSCertificateName string `json:"tlsCertificateName,omitempty"`
+
+	// Name of a secret with a TLS client certificate and key (tls.crt and
+	// tls.key) for authenticating to the database with mutual TLS.
+	// Requires TLSCertificateName.
+	// +optional
+	ClientCertificateName string `json:"clientCertificateName,omitempty"`
+
+	// Backup configures a backup of the database before each schema upgrade.
+	// +optional
+	Backup *DatabaseBackup `json:"backup,omitempty"`
+}
+
+// DatabaseBackup configures where database backups are stored.
+// +kubebuilder:validation:XValidation:rule="has(self.persistentVolumeClaimName) != has(self.objectStorage)",message="exactly one of persistentVolumeClaimName and objectStorage must be set"
+type DatabaseBackup struct {
+	// Name of a persistent volume claim to store backups in.
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// ObjectStorage configures an S3-compatible object storage to upload backups to.
+	// +optional
+	ObjectStorage *ObjectStorage `json:"objectStorage,omitempty"`
+
+	// Number of the most recent backups to keep. Older backups are removed
+	// after a new backup succeeds. Defaults to 3, at most 50.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	Retain int32 `json:"retain,omitempty"`
+}
+
+// ObjectStorage is a bucket in an S3-compatible object storage.
+type ObjectStorage struct {
+	// URL of the object storage endpoint, e.g. https://s3.example.com.
+	// Objects are addressed using the path style.
+	Endpoint string `json:"endpoint"`
+
+	// Name of the bucket to use.
+	Bucket string `json:"bucket"`
+
+	// Prefix to add to the object names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Name of a secret with the access key (accessKeyID) and
+	// the secret key (secretAccessKey).
+	CredentialsName string `json:"credentialsName"`
+}
+
+type Overrides struct {
+	// Extra annotations to add to each pod (including upgrade jobs).
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AgentImages overrides the default IPA images with custom per-architecture images.
+	// Consider setting deployRamdisk.disableDownloader=true when using custom images.
+	// +optional
+	AgentImages []AgentImages `json:"agentImages,omitempty"`
+
+	// Containers to append to the main Ironic pod.
+	// If a container name matches an existing container, the existing container is replaced.
+	// +optional
+	Containers []corev1.Container `json:"containers,omitempty"`
+
+	// HttpdLivenessProbe overrides the httpd container liveness probe.
+	// +optional
+	HttpdLivenessProbe *corev1.Probe `json:"httpdLivenessProbe,omitempty"`
+
+	// HttpdReadinessProbe overrides the httpd container readiness probe.
+	// +optional
+	HttpdReadinessProbe *corev1.Probe `json:"httpdReadinessProbe,omitempty"`
+
+	// InitContainers to append to the main Ironic pod.
+	// If a container name matches an existing init container, the existing init container is replaced.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Extra labels to add to each pod (including upgrade jobs).
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Volumes to add to the main Ironic pod (and upgrade jobs).
+	// Use this together with volumeMounts on overridden containers to mount
+	// additional ConfigMaps, Secrets or PersistentVolumeClaims.
+	// If a volume name matches an existing volume, the existing volume is replaced.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+}
+
+// PrometheusExporter defines configuration for Prometheus metrics export.
+type PrometheusExporter struct {
+	// DisableServiceMonitor controls whether a ServiceMonitor resource is created.
+	// Set to true if your cluster does not have prometheus-operator installed,
+	// or when you want to run the exporter but manage Prometheus configuration manually.
+	//
+	// Must be set to true for a highly available deployment. In this case, every replica
+	// provides different metrics, which must be aggregated on the consumer side.
+	// +optional
+	DisableServiceMonitor bool `json:"disableServiceMonitor,omitempty"`
+
+	// Enabled controls whether sensor data collection and metrics export is active.
+	// When true, configures Ironic to collect sensor data and deploys the
+	// ironic-prometheus-exporter container.
+	Enabled bool `json:"enabled"`
+
+	// BindAddress is the IP address the metrics endpoint listens on.
+	// Defaults to "0.0.0.0" to listen on all interfaces.
+	//
+	// Can be set to a specific IP address (e.g. the provisioning network IP)
+	// to limit exposure to a particular network interface, or to "127.0.0.1"
+	// to restrict access to the local host only (note: this makes
+	// ServiceMonitor-based scraping impossible).
+	// +kubebuilder:default="0.0.0.0"
+	// +optional
+	BindAddress string `json:"bindAddress,omitempty"`
+
+	// SensorCollectionInterval defines how often (in seconds) sensor data
+	// is collected from BMCs using Ironic. Must be at least 60 seconds.
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	SensorCollectionInterval int `json:"sensorCollectionInterval,omitempty"`
+}
+
+// CloudsYAML configures generation of a Secret with an OpenStack clouds.yaml
+// for accessing Ironic.
+type CloudsYAML struct {
+	// SecretName is the name of the Secret to create.
+	// Defaults to the name of the Ironic object with the -clouds-yaml suffix.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CloudName is the name of the cloud entry in clouds.yaml.
+	// +kubebuilder:default=ironic
+	// +optional
+	CloudName string `json:"cloudName,omitempty"`
+
+	// ExternalEndpoint causes the external URL of the API (ingress, external callback URL or
+	// external IP) to be used instead of the in-cluster service URL.
+	// The in-cluster URL is used if no external URL is configured.
+	// +optional
+	ExternalEndpoint bool `json:"externalEndpoint,omitempty"`
+
+	// CACertPath is the path where the CA certificate will be available to clients.
+	// The CA certificate is stored in the same Secret under the ca.crt key, the default value
+	// assumes that the Secret is mounted to /etc/openstack.
+	// Only used when TLS is enabled and the TLS secret contains a ca.crt key.
+	// +kubebuilder:default=/etc/openstack/ca.crt
+	// +optional
+	CACertPath string `json:"caCertPath,omitempty"`
+}
+
+// CredentialsRotation defines a policy for automatic rotation of credentials.
+type CredentialsRotation struct {
+	// Interval between two rotations, e.g. 720h for 30 days.
+	// The first rotation happens one interval after the credentials secret has been created.
+	Interval metav1.Duration `json:"interval"`
+}
+
+// MaintenanceWindow defines when disruptive changes to Ironic may be applied.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression marking the start of each window,
+	// e.g. "0 2 * * 6" for 2am every Saturday. The time zone is UTC unless
+	// a different one is set with the CRON_TZ= prefix.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration of each window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UpgradePolicy defines how upgrades of Ironic are carried out.
+type UpgradePolicy struct {
+	// NodeOperationsTimeout is the maximum time to wait for nodes to leave
+	// transient provision states (e.g. deploying, cleaning or inspecting)
+	// before Ironic is restarted with a new version or image. Once it
+	// passes, the upgrade proceeds regardless.
+	// Node states cannot be checked when TLS.ClientCA is set since the
+	// operator has no client certificate. The upgrade then always waits for
+	// the full timeout, set it to "0s" to upgrade right away instead.
+	// +kubebuilder:default="1h"
+	// +optional
+	NodeOperationsTimeout *metav1.Duration `json:"nodeOperationsTimeout,omitempty"`
+
+	// HistoryLimit is the number of finished upgrades to keep in the status.
+	// Defaults to 10, at most 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit int32 `json:"historyLimit,omitempty"`
+
+	// IntermediateImages maps versions to the Ironic images used to migrate
+	// the database when an upgrade with an external database skips them,
+	// e.g. {"37.0": "registry.example.com/metal3-io/ironic:release-37.0"}.
+	// Versions without an image here use their default image, which is only
+	// allowed when the target Ironic image is the default one as well.
+	// +optional
+	IntermediateImages map[string]string `json:"intermediateImages,omitempty"`
+}
+
+// IronicSpec defines the desired state of Ironic.
+// The most common mistakes are also validated in the CRD itself so that they
+// are rejected even when the validating webhook is not deployed.
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || !has(self.networking.disableHostNetwork) || !self.networking.disableHostNetwork || !((has(self.networking.bindInterface) && self.networking.bindInterface) || has(self.networking.dhcp) || (has(self.networking.interface) && size(self.networking.interface) > 0) || (has(self.networking.ipAddress) && size(self.networking.ipAddress) > 0) || (has(self.networking.macAddresses) && size(self.networking.macAddresses) > 0) || has(self.networking.keepalived))",message="networking.disableHostNetwork cannot be set to true together with networking.bindInterface or networking.dhcp or networking.interface or networking.ipAddress or networking.macAddresses or networking.keepalived"
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || !has(self.networking.external) || !has(self.networking.external.ip) || size(self.networking.external.ip) == 0 || !(has(self.networking.external.ingress) || (has(self.networking.external.apiURL) && size(self.networking.external.apiURL) > 0) || (has(self.networking.external.imageServerURL) && size(self.networking.external.imageServerURL) > 0))",message="networking.external.ip cannot be set together with networking.external.ingress or networking.external.apiURL or networking.external.imageServerURL"
+// +kubebuilder:validation:XValidation:rule="!has(self.networking) || !has(self.networking.external) || has(self.networking.external.ingress) || ((has(self.networking.external.apiURL) && size(self.networking.external.apiURL) > 0) == (has(self.networking.external.imageServerURL) && size(self.networking.external.imageServerURL) > 0))",message="when networking.external.ingress is not set, networking.external.apiURL and networking.external.imageServerURL must be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.tls) || !has(self.tls.insecureRPC) || (has(self.highAvailability) && self.highAvailability)",message="insecureRPC makes no sense without highAvailability"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.database) || !has(self.database) || (self.database.host == oldSelf.database.host && self.database.name == oldSelf.database.name)",message="cannot change to a new database"
+type IronicSpec struct {
+	// APICredentialsName is a reference to the secret with Ironic API credentials.
+	// A new secret will be created if this field is empty.
+	// +optional
+	APICredentialsName string `json:"apiCredentialsName,omitempty"`
+
+	// APICredentialsRotation enables automatic rotation of the API credentials.
+	// On each rotation, a new password is generated while the user name is
+	// kept. This also applies to a secret provided in APICredentialsName.
+	// Ironic reads the credentials from a mounted file that is updated in
+	// place, so the previous password stops working within a minute or two
+	// and clients must re-read the password from the secret. Ironic is only
+	// restarted when the credentials are also used for JSON RPC (e.g. with
+	// HighAvailability). Rotations are only applied inside the maintenance
+	// window and are postponed while nodes are in transient provision states,
+	// up to the node operations timeout of the upgrade policy.
+	// +optional
+	APICredentialsRotation *CredentialsRotation `json:"apiCredentialsRotation,omitempty"`
+
+	// ExtraAPICredentialsNames is a list of secrets with additional Ironic API
+	// credentials (username and password), e.g. for monitoring tools.
+	// Each user can be revoked separately by removing its secret from the list.
+	// The secrets are not modified, the htpasswd of all users is stored in
+	// the <name>-htpasswd secret managed by the operator.
+	// All users have the same access to the API as the main user: Ironic does
+	// not apply its policy to users authenticated with HTTP basic
+	// authentication, so read-only users are not supported.
+	// +listType=set
+	// +optional
+	ExtraAPICredentialsNames []string `json:"extraAPICredentialsNames,omitempty"`
+
+	// CloudsYAML enables generation of a Secret with an OpenStack clouds.yaml file
+	// that uses the API credentials and the effective API endpoint.
+	// The Secret is kept in sync when the API credentials change.
+	// +optional
+	CloudsYAML *CloudsYAML `json:"cloudsYAML,omitempty"`
+
+	// Database is a reference to a MariaDB database to use for persisting Ironic data.
+	// Must be provided for a highly available architecture, optional otherwise.
+	// If missing, a local SQLite database will be used, and the Ironic state will be reset on each pod restart.
+	// +optional
+	Database *Database `json:"database,omitempty"`
+
+	// DeployRamdisk defines settings for the provisioning/inspection ramdisk based on Ironic Python Agent.
+	// +optional
+	DeployRamdisk DeployRamdisk `json:"deployRamdisk,omitempty"`
+
+	// ExtraConfig allows overriding any Ironic configuration options.
+	// +optional
+	ExtraConfig []ExtraConfig `json:"extraConfig,omitempty"`
+
+	// HighAvailability causes Ironic to be deployed as a DaemonSet on control plane nodes instead of a deployment with 1 replica.
+	// Requires database to be installed and linked in the Database field.
+	// DHCP support is not yet implemented in the highly available architecture.
+	// Requires the HighAvailability feature gate to be set.
+	// +optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+
+	// Images is a collection of container images to deploy from.
+	// +optional
+	Images Images `json:"images,omitempty"`
+
+	// Inspection defines inspection settings.
+	// +optional
+	Inspection Inspection `json:"inspection,omitempty"`
+
+	// MaintenanceWindow restricts when version changes and changes to the
+	// Ironic pods are applied. Outside of the window, such changes are held
+	// and reported in the status. The initial deployment is not affected.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Networking defines networking settings for Ironic.
+	// +optional
+	Networking Networking `json:"networking,omitempty"`
+
+	// NetworkingService provides configuration for the Ironic Networking Service
+	// +optional
+	NetworkingService *NetworkingService `json:"networkingService,omitempty"`
+
+	// NodeSelector is a selector which must be true for the Ironic pod to fit on a node.
+	// Selector which must match a node's labels for the vmi to be scheduled on that node.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Overrides for the generated Deployment or Daemon Set.
+	// EXPERIMENTAL: requires feature gate Overrides.
+	// +optional
+	Overrides *Overrides `json:"overrides,omitempty"`
+
+	// Paused stops all changes to the Ironic workloads and the database
+	// migrations, while the status is still updated. The same effect can be
+	// achieved with the ironic.metal3.io/paused annotation.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// PrometheusExporter configures sensor data collection and Prometheus metrics export.
+	// When enabled, this configures Ironic to collect sensor data and deploys the
+	// ironic-prometheus-exporter container.
+	// +optional
+	PrometheusExporter *PrometheusExporter `json:"prometheusExporter,omitempty"`
+
+	// TLS defines TLS-related settings for various network interactions.
+	// +optional
+	TLS TLS `json:"tls,omitempty"`
+
+	// UpgradePolicy defines how upgrades of Ironic are carried out.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// Version is the version of Ironic to be installed.
+	// Must be either "latest" or a MAJOR.MINOR pair, e.g. "27.0".
+	// The default version depends on the operator branch.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// IronicEndpoints describes how the Ironic API and the image server can be reached.
+type IronicEndpoints struct {
+	// APIURLs are the URLs of the Ironic API. The first URL is always
+	// the in-cluster URL of the Ironic service, the others (if any) can be
+	// used from outside of the cluster.
+	// +optional
+	APIURLs []string `json:"apiURLs,omitempty"`
+
+	// ImageServerURLs are the URLs of the image server. The in-cluster
+	// URL of the Ironic service comes first (if the service exposes the
+	// image server), followed by the externally accessible URLs (if any).
+	// +optional
+	ImageServerURLs []string `json:"imageServerURLs,omitempty"`
+
+	// TLS is true when the Ironic API is served over HTTPS.
+	TLS bool `json:"tls"`
+
+	// CACertificate references the CA certificate that can be used to
+	// validate the Ironic API TLS certificate.
+	// Only set when TLS is active and such a certificate is known.
+	// +optional
+	CACertificate *ResourceReferenceWithKey `json:"caCertificate,omitempty"`
+}
+
+// APICredentialsStatus describes the state of the API credentials.
+type APICredentialsStatus struct {
+	// LastRotationTime is the time the credentials were last rotated by the operator.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime is the time of the next automatic rotation.
+	// Only set when automatic rotation is enabled.
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+}
+
+// CertificateType is the purpose of a certificate used by Ironic.
+type CertificateType string
+
+const (
+	// CertificateTypeServing is the certificate of the Ironic API and the image server.
+	CertificateTypeServing CertificateType = "Serving"
+	// CertificateTypeBMCCA is the CA used to validate BMC connections.
+	CertificateTypeBMCCA CertificateType = "BMCCA"
+	// CertificateTypeTrustedCA is the CA used to validate image servers and other services.
+	CertificateTypeTrustedCA CertificateType = "TrustedCA"
+	// CertificateTypeDatabase is the certificate used to connect to the database.
+	CertificateTypeDatabase CertificateType = "Database"
+	// CertificateTypeClientCA is the CA used to validate client certificates.
+	CertificateTypeClientCA CertificateType = "ClientCA"
+	// CertificateTypeDatabaseClient is the client certificate used to authenticate to the database.
+	CertificateTypeDatabaseClient CertificateType = "DatabaseClient"
+)
+
+// DatabaseBackupStatus describes a database backup made before an upgrade.
+type DatabaseBackupStatus struct {
+	// Location of the backup: a pvc://<claim>/<file> path or
+	// an object storage URL.
+	Location string `json:"location"`
+
+	// FromVersion is the version of Ironic before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the version of Ironic the database was upgraded to.
+	ToVersion string `json:"toVersion"`
+
+	// CompletionTime is the time when the backup was completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// CertificateStatus describes the expiration of a certificate used by Ironic.
+type CertificateStatus struct {
+	// Type of the certificate.
+	Type CertificateType `json:"type"`
+
+	// Kind of the resource with the certificate (ConfigMap or Secret).
+	Kind string `json:"kind"`
+
+	// Name of the resource with the certificate.
+	Name string `json:"name"`
+
+	// NotAfter is the earliest expiration time of the certificates in the resource.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// WarningThreshold is the smallest expiration threshold for which
+	// a warning has been emitted for this certificate.
+	// +optional
+	WarningThreshold *metav1.Duration `json:"warningThreshold,omitempty"`
+}
+
+// PendingChanges describes changes to the Ironic workload that have not been
+// applied yet.
+type PendingChanges struct {
+	// Reason why the changes are held: Paused or OutsideMaintenanceWindow.
+	Reason string `json:"reason"`
+
+	// Changes is a human-readable list of the held changes.
+	// +listType=atomic
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+
+	// NextWindowTime is the start of the next maintenance window.
+	// +optional
+	NextWindowTime *metav1.Time `json:"nextWindowTime,omitempty"`
+}
+
+// UpgradeOutcome is the result of a finished upgrade.
+// +kubebuilder:validation:Enum=Succeeded;Failed;RolledBack
+type UpgradeOutcome string
+
+const (
+	// UpgradeSucceeded means that the new version is up and running.
+	UpgradeSucceeded UpgradeOutcome = "Succeeded"
+	// UpgradeFailed means that one of the upgrade steps has failed.
+	UpgradeFailed UpgradeOutcome = "Failed"
+	// UpgradeRolledBack means that the database has been restored from
+	// the backup taken before the upgrade.
+	UpgradeRolledBack UpgradeOutcome = "RolledBack"
+)
+
+// UpgradeStatus describes an upgrade of Ironic to a new version or image.
+type UpgradeStatus struct {
+	// FromVersion is the version of Ironic installed before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the requested version of Ironic.
+	ToVersion string `json:"toVersion"`
+
+	// FromIronicImage is the Ironic image running before the upgrade.
+	// +optional
+	FromIronicImage string `json:"fromIronicImage,omitempty"`
+
+	// IronicImage is the requested Ironic image.
+	IronicImage string `json:"ironicImage"`
+
+	// RequestTime is when the upgrade has been requested.
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// StartTime is when the upgrade has been allowed to proceed. Unset while
+	// the upgrade is blocked by nodes in transient provision states.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Steps are the intermediate versions that the database is migrated
+	// through before migrating to the requested version.
+	// +listType=atomic
+	// +optional
+	Steps []UpgradeStep `json:"steps,omitempty"`
+
+	// CompletionTime is when the upgrade has finished or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Outcome of the upgrade, unset while it is in progress.
+	// +optional
+	Outcome UpgradeOutcome `json:"outcome,omitempty"`
+
+	// Jobs are the names of the jobs run during the upgrade.
+	// +listType=atomic
+	// +optional
+	Jobs []string `json:"jobs,omitempty"`
+}
+
+// UpgradeStep describes a migration of the database to an intermediate
+// version during an upgrade that skips versions.
+type UpgradeStep struct {
+	// Version of Ironic used for the migration.
+	Version string `json:"version"`
+
+	// IronicImage used for the migration.
+	IronicImage string `json:"ironicImage"`
+
+	// CompletionTime is when both the schema upgrade and the online data
+	// migrations have finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IronicStatus defines the observed state of Ironic.
+type IronicStatus struct {
+	// Conditions describe the state of the Ironic deployment.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// RequestedVersion identifies which version of Ironic was last requested.
+	RequestedVersion string `json:"requestedVersion,omitempty"`
+
+	// InstalledVersion identifies which version of Ironic was installed.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// RolledBackVersion is the version whose failed upgrade has been rolled
+	// back. The installed version is kept until a different version is
+	// requested or the upgrade is retried.
+	// +optional
+	RolledBackVersion string `json:"rolledBackVersion,omitempty"`
+
+	// PendingChanges describes the changes to the Ironic workload that are
+	// held because reconciliation is paused or outside the maintenance window.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+
+	// Upgrade describes the most recent upgrade of Ironic.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// UpgradeHistory lists the most recent finished upgrades, oldest first.
+	// +listType=atomic
+	// +optional
+	UpgradeHistory []UpgradeStatus `json:"upgradeHistory,omitempty"`
+
+	// Endpoints describes how the Ironic API and the image server can be
+	// reached. Populated once the Ironic service has been created.
+	// +optional
+	Endpoints *IronicEndpoints `json:"endpoints,omitempty"`
+
+	// APICredentials describes the state of the API credentials.
+	// +optional
+	APICredentials *APICredentialsStatus `json:"apiCredentials,omitempty"`
+
+	// Certificates describes the expiration of the certificates used by Ironic.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// DatabaseBackups lists the most recent database backups, oldest first.
+	// +listType=atomic
+	// +optional
+	DatabaseBackups []DatabaseBackupStatus `json:"databaseBackups,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Requested Version",type="string",JSONPath=".status.requestedVersion",description="Currently requested version",priority=1
+//+kubebuilder:printcolumn:name="Installed Version",type="string",JSONPath=".status.installedVersion",description="Currently installed version"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Is ready"
+
+// Ironic is the Schema for the ironics API.
+type Ironic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IronicSpec   `json:"spec,omitempty"`
+	Status IronicStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IronicList contains a list of Ironic.
+type IronicList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ironic `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &Ironic{}, &IronicList{})
+}
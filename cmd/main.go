@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	metal3iov1alpha1 "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	metal3iov1beta1 "github.com/metal3-io/ironic-standalone-operator/api/v1beta1"
 	"github.com/metal3-io/ironic-standalone-operator/internal/controller"
 	webhookv1alpha1 "github.com/metal3-io/ironic-standalone-operator/internal/webhook/v1alpha1"
 	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
@@ -58,13 +60,15 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(metal3iov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(metal3iov1beta1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 const (
-	TLSVersion12       = "TLS12"
-	TLSVersion13       = "TLS13"
+	TLSVersion12       = "VersionTLS12"
+	TLSVersion13       = "VersionTLS13"
 	defaultWebhookPort = 9443
 )
 
@@ -125,6 +129,8 @@ func main() {
 		"Ramdisk downloader image to install.")
 	flag.StringVar(&ironicImages.Keepalived, "keepalived-image", os.Getenv("KEEPALIVED_IMAGE"),
 		"Keepalived image to install.")
+	flag.StringVar(&ironicImages.DatabaseBackup, "database-backup-image", os.Getenv("DATABASE_BACKUP_IMAGE"),
+		"Image with the MariaDB client tools to use for database backups.")
 	flag.StringVar(&ironicVersion, "ironic-version", os.Getenv("IRONIC_VERSION"),
 		"Branch of Ironic that the operator installs.")
 
@@ -305,10 +311,11 @@ func GetTLSOptionOverrideFuncs(options TLSOptions) ([]func(*tls.Config), error)
 func GetTLSVersion(version string) (uint16, error) {
 	var v uint16
 
+	// TLS12 and TLS13 are accepted for compatibility with older releases.
 	switch version {
-	case TLSVersion12:
+	case TLSVersion12, "TLS12":
 		v = tls.VersionTLS12
-	case TLSVersion13:
+	case TLSVersion13, "TLS13":
 		v = tls.VersionTLS13
 	default:
 		return 0, fmt.Errorf("unexpected TLS version %q (must be one of: %s)", version, strings.Join(tlsSupportedVersions, ", "))
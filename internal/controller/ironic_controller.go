@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -30,6 +32,7 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -37,6 +40,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
 	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
@@ -53,6 +59,9 @@ type IronicReconciler struct {
 	Domain        string
 	VersionInfo   ironic.VersionInfo
 	EventRecorder events.EventRecorder
+
+	// Whether the cert-manager Certificate CRD is available.
+	hasCertManager bool
 }
 
 const (
@@ -63,6 +72,15 @@ const (
 	eventReasonIronicNotReady    = "IronicNotReady"
 	eventReasonInvalidLinkedRes  = "InvalidLinkedResource"
 	eventReasonAPISecretCreated  = "APISecretCreated"
+	eventReasonAPISecretRotated  = "APISecretRotated"
+	eventReasonCertificateIssued = "CertificateIssued"
+	eventReasonCAGenerated       = "CAGenerated"
+	eventReasonCertExpiring      = "CertificateExpiring"
+	eventReasonUpgradeRetried    = "UpgradeRetried"
+	eventReasonUpgradeRolledBack = "UpgradeRolledBack"
+	eventReasonRollbackFailed    = "RollbackFailed"
+	eventReasonChangesHeld       = "ChangesHeld"
+	eventReasonJobFailed         = "JobFailed"
 	eventActionReconciling       = "Reconciling"
 )
 
@@ -73,11 +91,13 @@ const (
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;update
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -110,12 +130,80 @@ func (r *IronicReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	now := time.Now()
+	var requeueAfter time.Duration
+	// Held rotations are applied once the changes are released
+	if ironicConf.Status.PendingChanges == nil {
+		requeueAfter = nextRotationEvent(ironicConf.Status.APICredentials, now)
+	}
+	if certAfter := nextCertificateEvent(ironicConf, now); certAfter > 0 && (requeueAfter == 0 || certAfter < requeueAfter) {
+		requeueAfter = certAfter
+	}
+	if windowAfter := nextMaintenanceWindowEvent(ironicConf, now); windowAfter > 0 && (requeueAfter == 0 || windowAfter < requeueAfter) {
+		requeueAfter = windowAfter
+	}
+	if checkAfter := nextDatabaseCheckEvent(ironicConf); checkAfter > 0 && (requeueAfter == 0 || checkAfter < requeueAfter) {
+		requeueAfter = checkAfter
+	}
+	if requeueAfter > 0 {
+		logger.Info("object has been fully reconciled, waiting for the next time-based event", "RequeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	logger.Info("object has been fully reconciled")
 	return ctrl.Result{}, nil
 }
 
+// rotationRetryInterval is how often postponed changes to the API
+// credentials are retried.
+const rotationRetryInterval = time.Minute
+
+// nextRotationEvent returns the time until the API credentials need to be
+// rotated, zero if never.
+func nextRotationEvent(status *metal3api.APICredentialsStatus, now time.Time) time.Duration {
+	if status == nil || status.NextRotationTime == nil {
+		return 0
+	}
+
+	// Rotations in the past have been postponed because of node operations
+	until := status.NextRotationTime.Sub(now)
+	if until <= 0 {
+		until = rotationRetryInterval
+	}
+	return until
+}
+
+// nextDatabaseCheckEvent returns the time until the database check is
+// retried, zero if the database is not known to be unreachable.
+func nextDatabaseCheckEvent(ironicConf *metal3api.Ironic) time.Duration {
+	cond := meta.FindStatusCondition(ironicConf.Status.Conditions, string(metal3api.IronicStatusDatabaseMigrated))
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != metal3api.IronicReasonDatabaseUnreachable {
+		return 0
+	}
+	return ironic.DatabaseCheckRetryInterval
+}
+
+// nextCertificateEvent returns the time until one of the certificates crosses
+// an expiration warning threshold, zero if never.
+func nextCertificateEvent(ironicConf *metal3api.Ironic, now time.Time) time.Duration {
+	thresholds := ironic.CertificateExpirationThresholds(&ironicConf.Spec.TLS)
+
+	var result time.Duration
+	for _, cert := range ironicConf.Status.Certificates {
+		eventTime, found := ironic.NextExpirationThreshold(cert.NotAfter.Time, now, thresholds)
+		if !found {
+			continue
+		}
+		until := max(eventTime.Sub(now), time.Second)
+		if result == 0 || until < result {
+			result = until
+		}
+	}
+	return result
+}
+
 func (r *IronicReconciler) setNotReady(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, reason, message string) error {
-	setCondition(&ironicConf.Status.Conditions, ironicConf.Generation,
+	setCondition(&ironicConf.Status.Conditions, metal3api.IronicStatusReady, ironicConf.Generation,
 		false, reason, message)
 
 	err := cctx.Client.Status().Update(cctx.Context, ironicConf)
@@ -142,7 +230,8 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		return r.cleanUp(cctx, ironicConf)
 	}
 
-	versionInfo, err := cctx.VersionInfo.WithIronicOverrides(ironicConf)
+	defaultVersionInfo := cctx.VersionInfo
+	versionInfo, err := defaultVersionInfo.WithIronicOverrides(ironicConf)
 	if err != nil {
 		// This condition requires a user's intervention
 		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
@@ -151,6 +240,27 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 	}
 	cctx.VersionInfo = versionInfo
 
+	requeue, err = r.handleUpgradeRetry(cctx, ironicConf)
+	if requeue || err != nil {
+		return requeue, err
+	}
+
+	if rolledBackVersion := ironicConf.Status.RolledBackVersion; rolledBackVersion != "" {
+		if rolledBackVersion == cctx.VersionInfo.InstalledVersion.String() {
+			// Stay on the installed version until the upgrade is retried
+			cctx.VersionInfo, err = installedVersionInfo(defaultVersionInfo, ironicConf)
+			if err != nil {
+				return false, err
+			}
+		} else {
+			cctx.Logger.Info("a different version requested after rollback", "RolledBackVersion", rolledBackVersion)
+			ironicConf.Status.RolledBackVersion = ""
+			if err = cctx.Client.Status().Update(cctx.Context, ironicConf); err != nil {
+				return false, err
+			}
+		}
+	}
+
 	actuallyRequestedVersion := cctx.VersionInfo.InstalledVersion.String()
 	if actuallyRequestedVersion != ironicConf.Status.InstalledVersion && actuallyRequestedVersion != ironicConf.Status.RequestedVersion {
 		// Ironic does not support downgrades when a real external database is used.
@@ -179,13 +289,40 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonVersionChange, "Version change requested from %s to %s", ironicConf.Status.InstalledVersion, actuallyRequestedVersion)
 	}
 
+	// Secrets used by the running pods must not change while changes to
+	// the workload are held.
+	now := time.Now()
+	heldReason, err := ironic.ChangesHeldReason(ironicConf, now)
+	if err != nil {
+		return false, err
+	}
+
 	apiSecret, requeue, err := r.ensureAPISecret(cctx, ironicConf)
 	if requeue || err != nil {
 		return requeue, err
 	}
 
+	if ironicConf.Spec.TLS.CertificateIssuer == nil && r.hasCertManager && heldReason == "" {
+		if err = ironic.RemoveCertificate(cctx, ironicConf); err != nil {
+			return true, err
+		}
+	}
+
+	if ironicConf.Spec.TLS.SelfSigned != nil {
+		requeue, err = r.ensureSelfSignedCertificate(cctx, ironicConf, heldReason)
+		if requeue || err != nil {
+			return requeue, err
+		}
+	} else if ironicConf.Spec.TLS.CertificateIssuer != nil {
+		var issued bool
+		issued, requeue, err = r.ensureCertManagerCertificate(cctx, ironicConf)
+		if !issued || requeue || err != nil {
+			return requeue, err
+		}
+	}
+
 	var tlsSecret *corev1.Secret
-	if tlsSecretName := ironicConf.Spec.TLS.CertificateName; tlsSecretName != "" {
+	if tlsSecretName := ironic.ServingCertificateName(ironicConf); tlsSecretName != "" {
 		tlsSecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, tlsSecretName)
 		if requeue || err != nil {
 			return requeue, err
@@ -194,36 +331,92 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 
 	var bmcCASecret *corev1.Secret
 	var bmcCAConfigMap *corev1.ConfigMap
-	if bmcCARef := ironic.GetBMCCA(&ironicConf.Spec.TLS); bmcCARef != nil {
-		switch bmcCARef.Kind {
-		case metal3api.ResourceKindSecret:
-			bmcCASecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, bmcCARef.Name)
-		case metal3api.ResourceKindConfigMap:
-			bmcCAConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, bmcCARef.Name)
-		default:
-			err = fmt.Errorf("unexpected resource kind %q", bmcCARef.Kind)
-		}
+	if bundleSources := ironic.GetBMCCABundleSources(&ironicConf.Spec.TLS); bundleSources != nil {
+		bmcCAConfigMap, requeue, err = r.ensureCABundle(cctx, ironicConf, ironic.BMCCABundleName(ironicConf), bundleSources, heldReason)
 		if requeue || err != nil {
 			return requeue, err
 		}
+	} else {
+		if err = r.removeCABundle(cctx, ironicConf, ironic.BMCCABundleName(ironicConf)); err != nil {
+			return false, err
+		}
+		if bmcCARef := ironic.GetBMCCA(&ironicConf.Spec.TLS); bmcCARef != nil {
+			switch bmcCARef.Kind {
+			case metal3api.ResourceKindSecret:
+				bmcCASecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, bmcCARef.Name)
+			case metal3api.ResourceKindConfigMap:
+				bmcCAConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, bmcCARef.Name)
+			default:
+				err = fmt.Errorf("unexpected resource kind %q", bmcCARef.Kind)
+			}
+			if requeue || err != nil {
+				return requeue, err
+			}
+		}
 	}
 
 	var trustedCASecret *corev1.Secret
 	var trustedCAConfigMap *corev1.ConfigMap
-	if trustedCARef := ironic.GetTrustedCA(&ironicConf.Spec.TLS); trustedCARef != nil {
-		switch trustedCARef.Kind {
+	if bundleSources := ironic.GetTrustedCABundleSources(&ironicConf.Spec.TLS); bundleSources != nil {
+		trustedCAConfigMap, requeue, err = r.ensureCABundle(cctx, ironicConf, ironic.TrustedCABundleName(ironicConf), bundleSources, heldReason)
+		if requeue || err != nil {
+			return requeue, err
+		}
+	} else {
+		if err = r.removeCABundle(cctx, ironicConf, ironic.TrustedCABundleName(ironicConf)); err != nil {
+			return false, err
+		}
+		if trustedCARef := ironic.GetTrustedCA(&ironicConf.Spec.TLS); trustedCARef != nil {
+			switch trustedCARef.Kind {
+			case metal3api.ResourceKindSecret:
+				trustedCASecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, trustedCARef.Name)
+			case metal3api.ResourceKindConfigMap:
+				trustedCAConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, trustedCARef.Name)
+			default:
+				err = fmt.Errorf("unexpected resource kind %q", trustedCARef.Kind)
+			}
+			if requeue || err != nil {
+				return requeue, err
+			}
+		}
+	}
+
+	var clientCASecret *corev1.Secret
+	var clientCAConfigMap *corev1.ConfigMap
+	if clientCARef := ironicConf.Spec.TLS.ClientCA; clientCARef != nil {
+		switch clientCARef.Kind {
 		case metal3api.ResourceKindSecret:
-			trustedCASecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, trustedCARef.Name)
+			clientCASecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, clientCARef.Name)
 		case metal3api.ResourceKindConfigMap:
-			trustedCAConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, trustedCARef.Name)
+			clientCAConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, clientCARef.Name)
 		default:
-			err = fmt.Errorf("unexpected resource kind %q", trustedCARef.Kind)
+			err = fmt.Errorf("unexpected resource kind %q", clientCARef.Kind)
 		}
 		if requeue || err != nil {
 			return requeue, err
 		}
 	}
 
+	var databaseSecret, databaseTLSSecret, databaseClientSecret *corev1.Secret
+	if db := ironicConf.Spec.Database; db != nil {
+		databaseSecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, db.CredentialsName)
+		if requeue || err != nil {
+			return requeue, err
+		}
+		if db.TLSCertificateName != "" {
+			databaseTLSSecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, db.TLSCertificateName)
+			if requeue || err != nil {
+				return requeue, err
+			}
+		}
+		if db.ClientCertificateName != "" {
+			databaseClientSecret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, db.ClientCertificateName)
+			if requeue || err != nil {
+				return requeue, err
+			}
+		}
+	}
+
 	switchConfigSecret, switchCredentialsSecret, err := ironic.EnsureNetworkingSwitchSecrets(cctx, ironicConf, r.APIReader)
 	if err != nil {
 		// Only missing-label and NotFound errors require user intervention.
@@ -246,6 +439,15 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		TrustedCAConfigMap:      trustedCAConfigMap,
 		SwitchConfigSecret:      switchConfigSecret,
 		SwitchCredentialsSecret: switchCredentialsSecret,
+		ClientCASecret:          clientCASecret,
+		ClientCAConfigMap:       clientCAConfigMap,
+		DatabaseSecret:          databaseSecret,
+		DatabaseTLSSecret:       databaseTLSSecret,
+		DatabaseClientSecret:    databaseClientSecret,
+	}
+
+	if _, ok := ironicConf.Annotations[metal3api.IronicRollbackUpgradeAnnotation]; ok {
+		return r.handleUpgradeRollback(cctx, resources, heldReason)
 	}
 
 	if versionErr := ironic.CheckVersion(resources, cctx.VersionInfo.InstalledVersion); versionErr != nil {
@@ -254,6 +456,23 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		return false, nil
 	}
 
+	apiCredentialsStatus := ironic.GetAPICredentialsStatus(apiSecret, ironicConf.Spec.APICredentialsRotation)
+	certificates := r.checkCertificates(ironicConf, resources, now)
+
+	if heldReason != "" {
+		var held bool
+		held, requeue, err = r.holdWorkloadChanges(cctx, resources, heldReason,
+			ironic.Status{APICredentials: apiCredentialsStatus, Certificates: certificates}, now)
+		if held || err != nil {
+			return requeue, err
+		}
+	}
+
+	requeue, err = r.rotateAPICredentials(cctx, resources, now)
+	if requeue || err != nil {
+		return requeue, err
+	}
+
 	// Manage networking service deployment
 	networkingStatus, err := ironic.EnsureIronicNetworking(cctx, resources)
 	if err != nil {
@@ -261,8 +480,12 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		return requeue, err
 	}
 	if !networkingStatus.IsReady() {
-		cctx.Logger.Info(networkingStatus.String())
-		return true, nil
+		status := networkingStatus
+		status.APICredentials = apiCredentialsStatus
+		status.Certificates = certificates
+		status.Components = ironic.Components{metal3api.IronicStatusNetworkingServiceReady: networkingStatus}
+		_, err = r.updateIronicStatus(cctx, ironicConf, status, actuallyRequestedVersion)
+		return true, err
 	}
 
 	status, err := ironic.EnsureIronic(cctx, resources)
@@ -270,6 +493,9 @@ func (r *IronicReconciler) handleIronic(cctx ironic.ControllerContext, ironicCon
 		cctx.Logger.Error(err, "potentially transient error, will retry")
 		return requeue, err
 	}
+	status.Components[metal3api.IronicStatusNetworkingServiceReady] = networkingStatus
+	status.APICredentials = apiCredentialsStatus
+	status.Certificates = certificates
 
 	return r.updateIronicStatus(cctx, ironicConf, status, actuallyRequestedVersion)
 }
@@ -282,13 +508,36 @@ func (r *IronicReconciler) updateIronicStatus(cctx ironic.ControllerContext, iro
 	if status.IsReady() {
 		newStatus.InstalledVersion = requestedVersion
 	}
+	if status.Endpoints != nil {
+		newStatus.Endpoints = status.Endpoints
+	}
+	newStatus.APICredentials = status.APICredentials
+	newStatus.Certificates = status.Certificates
+	newStatus.PendingChanges = nil
+	if status.Upgrade != nil {
+		newStatus.Upgrade = status.Upgrade
+		if err := finishUpgrade(cctx, ironicConf, newStatus, status); err != nil {
+			return false, err
+		}
+	}
+	if status.DatabaseBackup != nil {
+		var retain int32
+		if db := ironicConf.Spec.Database; db != nil && db.Backup != nil {
+			retain = db.Backup.Retain
+		}
+		newStatus.DatabaseBackups = ironic.RecordDatabaseBackup(newStatus.DatabaseBackups, *status.DatabaseBackup, retain)
+	}
 	newReady := isStatusReady(newStatus)
 
 	if !apiequality.Semantic.DeepEqual(newStatus, &ironicConf.Status) {
 		cctx.Logger.Info("updating status", "Status", newStatus)
+		jobFailure := newJobFailure(&ironicConf.Status, newStatus, status)
 		ironicConf.Status = *newStatus
 		err := cctx.Client.Status().Update(cctx.Context, ironicConf)
 		if err == nil {
+			if jobFailure != nil {
+				r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonJobFailed, "%s", jobFailure)
+			}
 			if newReady && !oldReady {
 				r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonIronicReady, "Ironic deployment is now ready")
 			} else if !newReady && oldReady {
@@ -305,6 +554,66 @@ func (r *IronicReconciler) updateIronicStatus(cctx ironic.ControllerContext, iro
 	return requeue, nil
 }
 
+// newJobFailure returns the failed job reported by EnsureIronic unless the
+// failure is already reflected in the Ready condition.
+func newJobFailure(oldStatus, newStatus *metal3api.IronicStatus, status ironic.Status) *ironic.JobFailedError {
+	var jobErr *ironic.JobFailedError
+	if !errors.As(status.Fatal, &jobErr) {
+		return nil
+	}
+
+	oldCond := meta.FindStatusCondition(oldStatus.Conditions, string(metal3api.IronicStatusReady))
+	newCond := meta.FindStatusCondition(newStatus.Conditions, string(metal3api.IronicStatusReady))
+	if oldCond != nil && newCond != nil && oldCond.Message == newCond.Message {
+		return nil
+	}
+	return jobErr
+}
+
+// checkCertificates returns the expiration status of the certificates,
+// updates the metrics and emits a warning event every time a certificate
+// crosses the next expiration threshold.
+func (r *IronicReconciler) checkCertificates(ironicConf *metal3api.Ironic, resources ironic.Resources, now time.Time) []metal3api.CertificateStatus {
+	certificates := ironic.GetCertificateStatuses(resources)
+	setCertificateMetrics(ironicConf, certificates)
+
+	thresholds := ironic.CertificateExpirationThresholds(&ironicConf.Spec.TLS)
+	for idx := range certificates {
+		cert := &certificates[idx]
+		threshold, crossed := ironic.CrossedExpirationThreshold(cert.NotAfter.Time, now, thresholds)
+		if !crossed {
+			continue
+		}
+		cert.WarningThreshold = &metav1.Duration{Duration: threshold}
+
+		previous := findCertificateStatus(ironicConf.Status.Certificates, cert.Type)
+		if previous != nil && previous.NotAfter.Equal(&cert.NotAfter) &&
+			previous.WarningThreshold != nil && previous.WarningThreshold.Duration <= threshold {
+			// Already reported
+			continue
+		}
+
+		if threshold == 0 {
+			r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonCertExpiring,
+				"%s certificate from %s %s has expired at %s", cert.Type, cert.Kind, cert.Name, cert.NotAfter.UTC().Format(time.RFC3339))
+		} else {
+			r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonCertExpiring,
+				"%s certificate from %s %s expires in less than %s (at %s)", cert.Type, cert.Kind, cert.Name, threshold, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return certificates
+}
+
+func findCertificateStatus(certificates []metal3api.CertificateStatus, certType metal3api.CertificateType) *metal3api.CertificateStatus {
+	for idx := range certificates {
+		if certificates[idx].Type == certType {
+			return &certificates[idx]
+		}
+	}
+	return nil
+}
+
 // Get a secret and update its owner references using SecretManager.
 // This ensures the secret is labeled for cache filtering and has owner references set.
 // Only returns a valid pointer if requeue is false and err is nil.
@@ -364,6 +673,8 @@ func (r *IronicReconciler) getConfigMap(cctx ironic.ControllerContext, ironicCon
 	return configMap, false, nil
 }
 
+// ensureAPISecret generates or updates the API credentials. Rotation is
+// handled separately by rotateAPICredentials.
 func (r *IronicReconciler) ensureAPISecret(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic) (apiSecret *corev1.Secret, requeue bool, err error) {
 	if ironicConf.Spec.APICredentialsName == "" {
 		apiSecret, err = generateSecret(cctx, ironicConf, &ironicConf.ObjectMeta, "service", true)
@@ -398,9 +709,251 @@ func (r *IronicReconciler) ensureAPISecret(cctx ironic.ControllerContext, ironic
 	if requeue {
 		cctx.Logger.Info("updating htpasswd", "Secret", apiSecret.Name)
 		err = cctx.Client.Update(cctx.Context, apiSecret)
+		return apiSecret, true, err
+	}
+
+	userSecrets := make([]*corev1.Secret, 0, len(ironicConf.Spec.ExtraAPICredentialsNames))
+	for _, name := range ironicConf.Spec.ExtraAPICredentialsNames {
+		userSecret, userRequeue, userErr := r.getAndUpdateSecret(cctx, ironicConf, name)
+		if userRequeue || userErr != nil {
+			return nil, userRequeue, userErr
+		}
+		userSecrets = append(userSecrets, userSecret)
+	}
+
+	// The user-provided secrets are not modified apart from the htpasswd of
+	// the API secret, the combined htpasswd is kept in a separate secret.
+	err = ironic.EnsureHtpasswdSecret(cctx, ironicConf, apiSecret, userSecrets)
+	if err != nil {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
+		return nil, true, err
+	}
+
+	return apiSecret, false, nil
+}
+
+// rotateAPICredentials rotates the API credentials when they are due. The
+// previous password stops working right away, so the rotation is postponed
+// while nodes are in transient provision states, up to the node operations
+// timeout. Must not be called while changes to the workload are held.
+func (r *IronicReconciler) rotateAPICredentials(cctx ironic.ControllerContext, resources ironic.Resources, now time.Time) (requeue bool, err error) {
+	ironicConf := resources.Ironic
+	apiSecret := resources.APISecret
+	rotation := ironicConf.Spec.APICredentialsRotation
+	dueSince := ironic.CredentialChangesDueSince(apiSecret, rotation, now)
+	if dueSince.IsZero() {
+		return false, nil
+	}
+
+	if timeout := ironic.NodeOperationsTimeout(ironicConf); now.Sub(dueSince) < timeout {
+		busyNodes, nodesErr := ironic.BusyNodes(cctx, resources)
+		if nodesErr != nil {
+			cctx.Logger.Info("cannot check node states before updating API credentials", "Error", nodesErr.Error())
+		} else if busyNodes != "" {
+			cctx.Logger.Info("postponing the update of API credentials until nodes leave transient provision states",
+				"Nodes", busyNodes, "DueSince", dueSince, "Timeout", timeout)
+			return false, nil
+		}
+	}
+
+	rotated, err := ironic.RotateSecret(apiSecret, rotation, now)
+	if err != nil {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
+		return true, err
+	}
+	if !rotated {
+		return false, nil
+	}
+
+	cctx.Logger.Info("rotating API credentials", "Secret", apiSecret.Name)
+	err = cctx.Client.Update(cctx.Context, apiSecret)
+	if err == nil {
+		r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonAPISecretRotated, "Rotated the API password in secret %s", apiSecret.Name)
+	}
+	return true, err
+}
+
+// ensureSelfSignedCertificate generates or renews the CA and the serving
+// certificate. Ironic uses the latter through ServingCertificateName.
+// An existing serving certificate is not renewed while changes to the
+// workload are held.
+func (r *IronicReconciler) ensureSelfSignedCertificate(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, heldReason string) (requeue bool, err error) {
+	servingName := ironic.GeneratedCertificateName(ironicConf)
+	if certName := ironicConf.Spec.TLS.CertificateName; certName != "" && certName != servingName {
+		cctx.Logger.Info("not generating a certificate since one is provided", "Secret", certName)
+		return false, nil
+	}
+
+	if heldReason != "" {
+		existing := &corev1.Secret{}
+		err = cctx.Client.Get(cctx.Context, types.NamespacedName{Namespace: ironicConf.Namespace, Name: servingName}, existing)
+		if err == nil && ironic.IsGeneratedCertificate(existing) {
+			return false, nil
+		}
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return true, err
+		}
+	}
+
+	now := time.Now()
+	caName := ironic.SelfSignedCASecretName(ironicConf)
+	var caReason string
+	caSecret, err := r.ensureCertificateSecret(cctx, ironicConf, caName, true, func(secret *corev1.Secret) (changed bool, updateErr error) {
+		changed, caReason, updateErr = ironic.UpdateCA(secret, ironicConf, now)
+		return changed, updateErr
+	})
+	if err != nil {
+		return true, err
+	}
+	if caReason != "" {
+		cctx.Logger.Info("generated a new CA", "Secret", caName, "Reason", caReason)
+		r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonCAGenerated, "Generated a new CA in secret %s: %s", caName, caReason)
 	}
 
-	return apiSecret, requeue, err
+	var issueReason string
+	_, err = r.ensureCertificateSecret(cctx, ironicConf, servingName, false, func(secret *corev1.Secret) (changed bool, updateErr error) {
+		changed, issueReason, updateErr = ironic.UpdateServingCertificate(secret, caSecret, ironicConf, cctx.Domain, now)
+		return changed, updateErr
+	})
+	if err != nil {
+		return true, err
+	}
+	if issueReason != "" {
+		cctx.Logger.Info("issued a new serving certificate", "Secret", servingName, "Reason", issueReason)
+		r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonCertificateIssued, "Issued a new certificate in secret %s: %s", servingName, issueReason)
+	}
+
+	return false, nil
+}
+
+// ensureCertManagerCertificate requests the serving certificate from
+// cert-manager. Ironic uses it through ServingCertificateName, so the
+// deployment is not updated until issued is true.
+func (r *IronicReconciler) ensureCertManagerCertificate(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic) (issued, requeue bool, err error) {
+	secretName := ironic.GeneratedCertificateName(ironicConf)
+	if certName := ironicConf.Spec.TLS.CertificateName; certName != "" && certName != secretName {
+		cctx.Logger.Info("not requesting a certificate since one is provided", "Secret", certName)
+		return true, false, nil
+	}
+
+	if !r.hasCertManager {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, "certificateIssuer is set but cert-manager is not installed")
+		return false, false, nil
+	}
+
+	status, err := ironic.EnsureCertificate(cctx, ironicConf)
+	if err != nil {
+		return false, true, err
+	}
+	if !status.IsReady() {
+		cctx.Logger.Info("waiting for the certificate", "Status", status.String())
+		err = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonInProgress, status.String())
+		return false, status.NeedsRequeue(), err
+	}
+
+	return true, false, nil
+}
+
+// ensureCertificateSecret creates or updates a secret with a certificate
+// generated by the operator using the provided update function.
+func (r *IronicReconciler) ensureCertificateSecret(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, name string, isCA bool, update func(*corev1.Secret) (bool, error)) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ironicConf.Namespace},
+	}
+	_, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, secret, func() error {
+		if secret.ResourceVersion != "" && !ironic.IsGeneratedCertificate(secret) {
+			return fmt.Errorf("secret %s/%s already exists and has not been generated by the operator", secret.Namespace, name)
+		}
+		ironic.SetCertificateLabels(secret, isCA)
+		if _, updateErr := update(secret); updateErr != nil {
+			return updateErr
+		}
+		return controllerutil.SetControllerReference(ironicConf, secret, cctx.Scheme)
+	})
+	if err != nil {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
+		return nil, err
+	}
+	return secret, nil
+}
+
+// ensureCABundle concatenates the referenced CA certificates into
+// a generated ConfigMap. An existing bundle is not updated while changes
+// to the workload are held.
+// Only returns a valid pointer if requeue is false and err is nil.
+func (r *IronicReconciler) ensureCABundle(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, name string, refs []metal3api.ResourceReferenceWithKey, heldReason string) (configMap *corev1.ConfigMap, requeue bool, err error) {
+	if heldReason != "" {
+		existing := &corev1.ConfigMap{}
+		err = cctx.Client.Get(cctx.Context, types.NamespacedName{Namespace: ironicConf.Namespace, Name: name}, existing)
+		if err == nil && ironic.IsGeneratedCABundle(existing) {
+			return existing, false, nil
+		}
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, true, err
+		}
+	}
+
+	sources := make([]ironic.CABundleSource, 0, len(refs))
+	for _, ref := range refs {
+		source := ironic.CABundleSource{Key: ref.Key}
+		switch ref.Kind {
+		case metal3api.ResourceKindSecret:
+			source.Secret, requeue, err = r.getAndUpdateSecret(cctx, ironicConf, ref.Name)
+		case metal3api.ResourceKindConfigMap:
+			source.ConfigMap, requeue, err = r.getConfigMap(cctx, ironicConf, ref.Name)
+		default:
+			err = fmt.Errorf("unexpected resource kind %q", ref.Kind)
+		}
+		if requeue || err != nil {
+			return nil, requeue, err
+		}
+		sources = append(sources, source)
+	}
+
+	bundle, err := ironic.BuildCABundle(sources)
+	if err != nil {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
+		r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonInvalidLinkedRes, "%v", err)
+		return nil, true, err
+	}
+
+	configMap = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ironicConf.Namespace},
+	}
+	result, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, configMap, func() error {
+		if configMap.ResourceVersion != "" && !ironic.IsGeneratedCABundle(configMap) {
+			return fmt.Errorf("configmap %s/%s already exists and has not been generated by the operator", configMap.Namespace, name)
+		}
+		ironic.UpdateCABundle(configMap, bundle)
+		return controllerutil.SetControllerReference(ironicConf, configMap, cctx.Scheme)
+	})
+	if err != nil {
+		_ = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonFailed, err.Error())
+		return nil, true, err
+	}
+	if result != controllerutil.OperationResultNone {
+		cctx.Logger.Info("CA bundle updated", "ConfigMap", name, "Sources", len(sources))
+	}
+
+	return configMap, false, nil
+}
+
+// removeCABundle removes a generated CA bundle that is no longer used.
+func (r *IronicReconciler) removeCABundle(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, name string) error {
+	configMap := &corev1.ConfigMap{}
+	err := cctx.Client.Get(cctx.Context, types.NamespacedName{Namespace: ironicConf.Namespace, Name: name}, configMap)
+	if k8serrors.IsNotFound(err) || (err == nil && (!ironic.IsGeneratedCABundle(configMap) || !metav1.IsControlledBy(configMap, ironicConf))) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot check CA bundle %s: %w", name, err)
+	}
+
+	cctx.Logger.Info("removing unused CA bundle", "ConfigMap", name)
+	if err = cctx.Client.Delete(cctx.Context, configMap); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot remove CA bundle %s: %w", name, err)
+	}
+	return nil
 }
 
 func (r *IronicReconciler) cleanUp(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic) (bool, error) {
@@ -417,10 +970,55 @@ func (r *IronicReconciler) cleanUp(cctx ironic.ControllerContext, ironicConf *me
 		return false, err
 	}
 
+	deleteMetrics(ironicConf)
+
 	// This must be the last action.
 	return removeFinalizer(cctx, ironicConf)
 }
 
+// findIronicsForConfigMap returns reconcile requests for all Ironic objects
+// in the namespace of the ConfigMap that reference it.
+func (r *IronicReconciler) findIronicsForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	ironics := &metal3api.IronicList{}
+	if err := r.List(ctx, ironics, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "cannot list Ironic objects", "Namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var result []reconcile.Request
+	for _, ironicConf := range ironics.Items {
+		refs := []*metal3api.ResourceReference{
+			ironic.GetBMCCA(&ironicConf.Spec.TLS),
+			ironic.GetTrustedCA(&ironicConf.Spec.TLS),
+		}
+		if clientCA := ironicConf.Spec.TLS.ClientCA; clientCA != nil {
+			refs = append(refs, &clientCA.ResourceReference)
+		}
+		for _, additional := range [][]metal3api.ResourceReferenceWithKey{
+			ironicConf.Spec.TLS.AdditionalBMCCAs,
+			ironicConf.Spec.TLS.AdditionalTrustedCAs,
+		} {
+			for idx := range additional {
+				refs = append(refs, &additional[idx].ResourceReference)
+			}
+		}
+		// Generated bundles are watched in case they are modified externally
+		refs = append(refs,
+			&metal3api.ResourceReference{Name: ironic.BMCCABundleName(&ironicConf), Kind: metal3api.ResourceKindConfigMap},
+			&metal3api.ResourceReference{Name: ironic.TrustedCABundleName(&ironicConf), Kind: metal3api.ResourceKindConfigMap},
+		)
+		for _, ref := range refs {
+			if ref != nil && ref.Kind == metal3api.ResourceKindConfigMap && ref.Name == obj.GetName() {
+				result = append(result, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: ironicConf.Namespace, Name: ironicConf.Name},
+				})
+				break
+			}
+		}
+	}
+	return result
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *IronicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
@@ -429,7 +1027,9 @@ func (r *IronicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&batchv1.Job{})
+		Owns(&batchv1.Job{}).
+		// ConfigMaps are not owned by Ironic, only labeled.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findIronicsForConfigMap))
 
 	hasServiceMonitor, err := clusterHasCRD(mgr, &monitoringv1.ServiceMonitor{})
 	if err != nil {
@@ -442,5 +1042,16 @@ func (r *IronicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		r.Log.Info("WARNING: ServiceMonitor resources are not available and will not be reconciled")
 	}
 
+	r.hasCertManager, err = clusterHasCRD(mgr, &certmanagerv1.Certificate{})
+	if err != nil {
+		return err
+	}
+
+	if r.hasCertManager {
+		builder = builder.Owns(&certmanagerv1.Certificate{})
+	} else {
+		r.Log.Info("WARNING: cert-manager Certificate resources are not available, certificateIssuer cannot be used")
+	}
+
 	return builder.Complete(r)
 }
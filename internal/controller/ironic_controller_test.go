@@ -17,12 +17,18 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/pem"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
@@ -375,6 +381,188 @@ func TestUpdateIronicStatus_AlreadyNotReady_NoEvent(t *testing.T) {
 	assert.Empty(t, evts, "no events should be emitted when status is already not ready")
 }
 
+func TestUpdateIronicStatus_JobFailed_EmitsJobFailedEvent(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(ironicObj).WithObjects(ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	failedStatus := ironic.Status{Fatal: &ironic.JobFailedError{
+		JobName: "test-ironic-pre-37.0-to-38.0",
+		JobType: "pre-upgrade",
+		Reason:  "BackoffLimitExceeded",
+		Output:  "oslo_db.exception.DBError: table already exists",
+	}}
+	_, err := r.updateIronicStatus(cctx, ironicObj, failedStatus, "38.0")
+	require.NoError(t, err)
+
+	readyCond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(metal3api.IronicStatusReady))
+	require.NotNil(t, readyCond)
+	assert.Contains(t, readyCond.Message, "table already exists")
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "Warning JobFailed")
+	assert.Contains(t, evts[0], "pre-upgrade job failed: BackoffLimitExceeded; output: oslo_db.exception.DBError: table already exists")
+
+	// The same failure is not reported again
+	_, err = r.updateIronicStatus(cctx, ironicObj, failedStatus, "38.0")
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func TestUpdateIronicStatus_ComponentConditions(t *testing.T) {
+	testCases := []struct {
+		Scenario          string
+		Status            ironic.Status
+		ExpectedReady     metav1.ConditionStatus
+		ExpectedReadyMsg  string
+		ExpectedComponent map[metal3api.IronicStatusConditionType]metav1.ConditionStatus
+		ExpectedReasons   map[metal3api.IronicStatusConditionType]string
+	}{
+		{
+			Scenario: "all ready",
+			Status: ironic.Status{
+				Ready: true,
+				Components: ironic.Components{
+					metal3api.IronicStatusNetworkingServiceReady: {Ready: true},
+					metal3api.IronicStatusDatabaseMigrated:       {Ready: true},
+					metal3api.IronicStatusWorkloadAvailable:      {Ready: true},
+					metal3api.IronicStatusServiceReady:           {Ready: true},
+					metal3api.IronicStatusIngressReady:           {Ready: true},
+					metal3api.IronicStatusMonitoringReady:        {Ready: true},
+				},
+			},
+			ExpectedReady:    metav1.ConditionTrue,
+			ExpectedReadyMsg: "ironic: resources are available",
+			ExpectedComponent: map[metal3api.IronicStatusConditionType]metav1.ConditionStatus{
+				metal3api.IronicStatusNetworkingServiceReady: metav1.ConditionTrue,
+				metal3api.IronicStatusDatabaseMigrated:       metav1.ConditionTrue,
+				metal3api.IronicStatusWorkloadAvailable:      metav1.ConditionTrue,
+				metal3api.IronicStatusServiceReady:           metav1.ConditionTrue,
+				metal3api.IronicStatusIngressReady:           metav1.ConditionTrue,
+				metal3api.IronicStatusMonitoringReady:        metav1.ConditionTrue,
+			},
+		},
+		{
+			Scenario: "workload in progress",
+			Status: ironic.Status{
+				Message: "deployment not available yet",
+				Components: ironic.Components{
+					metal3api.IronicStatusNetworkingServiceReady: {Ready: true},
+					metal3api.IronicStatusDatabaseMigrated:       {Ready: true},
+					metal3api.IronicStatusWorkloadAvailable:      {Message: "deployment not available yet"},
+					metal3api.IronicStatusServiceReady:           {Ready: true},
+				},
+			},
+			ExpectedReady:    metav1.ConditionFalse,
+			ExpectedReadyMsg: "ironic: deployment not available yet",
+			ExpectedComponent: map[metal3api.IronicStatusConditionType]metav1.ConditionStatus{
+				metal3api.IronicStatusNetworkingServiceReady: metav1.ConditionTrue,
+				metal3api.IronicStatusDatabaseMigrated:       metav1.ConditionTrue,
+				metal3api.IronicStatusWorkloadAvailable:      metav1.ConditionFalse,
+				metal3api.IronicStatusServiceReady:           metav1.ConditionTrue,
+				metal3api.IronicStatusIngressReady:           metav1.ConditionUnknown,
+				metal3api.IronicStatusMonitoringReady:        metav1.ConditionUnknown,
+			},
+			ExpectedReasons: map[metal3api.IronicStatusConditionType]string{
+				metal3api.IronicStatusWorkloadAvailable: metal3api.IronicReasonInProgress,
+			},
+		},
+		{
+			Scenario: "migration failed",
+			Status: ironic.Status{
+				Fatal: errors.New("pre-upgrade job failed"),
+				Components: ironic.Components{
+					metal3api.IronicStatusNetworkingServiceReady: {Ready: true},
+					metal3api.IronicStatusDatabaseMigrated:       {Fatal: errors.New("pre-upgrade job failed")},
+				},
+			},
+			ExpectedReady:    metav1.ConditionFalse,
+			ExpectedReadyMsg: "ironic: pre-upgrade job failed",
+			ExpectedComponent: map[metal3api.IronicStatusConditionType]metav1.ConditionStatus{
+				metal3api.IronicStatusNetworkingServiceReady: metav1.ConditionTrue,
+				metal3api.IronicStatusDatabaseMigrated:       metav1.ConditionFalse,
+				metal3api.IronicStatusWorkloadAvailable:      metav1.ConditionUnknown,
+			},
+			ExpectedReasons: map[metal3api.IronicStatusConditionType]string{
+				metal3api.IronicStatusReady:            metal3api.IronicReasonFailed,
+				metal3api.IronicStatusDatabaseMigrated: metal3api.IronicReasonFailed,
+			},
+		},
+		{
+			Scenario: "database authentication failed",
+			Status: ironic.Status{
+				Fatal:  errors.New("database check failed: access denied"),
+				Reason: metal3api.IronicReasonDatabaseAuthFailed,
+				Components: ironic.Components{
+					metal3api.IronicStatusNetworkingServiceReady: {Ready: true},
+					metal3api.IronicStatusDatabaseMigrated: {
+						Fatal:  errors.New("database check failed: access denied"),
+						Reason: metal3api.IronicReasonDatabaseAuthFailed,
+					},
+				},
+			},
+			ExpectedReady:    metav1.ConditionFalse,
+			ExpectedReadyMsg: "ironic: database check failed: access denied",
+			ExpectedComponent: map[metal3api.IronicStatusConditionType]metav1.ConditionStatus{
+				metal3api.IronicStatusNetworkingServiceReady: metav1.ConditionTrue,
+				metal3api.IronicStatusDatabaseMigrated:       metav1.ConditionFalse,
+				metal3api.IronicStatusWorkloadAvailable:      metav1.ConditionUnknown,
+			},
+			ExpectedReasons: map[metal3api.IronicStatusConditionType]string{
+				metal3api.IronicStatusReady:            metal3api.IronicReasonDatabaseAuthFailed,
+				metal3api.IronicStatusDatabaseMigrated: metal3api.IronicReasonDatabaseAuthFailed,
+			},
+		},
+		{
+			Scenario: "component not ready despite overall status",
+			Status: ironic.Status{
+				Ready: true,
+				Components: ironic.Components{
+					metal3api.IronicStatusMonitoringReady: {Message: "waiting"},
+				},
+			},
+			ExpectedReady:    metav1.ConditionFalse,
+			ExpectedReadyMsg: "ironic: waiting",
+			ExpectedComponent: map[metal3api.IronicStatusConditionType]metav1.ConditionStatus{
+				metal3api.IronicStatusMonitoringReady: metav1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			scheme := newTestScheme()
+			ironicObj := newTestIronic()
+
+			r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(ironicObj).WithObjects(ironicObj), events.NewFakeRecorder(10))
+			cctx := newTestControllerContext(t, scheme, r.Client)
+
+			_, err := r.updateIronicStatus(cctx, ironicObj, tc.Status, "latest")
+			require.NoError(t, err)
+
+			readyCond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(metal3api.IronicStatusReady))
+			require.NotNil(t, readyCond)
+			assert.Equal(t, tc.ExpectedReady, readyCond.Status)
+			assert.Equal(t, tc.ExpectedReadyMsg, readyCond.Message)
+
+			for condType, expected := range tc.ExpectedComponent {
+				cond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(condType))
+				require.NotNil(t, cond, "condition %s", condType)
+				assert.Equal(t, expected, cond.Status, "condition %s", condType)
+			}
+			for condType, expected := range tc.ExpectedReasons {
+				cond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(condType))
+				require.NotNil(t, cond, "condition %s", condType)
+				assert.Equal(t, expected, cond.Reason, "condition %s", condType)
+			}
+		})
+	}
+}
+
 func TestEnsureAPISecret_ExistingSecret_NoAPISecretCreatedEvent(t *testing.T) {
 	scheme := newTestScheme()
 	recorder := events.NewFakeRecorder(10)
@@ -408,3 +596,434 @@ func TestEnsureAPISecret_ExistingSecret_NoAPISecretCreatedEvent(t *testing.T) {
 			"APISecretCreated must not be emitted when using an existing secret")
 	}
 }
+
+func TestRotateAPICredentials_RotationDue_EmitsAPISecretRotatedEvent(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.APICredentialsName = "existing-api-secret"
+	ironicObj.Spec.APICredentialsRotation = &metal3api.CredentialsRotation{
+		Interval: metav1.Duration{Duration: time.Hour},
+	}
+	// Do not query the node states
+	ironicObj.Spec.UpgradePolicy = &metal3api.UpgradePolicy{NodeOperationsTimeout: &metav1.Duration{}}
+
+	secret, err := ironic.GenerateSecret(&ironicObj.ObjectMeta, "service", true)
+	require.NoError(t, err)
+	secret.Name = "existing-api-secret"
+	secret.CreationTimestamp = metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	// The API secret itself does not rotate the credentials
+	result, requeue, err := r.ensureAPISecret(cctx, ironicObj)
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	require.NotNil(t, result)
+	assert.Equal(t, string(secret.Data["username"]), string(result.Data["username"]))
+
+	requeue, err = r.rotateAPICredentials(cctx, ironic.Resources{Ironic: ironicObj, APISecret: result}, time.Now())
+	require.NoError(t, err)
+	assert.True(t, requeue)
+
+	stored := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(secret), stored))
+	assert.Equal(t, string(secret.Data["username"]), string(stored.Data["username"]))
+	assert.NotEqual(t, string(secret.Data["password"]), string(stored.Data["password"]))
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "APISecretRotated")
+
+	// Nothing to do until the next interval
+	requeue, err = r.rotateAPICredentials(cctx, ironic.Resources{Ironic: ironicObj, APISecret: stored}, time.Now())
+	require.NoError(t, err)
+	assert.False(t, requeue)
+}
+
+func TestNextRotationEvent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(d)}
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Status   *metal3api.APICredentialsStatus
+		Expected time.Duration
+	}{
+		{
+			Scenario: "no status",
+		},
+		{
+			Scenario: "next rotation",
+			Status:   &metal3api.APICredentialsStatus{NextRotationTime: at(time.Hour)},
+			Expected: time.Hour,
+		},
+		{
+			Scenario: "rotation disabled",
+			Status:   &metal3api.APICredentialsStatus{LastRotationTime: at(-time.Hour)},
+		},
+		{
+			Scenario: "postponed",
+			Status:   &metal3api.APICredentialsStatus{NextRotationTime: at(-time.Hour)},
+			Expected: rotationRetryInterval,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, nextRotationEvent(tc.Status, now))
+		})
+	}
+}
+
+func TestNextDatabaseCheckEvent(t *testing.T) {
+	ironicObj := newTestIronic()
+	assert.Zero(t, nextDatabaseCheckEvent(ironicObj))
+
+	ironicObj.Status.Conditions = []metav1.Condition{
+		{
+			Type:   string(metal3api.IronicStatusDatabaseMigrated),
+			Status: metav1.ConditionFalse,
+			Reason: metal3api.IronicReasonDatabaseAuthFailed,
+		},
+	}
+	assert.Zero(t, nextDatabaseCheckEvent(ironicObj))
+
+	ironicObj.Status.Conditions[0].Reason = metal3api.IronicReasonDatabaseUnreachable
+	assert.Equal(t, ironic.DatabaseCheckRetryInterval, nextDatabaseCheckEvent(ironicObj))
+}
+
+func TestEnsureAPISecret_ExtraUsers(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.APICredentialsName = "existing-api-secret"
+	ironicObj.Spec.ExtraAPICredentialsNames = []string{"monitoring-secret"}
+
+	secret, err := ironic.GenerateSecret(&ironicObj.ObjectMeta, "service", true)
+	require.NoError(t, err)
+	secret.Name = "existing-api-secret"
+
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "monitoring-secret",
+			Namespace: "test-ns",
+			Labels:    environmentLabels(),
+		},
+		Data: map[string][]byte{
+			"username": []byte("monitoring"),
+			"password": []byte("secret"),
+		},
+	}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, userSecret, ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	result, requeue, err := r.ensureAPISecret(cctx, ironicObj)
+
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	require.NotNil(t, result)
+	assert.NotContains(t, string(result.Data["htpasswd"]), "monitoring:")
+
+	htpasswdSecret := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: ironicObj.Namespace, Name: ironicObj.Name + "-htpasswd"}, htpasswdSecret))
+	assert.Contains(t, string(htpasswdSecret.Data["htpasswd"]), "\nmonitoring:")
+
+	result, requeue, err = r.ensureAPISecret(cctx, ironicObj)
+
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	require.NotNil(t, result)
+}
+
+func TestEnsureSelfSignedCertificate(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.SelfSigned = &metal3api.SelfSignedCertificate{}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	requeue, err := r.ensureSelfSignedCertificate(cctx, ironicObj, "")
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	assert.Empty(t, ironicObj.Spec.TLS.CertificateName)
+	assert.Equal(t, "test-ironic-tls", ironic.ServingCertificateName(ironicObj))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-tls"}, secret))
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, "serving", secret.Labels[metal3api.IronicCertificateLabel])
+	assert.Equal(t, metal3api.LabelEnvironmentValue, secret.Labels[metal3api.LabelEnvironmentName])
+	assert.NotEmpty(t, secret.Data["ca.crt"])
+	assert.Len(t, secret.OwnerReferences, 1)
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 2)
+	assert.Contains(t, evts[0], "CAGenerated")
+	assert.Contains(t, evts[1], "CertificateIssued")
+
+	stored := &metal3api.Ironic{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), stored))
+	assert.Empty(t, stored.Spec.TLS.CertificateName)
+
+	requeue, err = r.ensureSelfSignedCertificate(cctx, ironicObj, "")
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func TestEnsureSelfSignedCertificate_CAExpiring(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.SelfSigned = &metal3api.SelfSignedCertificate{}
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-ca", Namespace: "test-ns"},
+	}
+	_, _, err := ironic.UpdateCA(caSecret, ironicObj, time.Now().Add(-10*365*24*time.Hour))
+	require.NoError(t, err)
+	ironic.SetCertificateLabels(caSecret, true)
+	oldCA := caSecret.Data[corev1.TLSCertKey]
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(caSecret, ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	_, err = r.ensureSelfSignedCertificate(cctx, ironicObj, "")
+	require.NoError(t, err)
+
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(caSecret), caSecret))
+	assert.NotEqual(t, oldCA, caSecret.Data[corev1.TLSCertKey])
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 2)
+	assert.Contains(t, evts[0], "CAGenerated")
+	assert.Contains(t, evts[0], "the CA is about to expire")
+	assert.Contains(t, evts[1], "CertificateIssued")
+}
+
+func TestEnsureSelfSignedCertificate_Held(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.SelfSigned = &metal3api.SelfSignedCertificate{}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	// The initial certificate is issued even while changes are held
+	_, err := r.ensureSelfSignedCertificate(cctx, ironicObj, "paused")
+	require.NoError(t, err)
+	assert.Len(t, drainEvents(recorder), 2)
+
+	// Expire the certificate: it is not renewed while changes are held
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-tls"}, secret))
+	delete(secret.Data, corev1.TLSCertKey)
+	require.NoError(t, r.Client.Update(t.Context(), secret))
+
+	_, err = r.ensureSelfSignedCertificate(cctx, ironicObj, "paused")
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+
+	_, err = r.ensureSelfSignedCertificate(cctx, ironicObj, "")
+	require.NoError(t, err)
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "CertificateIssued")
+}
+
+func TestEnsureSelfSignedCertificate_ExistingSecret(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.SelfSigned = &metal3api.SelfSignedCertificate{}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ironic-ca",
+			Namespace: "test-ns",
+			Labels:    environmentLabels(),
+		},
+	}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	_, err := r.ensureSelfSignedCertificate(cctx, ironicObj, "")
+	require.ErrorContains(t, err, "has not been generated by the operator")
+	assert.Empty(t, ironicObj.Spec.TLS.CertificateName)
+}
+
+func TestEnsureCertManagerCertificate_NoCertManager(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.CertificateIssuer = &metal3api.IssuerReference{Name: "issuer"}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj).WithStatusSubresource(ironicObj), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	issued, requeue, err := r.ensureCertManagerCertificate(cctx, ironicObj)
+	require.NoError(t, err)
+	assert.False(t, issued)
+	assert.False(t, requeue)
+	assert.Empty(t, ironicObj.Spec.TLS.CertificateName)
+
+	cond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(metal3api.IronicStatusReady))
+	require.NotNil(t, cond)
+	assert.Contains(t, cond.Message, "cert-manager is not installed")
+}
+
+func TestCheckCertificates_EmitsCertificateExpiringEvent(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	ironicObj.Spec.TLS.SelfSigned = &metal3api.SelfSignedCertificate{}
+
+	now := time.Now()
+	issuedAt := now.Add(-85 * 24 * time.Hour)
+	caSecret := &corev1.Secret{}
+	_, _, err := ironic.UpdateCA(caSecret, ironicObj, issuedAt)
+	require.NoError(t, err)
+	tlsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-tls"}}
+	_, _, err = ironic.UpdateServingCertificate(tlsSecret, caSecret, ironicObj, "", issuedAt)
+	require.NoError(t, err)
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme), recorder)
+	resources := ironic.Resources{Ironic: ironicObj, TLSSecret: tlsSecret}
+
+	certificates := r.checkCertificates(ironicObj, resources, now)
+	require.Len(t, certificates, 1)
+	assert.Equal(t, metal3api.CertificateTypeServing, certificates[0].Type)
+	require.NotNil(t, certificates[0].WarningThreshold)
+	assert.Equal(t, 7*24*time.Hour, certificates[0].WarningThreshold.Duration)
+	assert.InDelta(t, float64(certificates[0].NotAfter.Unix()),
+		testutil.ToFloat64(certificateExpiration.WithLabelValues("test-ns", "test-ironic", "Serving", "test-ironic-tls")), 0)
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "CertificateExpiring")
+	assert.Contains(t, evts[0], "expires in less than 168h0m0s")
+
+	// The same threshold is not reported twice
+	ironicObj.Status.Certificates = certificates
+	_ = r.checkCertificates(ironicObj, resources, now.Add(time.Hour))
+	assert.Empty(t, drainEvents(recorder))
+
+	// The next threshold is reported again
+	_ = r.checkCertificates(ironicObj, resources, now.Add(4*24*time.Hour+time.Hour))
+	evts = drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "expires in less than 24h0m0s")
+
+	deleteMetrics(ironicObj)
+	assert.Equal(t, 0, testutil.CollectAndCount(certificateExpiration))
+}
+
+func TestFindIronicsForConfigMap(t *testing.T) {
+	scheme := newTestScheme()
+	ironicBMC := newTestIronic()
+	ironicBMC.Name = "ironic-bmc"
+	ironicBMC.Spec.TLS.BMCCA = &metal3api.ResourceReference{Name: "ca", Kind: metal3api.ResourceKindConfigMap}
+	ironicTrusted := newTestIronic()
+	ironicTrusted.Name = "ironic-trusted"
+	ironicTrusted.Spec.TLS.TrustedCA = &metal3api.ResourceReferenceWithKey{
+		ResourceReference: metal3api.ResourceReference{Name: "ca", Kind: metal3api.ResourceKindConfigMap},
+	}
+	ironicSecret := newTestIronic()
+	ironicSecret.Name = "ironic-secret"
+	ironicSecret.Spec.TLS.BMCCA = &metal3api.ResourceReference{Name: "ca", Kind: metal3api.ResourceKindSecret}
+	ironicClient := newTestIronic()
+	ironicClient.Name = "ironic-client"
+	ironicClient.Spec.TLS.ClientCA = &metal3api.ResourceReferenceWithKey{
+		ResourceReference: metal3api.ResourceReference{Name: "ca", Kind: metal3api.ResourceKindConfigMap},
+	}
+	ironicOther := newTestIronic()
+	ironicOther.Name = "ironic-other"
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(ironicBMC, ironicTrusted, ironicSecret, ironicClient, ironicOther), events.NewFakeRecorder(10))
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "test-ns"}}
+	requests := r.findIronicsForConfigMap(t.Context(), configMap)
+	var names []string
+	for _, req := range requests {
+		names = append(names, req.Name)
+	}
+	assert.ElementsMatch(t, []string{"ironic-bmc", "ironic-trusted", "ironic-client"}, names)
+
+	configMap.Namespace = "other-ns"
+	assert.Empty(t, r.findIronicsForConfigMap(t.Context(), configMap))
+}
+
+func TestEnsureCABundle(t *testing.T) {
+	scheme := newTestScheme()
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestIronic()
+	certPEM := func(name string) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(name)}))
+	}
+	ironicObj.Spec.TLS.AdditionalTrustedCAs = []metal3api.ResourceReferenceWithKey{
+		{ResourceReference: metal3api.ResourceReference{Name: "vendor-ca", Kind: metal3api.ResourceKindSecret}},
+	}
+	ironicObj.Spec.TLS.TrustedCA = &metal3api.ResourceReferenceWithKey{
+		ResourceReference: metal3api.ResourceReference{Name: "corporate-ca", Kind: metal3api.ResourceKindConfigMap},
+		Key:               "ca.crt",
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vendor-ca", Namespace: "test-ns", Labels: environmentLabels()},
+		Data:       map[string][]byte{"tls.crt": []byte(certPEM("VENDOR"))},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: "test-ns", Labels: environmentLabels()},
+		Data:       map[string]string{"ca.crt": certPEM("CORPORATE"), "ignored.crt": certPEM("IGNORED")},
+	}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, secret, configMap), recorder)
+	cctx := newTestControllerContext(t, scheme, r.Client)
+
+	sources := ironic.GetTrustedCABundleSources(&ironicObj.Spec.TLS)
+	bundle, requeue, err := r.ensureCABundle(cctx, ironicObj, ironic.TrustedCABundleName(ironicObj), sources, "")
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	assert.Equal(t, "test-ironic-trusted-ca-bundle", bundle.Name)
+
+	stored := &corev1.ConfigMap{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: bundle.Name}, stored))
+	assert.Equal(t, map[string]string{ironic.CABundleKey: certPEM("CORPORATE") + certPEM("VENDOR")}, stored.Data)
+	assert.True(t, ironic.IsGeneratedCABundle(stored))
+	assert.True(t, metav1.IsControlledBy(stored, ironicObj))
+
+	// The existing bundle is kept while changes are held
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(secret), secret))
+	secret.Data["tls.crt"] = []byte(certPEM("NEW VENDOR"))
+	require.NoError(t, r.Client.Update(t.Context(), secret))
+	_, requeue, err = r.ensureCABundle(cctx, ironicObj, bundle.Name, sources, metal3api.IronicReasonOutsideMaintenanceWindow)
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: bundle.Name}, stored))
+	assert.Equal(t, map[string]string{ironic.CABundleKey: certPEM("CORPORATE") + certPEM("VENDOR")}, stored.Data)
+
+	_, requeue, err = r.ensureCABundle(cctx, ironicObj, bundle.Name, sources, "")
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: bundle.Name}, stored))
+	assert.Equal(t, map[string]string{ironic.CABundleKey: certPEM("CORPORATE") + certPEM("NEW VENDOR")}, stored.Data)
+
+	require.NoError(t, r.removeCABundle(cctx, ironicObj, bundle.Name))
+	err = r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: bundle.Name}, stored)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// ConfigMaps not generated by the operator are never touched
+	require.NoError(t, r.removeCABundle(cctx, ironicObj, "corporate-ca"))
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "corporate-ca"}, stored))
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
+)
+
+// holdWorkloadChanges reports the pending changes to the Ironic workload
+// without applying them. Returns false in the first value if there is
+// nothing to hold and the normal reconciliation should proceed.
+func (r *IronicReconciler) holdWorkloadChanges(cctx ironic.ControllerContext, resources ironic.Resources, reason string, status ironic.Status, now time.Time) (held bool, requeue bool, err error) {
+	ironicConf := resources.Ironic
+	changes, workloadStatus, err := ironic.PendingWorkloadChanges(cctx, resources)
+	if err != nil {
+		return false, false, err
+	}
+	// Rotation is not applied while changes are held, see rotateAPICredentials
+	changes = append(changes, ironic.PendingCredentialChanges(resources.APISecret, ironicConf.Spec.APICredentialsRotation, now)...)
+	if len(changes) == 0 && reason != metal3api.IronicReasonPaused {
+		return false, false, nil
+	}
+
+	pending := &metal3api.PendingChanges{
+		Reason:  reason,
+		Changes: changes,
+	}
+	if reason == metal3api.IronicReasonOutsideMaintenanceWindow {
+		nextWindow, windowErr := ironic.NextMaintenanceWindow(ironicConf, now)
+		if windowErr != nil {
+			return false, false, windowErr
+		}
+		pending.NextWindowTime = &metav1.Time{Time: nextWindow}
+	}
+
+	newStatus := ironicConf.Status.DeepCopy()
+	newStatus.APICredentials = status.APICredentials
+	newStatus.Certificates = status.Certificates
+	newStatus.PendingChanges = pending
+
+	value, condReason := statusReason(workloadStatus)
+	if value {
+		condReason = metal3api.IronicReasonAvailable
+	}
+	setCondition(&newStatus.Conditions, metal3api.IronicStatusWorkloadAvailable, ironicConf.Generation,
+		value, condReason, workloadStatus.String())
+	message := fmt.Sprintf("ironic: %s; changes held (%s)", workloadStatus, reason)
+	if len(changes) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(changes, ", "))
+	}
+	setCondition(&newStatus.Conditions, metal3api.IronicStatusReady, ironicConf.Generation, value, condReason, message)
+
+	if apiequality.Semantic.DeepEqual(newStatus, &ironicConf.Status) {
+		return true, workloadStatus.NeedsRequeue(), nil
+	}
+
+	cctx.Logger.Info("holding changes to the ironic workload", "Reason", reason, "Changes", changes)
+	newlyHeld := ironicConf.Status.PendingChanges == nil && len(changes) > 0
+	ironicConf.Status = *newStatus
+	if err = cctx.Client.Status().Update(cctx.Context, ironicConf); err != nil {
+		return true, false, err
+	}
+	if newlyHeld {
+		r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonChangesHeld, "Changes held (%s): %s", reason, strings.Join(changes, ", "))
+	}
+	return true, workloadStatus.NeedsRequeue(), nil
+}
+
+// nextMaintenanceWindowEvent returns the time until held changes can be
+// applied, zero if no changes are waiting for a maintenance window.
+func nextMaintenanceWindowEvent(ironicConf *metal3api.Ironic, now time.Time) time.Duration {
+	pending := ironicConf.Status.PendingChanges
+	if pending == nil || pending.NextWindowTime == nil {
+		return 0
+	}
+	return max(pending.NextWindowTime.Sub(now), time.Second)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
+)
+
+func TestHoldWorkloadChanges(t *testing.T) {
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+	apiSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"htpasswd": []byte("abcd"),
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Paused            bool
+		MaintenanceWindow *metal3api.MaintenanceWindow
+
+		ExpectedNextWindow *metav1.Time
+	}{
+		{
+			Scenario: "paused",
+			Paused:   true,
+		},
+		{
+			Scenario: "outside maintenance window",
+			MaintenanceWindow: &metal3api.MaintenanceWindow{
+				Schedule: "0 4 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			ExpectedNextWindow: &metav1.Time{Time: time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			scheme := newTestScheme()
+			require.NoError(t, appsv1.AddToScheme(scheme))
+			recorder := events.NewFakeRecorder(10)
+			ironicObj := newTestIronic()
+			ironicObj.Spec.Paused = tc.Paused
+			ironicObj.Spec.MaintenanceWindow = tc.MaintenanceWindow
+			ironicObj.Status.InstalledVersion = "latest"
+
+			r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(ironicObj).WithObjects(ironicObj), recorder)
+			cctx := newTestUpgradeContext(t, r, ironicObj)
+			resources := ironic.Resources{Ironic: ironicObj, APISecret: apiSecret}
+
+			reason, err := ironic.ChangesHeldReason(ironicObj, now)
+			require.NoError(t, err)
+			require.NotEmpty(t, reason)
+
+			held, _, err := r.holdWorkloadChanges(cctx, resources, reason, ironic.Status{}, now)
+			require.NoError(t, err)
+			assert.True(t, held)
+
+			deployments := &appsv1.DeploymentList{}
+			require.NoError(t, r.Client.List(t.Context(), deployments))
+			assert.Empty(t, deployments.Items)
+
+			updated := &metal3api.Ironic{}
+			require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), updated))
+			require.NotNil(t, updated.Status.PendingChanges)
+			assert.Equal(t, reason, updated.Status.PendingChanges.Reason)
+			assert.Equal(t, []string{"creation of deployment test-ironic-service"}, updated.Status.PendingChanges.Changes)
+			if tc.ExpectedNextWindow != nil {
+				require.NotNil(t, updated.Status.PendingChanges.NextWindowTime)
+				assert.True(t, tc.ExpectedNextWindow.Equal(updated.Status.PendingChanges.NextWindowTime))
+			} else {
+				assert.Nil(t, updated.Status.PendingChanges.NextWindowTime)
+			}
+
+			readyCond := meta.FindStatusCondition(updated.Status.Conditions, string(metal3api.IronicStatusReady))
+			require.NotNil(t, readyCond)
+			assert.Equal(t, metav1.ConditionFalse, readyCond.Status)
+			assert.Contains(t, readyCond.Message, "changes held ("+reason+")")
+			assert.Equal(t, "latest", updated.Status.InstalledVersion)
+
+			evts := drainEvents(recorder)
+			require.Len(t, evts, 1)
+			assert.Contains(t, evts[0], eventReasonChangesHeld)
+
+			// Nothing changes on the next reconciliation
+			held, _, err = r.holdWorkloadChanges(cctx, ironic.Resources{Ironic: updated, APISecret: apiSecret}, reason, ironic.Status{}, now)
+			require.NoError(t, err)
+			assert.True(t, held)
+			assert.Empty(t, drainEvents(recorder))
+		})
+	}
+}
+
+func TestNextMaintenanceWindowEvent(t *testing.T) {
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+	ironicObj := newTestIronic()
+	assert.Zero(t, nextMaintenanceWindowEvent(ironicObj, now))
+
+	ironicObj.Status.PendingChanges = &metal3api.PendingChanges{Reason: metal3api.IronicReasonPaused}
+	assert.Zero(t, nextMaintenanceWindowEvent(ironicObj, now))
+
+	ironicObj.Status.PendingChanges = &metal3api.PendingChanges{
+		Reason:         metal3api.IronicReasonOutsideMaintenanceWindow,
+		NextWindowTime: &metav1.Time{Time: now.Add(time.Hour)},
+	}
+	assert.Equal(t, time.Hour, nextMaintenanceWindowEvent(ironicObj, now))
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	labelNamespace       = "namespace"
+	labelIronic          = "ironic"
+	labelCertificateType = "type"
+	labelCertificateName = "name"
+)
+
+var certificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ironic_operator_certificate_expiration_timestamp_seconds",
+	Help: "Expiration time of the certificates used by Ironic as a Unix timestamp",
+}, []string{labelNamespace, labelIronic, labelCertificateType, labelCertificateName})
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiration)
+}
+
+func ironicMetricLabels(ironicConf *metal3api.Ironic) prometheus.Labels {
+	return prometheus.Labels{
+		labelNamespace: ironicConf.Namespace,
+		labelIronic:    ironicConf.Name,
+	}
+}
+
+// setCertificateMetrics replaces the certificate expiration metrics of the
+// given Ironic object.
+func setCertificateMetrics(ironicConf *metal3api.Ironic, certificates []metal3api.CertificateStatus) {
+	certificateExpiration.DeletePartialMatch(ironicMetricLabels(ironicConf))
+	for _, cert := range certificates {
+		certificateExpiration.WithLabelValues(ironicConf.Namespace, ironicConf.Name, string(cert.Type), cert.Name).
+			Set(float64(cert.NotAfter.Unix()))
+	}
+}
+
+// deleteMetrics removes all metrics of the given Ironic object.
+func deleteMetrics(ironicConf *metal3api.Ironic) {
+	certificateExpiration.DeletePartialMatch(ironicMetricLabels(ironicConf))
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
+)
+
+// installedVersionInfo returns the version information for the installed
+// version, ignoring the requested version and Ironic image.
+func installedVersionInfo(defaults ironic.VersionInfo, ironicConf *metal3api.Ironic) (ironic.VersionInfo, error) {
+	installed := ironicConf.DeepCopy()
+	installed.Spec.Version = ironicConf.Status.InstalledVersion
+	installed.Spec.Images.Ironic = ""
+	return defaults.WithIronicOverrides(installed)
+}
+
+// handleUpgradeRetry removes the failed jobs of the current upgrade when
+// requested through the retry annotation, including a rolled back one.
+func (r *IronicReconciler) handleUpgradeRetry(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic) (bool, error) {
+	if _, ok := ironicConf.Annotations[metal3api.IronicRetryUpgradeAnnotation]; !ok {
+		return false, nil
+	}
+
+	requestedVersion := cctx.VersionInfo.InstalledVersion.String()
+	cctx.Logger.Info("retrying upgrade", "InstalledVersion", ironicConf.Status.InstalledVersion, "RequestedVersion", requestedVersion)
+	if err := ironic.RetryUpgrade(cctx, ironicConf, requestedVersion); err != nil {
+		return false, err
+	}
+
+	upgrade := ironicConf.Status.Upgrade
+	if upgrade != nil && upgrade.Outcome == metal3api.UpgradeFailed {
+		// Already in the history, record the next attempt separately
+		upgrade.Outcome = ""
+		upgrade.CompletionTime = nil
+		upgrade.Jobs = nil
+	}
+	ironicConf.Status.RolledBackVersion = ""
+	if err := cctx.Client.Status().Update(cctx.Context, ironicConf); err != nil {
+		return false, err
+	}
+
+	r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonUpgradeRetried, "Retrying upgrade from %s to %s", ironicConf.Status.InstalledVersion, requestedVersion)
+	return true, removeAnnotation(cctx, ironicConf, metal3api.IronicRetryUpgradeAnnotation)
+}
+
+// handleUpgradeRollback restores the database from the backup taken before
+// the current upgrade and reverts the requested version to the installed one.
+// Ironic is stopped during the restore and started again by the normal
+// reconciliation afterwards.
+func (r *IronicReconciler) handleUpgradeRollback(cctx ironic.ControllerContext, resources ironic.Resources, heldReason string) (bool, error) {
+	ironicConf := resources.Ironic
+	installedVersion := ironicConf.Status.InstalledVersion
+	requestedVersion := cctx.VersionInfo.InstalledVersion.String()
+
+	var backup *metal3api.DatabaseBackupStatus
+	if installedVersion != "" && installedVersion != requestedVersion {
+		backup = ironic.FindDatabaseBackup(ironicConf, installedVersion, requestedVersion)
+	}
+	if backup == nil {
+		cctx.Logger.Info("no database backup to roll back to", "InstalledVersion", installedVersion, "RequestedVersion", requestedVersion)
+		r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonRollbackFailed, "Cannot roll back upgrade from %s to %s: no database backup found", installedVersion, requestedVersion)
+		return true, removeAnnotation(cctx, ironicConf, metal3api.IronicRollbackUpgradeAnnotation)
+	}
+
+	// Rolling back restarts Ironic, wait until changes can be applied
+	if heldReason != "" {
+		cctx.Logger.Info("holding the rollback", "Reason", heldReason)
+		return false, r.setNotReady(cctx, ironicConf, heldReason,
+			fmt.Sprintf("rollback of upgrade from %s to %s held (%s)", installedVersion, requestedVersion, heldReason))
+	}
+
+	// The jobs are removed once the restore is finished
+	jobs, err := ironic.UpgradeJobNames(cctx, ironicConf, requestedVersion)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := ironic.StopIronic(cctx, ironicConf)
+	if err == nil && status.IsReady() {
+		status, err = ironic.EnsureDatabaseRestore(cctx, resources, backup)
+	}
+	if err != nil {
+		return false, err
+	}
+	if !status.IsReady() {
+		reason := metal3api.IronicReasonInProgress
+		if status.IsError() {
+			reason = metal3api.IronicReasonFailed
+			r.recordEventf(ironicConf, corev1.EventTypeWarning, eventReasonRollbackFailed, "Rollback of upgrade from %s to %s failed: %s", installedVersion, requestedVersion, status)
+		}
+		err = r.setNotReady(cctx, ironicConf, reason, "rolling back upgrade: "+status.String())
+		return status.NeedsRequeue(), err
+	}
+
+	cctx.Logger.Info("upgrade rolled back", "InstalledVersion", installedVersion, "RolledBackVersion", requestedVersion)
+	ironicConf.Status.RequestedVersion = installedVersion
+	ironicConf.Status.RolledBackVersion = requestedVersion
+	// The database has been restored, a retry has to start from scratch
+	if ironicConf.Status.Upgrade != nil {
+		recordUpgrade(ironicConf, &ironicConf.Status, metal3api.UpgradeRolledBack, jobs)
+		ironicConf.Status.Upgrade = nil
+	}
+	err = r.setNotReady(cctx, ironicConf, metal3api.IronicReasonInProgress, fmt.Sprintf("upgrade to %s rolled back", requestedVersion))
+	if err != nil {
+		return false, err
+	}
+	r.recordEventf(ironicConf, corev1.EventTypeNormal, eventReasonUpgradeRolledBack, "Upgrade from %s to %s rolled back", installedVersion, requestedVersion)
+	return true, removeAnnotation(cctx, ironicConf, metal3api.IronicRollbackUpgradeAnnotation)
+}
+
+// recordUpgrade marks the current upgrade as finished and adds it to the
+// history in the provided status.
+func recordUpgrade(ironicConf *metal3api.Ironic, status *metal3api.IronicStatus, outcome metal3api.UpgradeOutcome, jobs []string) {
+	upgrade := status.Upgrade.DeepCopy()
+	upgrade.CompletionTime = ptr.To(metav1.Now())
+	upgrade.Outcome = outcome
+	upgrade.Jobs = jobs
+	status.Upgrade = upgrade
+
+	var limit int32
+	if policy := ironicConf.Spec.UpgradePolicy; policy != nil {
+		limit = policy.HistoryLimit
+	}
+	status.UpgradeHistory = ironic.RecordUpgrade(status.UpgradeHistory, *upgrade, limit)
+}
+
+// finishUpgrade records the upgrade reported by EnsureIronic once it has
+// either succeeded or failed.
+func finishUpgrade(cctx ironic.ControllerContext, ironicConf *metal3api.Ironic, newStatus *metal3api.IronicStatus, status ironic.Status) error {
+	upgrade := newStatus.Upgrade
+	if upgrade.StartTime == nil {
+		return nil
+	}
+
+	var outcome metal3api.UpgradeOutcome
+	switch {
+	case status.IsReady() && upgrade.Outcome != metal3api.UpgradeSucceeded:
+		outcome = metal3api.UpgradeSucceeded
+	case status.IsError() && upgrade.Outcome == "":
+		outcome = metal3api.UpgradeFailed
+	default:
+		return nil
+	}
+
+	jobs, err := ironic.UpgradeJobNames(cctx, ironicConf, upgrade.ToVersion)
+	if err != nil {
+		return err
+	}
+	cctx.Logger.Info("upgrade finished", "Outcome", outcome, "FromVersion", upgrade.FromVersion, "ToVersion", upgrade.ToVersion)
+	recordUpgrade(ironicConf, newStatus, outcome, jobs)
+	return nil
+}
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	"github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
+)
+
+func newTestUpgradeIronic(annotation string) *metal3api.Ironic {
+	ironicObj := newTestIronic()
+	ironicObj.Annotations = map[string]string{annotation: ""}
+	ironicObj.Spec.Version = "38.0"
+	ironicObj.Spec.Database = &metal3api.Database{
+		CredentialsName: "db-credentials",
+		Host:            "db.example.com",
+		Name:            "ironic",
+		Backup:          &metal3api.DatabaseBackup{PersistentVolumeClaimName: "backups"},
+	}
+	ironicObj.Status.InstalledVersion = "37.0"
+	ironicObj.Status.RequestedVersion = "38.0"
+	return ironicObj
+}
+
+func newTestUpgradeContext(t *testing.T, r *IronicReconciler, ironicObj *metal3api.Ironic) ironic.ControllerContext {
+	t.Helper()
+	cctx := newTestControllerContext(t, r.Scheme, r.Client)
+	defaults, err := ironic.NewVersionInfo(metal3api.Images{}, "")
+	require.NoError(t, err)
+	cctx.VersionInfo, err = defaults.WithIronicOverrides(ironicObj)
+	require.NoError(t, err)
+	return cctx
+}
+
+func TestHandleUpgradeRetry(t *testing.T) {
+	scheme := newTestScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	recorder := events.NewFakeRecorder(10)
+	ironicObj := newTestUpgradeIronic(metal3api.IronicRetryUpgradeAnnotation)
+	ironicObj.Status.RequestedVersion = "37.0"
+	ironicObj.Status.RolledBackVersion = "38.0"
+	ironicObj.Status.Upgrade = &metal3api.UpgradeStatus{
+		FromVersion:    "37.0",
+		ToVersion:      "38.0",
+		StartTime:      ptr.To(metav1.Now()),
+		CompletionTime: ptr.To(metav1.Now()),
+		Outcome:        metal3api.UpgradeFailed,
+		Jobs:           []string{"test-ironic-pre-37.0-to-38.0"},
+	}
+	failedJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ironic-pre-37.0-to-38.0",
+			Namespace: "test-ns",
+			Labels: map[string]string{
+				metal3api.IronicServiceLabel: "test-ironic",
+				metal3api.IronicVersionLabel: "38.0",
+			},
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(ironicObj).WithObjects(ironicObj, failedJob), recorder)
+	cctx := newTestUpgradeContext(t, r, ironicObj)
+
+	requeue, err := r.handleUpgradeRetry(cctx, ironicObj)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, r.Client.List(t.Context(), jobs))
+	assert.Empty(t, jobs.Items)
+
+	updated := &metal3api.Ironic{}
+	require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), updated))
+	assert.NotContains(t, updated.Annotations, metal3api.IronicRetryUpgradeAnnotation)
+	assert.Empty(t, updated.Status.RolledBackVersion)
+	require.NotNil(t, updated.Status.Upgrade)
+	assert.NotNil(t, updated.Status.Upgrade.StartTime)
+	assert.Nil(t, updated.Status.Upgrade.CompletionTime)
+	assert.Empty(t, updated.Status.Upgrade.Outcome)
+	assert.Empty(t, updated.Status.Upgrade.Jobs)
+
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], "UpgradeRetried")
+}
+
+func TestHandleUpgradeRollback(t *testing.T) {
+	scheme := newTestScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	t.Run("without backup", func(t *testing.T) {
+		recorder := events.NewFakeRecorder(10)
+		ironicObj := newTestUpgradeIronic(metal3api.IronicRollbackUpgradeAnnotation)
+
+		r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(ironicObj).WithObjects(ironicObj), recorder)
+		cctx := newTestUpgradeContext(t, r, ironicObj)
+
+		requeue, err := r.handleUpgradeRollback(cctx, ironic.Resources{Ironic: ironicObj}, "")
+		require.NoError(t, err)
+		assert.True(t, requeue)
+
+		updated := &metal3api.Ironic{}
+		require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), updated))
+		assert.NotContains(t, updated.Annotations, metal3api.IronicRollbackUpgradeAnnotation)
+		assert.Equal(t, "38.0", updated.Status.RequestedVersion)
+
+		evts := drainEvents(recorder)
+		require.Len(t, evts, 1)
+		assert.Contains(t, evts[0], "RollbackFailed")
+		assert.Contains(t, evts[0], "no database backup found")
+	})
+
+	t.Run("restores the backup", func(t *testing.T) {
+		recorder := events.NewFakeRecorder(10)
+		ironicObj := newTestUpgradeIronic(metal3api.IronicRollbackUpgradeAnnotation)
+		ironicObj.Status.DatabaseBackups = []metal3api.DatabaseBackupStatus{
+			{Location: "pvc://backups/test-ironic-backup-37.0-to-38.0.sql.gz", FromVersion: "37.0", ToVersion: "38.0"},
+		}
+		ironicObj.Status.Upgrade = &metal3api.UpgradeStatus{FromVersion: "37.0", ToVersion: "38.0"}
+
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-service", Namespace: "test-ns"}}
+
+		r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(ironicObj, &batchv1.Job{}).WithObjects(ironicObj, deployment), recorder)
+		cctx := newTestUpgradeContext(t, r, ironicObj)
+		resources := ironic.Resources{Ironic: ironicObj}
+
+		// Ironic is stopped before the database is restored
+		requeue, err := r.handleUpgradeRollback(cctx, resources, "")
+		require.NoError(t, err)
+		assert.False(t, requeue)
+		err = r.Client.Get(t.Context(), client.ObjectKeyFromObject(deployment), deployment)
+		assert.True(t, k8serrors.IsNotFound(err))
+		readyCond := meta.FindStatusCondition(ironicObj.Status.Conditions, string(metal3api.IronicStatusReady))
+		require.NotNil(t, readyCond)
+		assert.Equal(t, "rolling back upgrade: waiting for the ironic pods to terminate", readyCond.Message)
+
+		job := &batchv1.Job{}
+		key := client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-restore-38.0-to-37.0"}
+		assert.True(t, k8serrors.IsNotFound(r.Client.Get(t.Context(), key, job)))
+
+		requeue, err = r.handleUpgradeRollback(cctx, resources, "")
+		require.NoError(t, err)
+		assert.True(t, requeue)
+		readyCond = meta.FindStatusCondition(ironicObj.Status.Conditions, string(metal3api.IronicStatusReady))
+		require.NotNil(t, readyCond)
+		assert.Equal(t, metal3api.IronicReasonInProgress, readyCond.Reason)
+
+		require.NoError(t, r.Client.Get(t.Context(), key, job))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, r.Client.Status().Update(t.Context(), job))
+
+		requeue, err = r.handleUpgradeRollback(cctx, resources, "")
+		require.NoError(t, err)
+		assert.True(t, requeue)
+
+		updated := &metal3api.Ironic{}
+		require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), updated))
+		assert.NotContains(t, updated.Annotations, metal3api.IronicRollbackUpgradeAnnotation)
+		assert.Equal(t, "37.0", updated.Status.RequestedVersion)
+		assert.Equal(t, "38.0", updated.Status.RolledBackVersion)
+		assert.Nil(t, updated.Status.Upgrade)
+		require.Len(t, updated.Status.UpgradeHistory, 1)
+		assert.Equal(t, metal3api.UpgradeRolledBack, updated.Status.UpgradeHistory[0].Outcome)
+		assert.Equal(t, "38.0", updated.Status.UpgradeHistory[0].ToVersion)
+		assert.NotNil(t, updated.Status.UpgradeHistory[0].CompletionTime)
+		assert.Equal(t, []string{"test-ironic-restore-38.0-to-37.0"}, updated.Status.UpgradeHistory[0].Jobs)
+
+		evts := drainEvents(recorder)
+		require.Len(t, evts, 1)
+		assert.Contains(t, evts[0], "UpgradeRolledBack")
+
+		// The installed version is used until the upgrade is retried
+		versionInfo, err := installedVersionInfo(cctx.VersionInfo, updated)
+		require.NoError(t, err)
+		assert.Equal(t, "37.0", versionInfo.InstalledVersion.String())
+	})
+
+	t.Run("held while paused", func(t *testing.T) {
+		recorder := events.NewFakeRecorder(10)
+		ironicObj := newTestUpgradeIronic(metal3api.IronicRollbackUpgradeAnnotation)
+		ironicObj.Status.DatabaseBackups = []metal3api.DatabaseBackupStatus{
+			{Location: "pvc://backups/test-ironic-backup-37.0-to-38.0.sql.gz", FromVersion: "37.0", ToVersion: "38.0"},
+		}
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-service", Namespace: "test-ns"}}
+
+		r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(ironicObj).WithObjects(ironicObj, deployment), recorder)
+		cctx := newTestUpgradeContext(t, r, ironicObj)
+
+		requeue, err := r.handleUpgradeRollback(cctx, ironic.Resources{Ironic: ironicObj}, metal3api.IronicReasonPaused)
+		require.NoError(t, err)
+		assert.False(t, requeue)
+		require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(deployment), deployment))
+
+		updated := &metal3api.Ironic{}
+		require.NoError(t, r.Client.Get(t.Context(), client.ObjectKeyFromObject(ironicObj), updated))
+		assert.Contains(t, updated.Annotations, metal3api.IronicRollbackUpgradeAnnotation)
+		readyCond := meta.FindStatusCondition(updated.Status.Conditions, string(metal3api.IronicStatusReady))
+		require.NotNil(t, readyCond)
+		assert.Equal(t, metal3api.IronicReasonPaused, readyCond.Reason)
+		assert.Equal(t, "rollback of upgrade from 37.0 to 38.0 held (Paused)", readyCond.Message)
+		assert.Empty(t, drainEvents(recorder))
+	})
+}
+
+func TestFinishUpgrade(t *testing.T) {
+	scheme := newTestScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ironic-pre-37.0-to-38.0",
+			Namespace: "test-ns",
+			Labels: map[string]string{
+				metal3api.IronicServiceLabel: "test-ironic",
+				metal3api.IronicVersionLabel: "38.0",
+			},
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Upgrade metal3api.UpgradeStatus
+		Status  ironic.Status
+
+		ExpectedOutcome  metal3api.UpgradeOutcome
+		ExpectedRecorded bool
+	}{
+		{
+			Scenario:         "succeeded",
+			Upgrade:          metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now())},
+			Status:           ironic.Status{Ready: true},
+			ExpectedOutcome:  metal3api.UpgradeSucceeded,
+			ExpectedRecorded: true,
+		},
+		{
+			Scenario:         "failed",
+			Upgrade:          metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now())},
+			Status:           ironic.Status{Fatal: errors.New("pre-upgrade job failed")},
+			ExpectedOutcome:  metal3api.UpgradeFailed,
+			ExpectedRecorded: true,
+		},
+		{
+			Scenario:         "succeeded after failure",
+			Upgrade:          metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now()), Outcome: metal3api.UpgradeFailed},
+			Status:           ironic.Status{Ready: true},
+			ExpectedOutcome:  metal3api.UpgradeSucceeded,
+			ExpectedRecorded: true,
+		},
+		{
+			Scenario:        "already failed",
+			Upgrade:         metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now()), Outcome: metal3api.UpgradeFailed},
+			Status:          ironic.Status{Fatal: errors.New("pre-upgrade job failed")},
+			ExpectedOutcome: metal3api.UpgradeFailed,
+		},
+		{
+			Scenario:        "already succeeded",
+			Upgrade:         metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now()), Outcome: metal3api.UpgradeSucceeded},
+			Status:          ironic.Status{Ready: true},
+			ExpectedOutcome: metal3api.UpgradeSucceeded,
+		},
+		{
+			Scenario: "in progress",
+			Upgrade:  metal3api.UpgradeStatus{StartTime: ptr.To(metav1.Now())},
+			Status:   ironic.Status{Reason: metal3api.IronicReasonInProgress, Message: "pre-upgrade job not complete yet"},
+		},
+		{
+			Scenario: "not started",
+			Status:   ironic.Status{Ready: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironicObj := newTestUpgradeIronic(metal3api.IronicRetryUpgradeAnnotation)
+			ironicObj.Spec.UpgradePolicy = &metal3api.UpgradePolicy{HistoryLimit: 2}
+			ironicObj.Status.UpgradeHistory = []metal3api.UpgradeStatus{
+				{FromVersion: "35.0", ToVersion: "36.0", Outcome: metal3api.UpgradeSucceeded},
+				{FromVersion: "36.0", ToVersion: "37.0", Outcome: metal3api.UpgradeSucceeded},
+			}
+			r := newTestReconciler(scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, job), events.NewFakeRecorder(10))
+			cctx := newTestUpgradeContext(t, r, ironicObj)
+
+			newStatus := ironicObj.Status.DeepCopy()
+			upgrade := tc.Upgrade
+			upgrade.FromVersion = "37.0"
+			upgrade.ToVersion = "38.0"
+			newStatus.Upgrade = &upgrade
+
+			require.NoError(t, finishUpgrade(cctx, ironicObj, newStatus, tc.Status))
+			assert.Equal(t, tc.ExpectedOutcome, newStatus.Upgrade.Outcome)
+
+			// The history limit is 2, so the oldest entry is dropped
+			require.Len(t, newStatus.UpgradeHistory, 2)
+			last := newStatus.UpgradeHistory[1]
+			if tc.ExpectedRecorded {
+				assert.Equal(t, "37.0", newStatus.UpgradeHistory[0].ToVersion)
+				assert.Equal(t, "38.0", last.ToVersion)
+				assert.Equal(t, tc.ExpectedOutcome, last.Outcome)
+				assert.NotNil(t, last.CompletionTime)
+				assert.Equal(t, []string{"test-ironic-pre-37.0-to-38.0"}, last.Jobs)
+			} else {
+				assert.Equal(t, "37.0", last.ToVersion)
+			}
+		})
+	}
+}
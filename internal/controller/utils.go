@@ -48,14 +48,43 @@ func removeFinalizer(cctx ironic.ControllerContext, obj client.Object) (bool, er
 	return false, nil
 }
 
-func setCondition(conditions *[]metav1.Condition, generation int64, value bool, reason, message string) {
+// componentConditions lists per-component conditions in the order in which
+// the components are reconciled.
+var componentConditions = []metal3api.IronicStatusConditionType{
+	metal3api.IronicStatusNetworkingServiceReady,
+	metal3api.IronicStatusDatabaseMigrated,
+	metal3api.IronicStatusWorkloadAvailable,
+	metal3api.IronicStatusServiceReady,
+	metal3api.IronicStatusIngressReady,
+	metal3api.IronicStatusMonitoringReady,
+	metal3api.IronicStatusClientCertificatesVerified,
+}
+
+func removeAnnotation(cctx ironic.ControllerContext, obj client.Object, key string) error {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[key]; !ok {
+		return nil
+	}
+	delete(annotations, key)
+	obj.SetAnnotations(annotations)
+	cctx.Logger.Info("removing annotation " + key)
+	if err := cctx.Client.Update(cctx.Context, obj); err != nil {
+		return fmt.Errorf("failed to remove annotation %s: %w", key, err)
+	}
+	return nil
+}
+
+func setCondition(conditions *[]metav1.Condition, condType metal3api.IronicStatusConditionType, generation int64, value bool, reason, message string) {
 	condStatus := metav1.ConditionFalse
 	if value {
 		condStatus = metav1.ConditionTrue
 	}
-	status := metal3api.IronicStatusReady
+	setConditionStatus(conditions, condType, generation, condStatus, reason, message)
+}
+
+func setConditionStatus(conditions *[]metav1.Condition, condType metal3api.IronicStatusConditionType, generation int64, condStatus metav1.ConditionStatus, reason, message string) {
 	cond := metav1.Condition{
-		Type:               string(status),
+		Type:               string(condType),
 		Status:             condStatus,
 		ObservedGeneration: generation,
 		Reason:             reason,
@@ -97,22 +126,53 @@ func generateSecret(cctx ironic.ControllerContext, owner metav1.Object, meta *me
 	return
 }
 
+// statusReason returns the condition value and reason matching the status.
+func statusReason(status ironic.Status) (bool, string) {
+	switch {
+	case status.Reason != "" && !status.IsReady():
+		return false, status.Reason
+	case status.IsError():
+		return false, metal3api.IronicReasonFailed
+	case !status.IsReady():
+		return false, metal3api.IronicReasonInProgress
+	case status.IsNotRequired():
+		return true, metal3api.IronicReasonNotRequired
+	default:
+		return true, metal3api.IronicReasonAvailable
+	}
+}
+
+// setConditionsFromStatus sets per-component conditions and the Ready
+// condition. Components that have not been evaluated (e.g. because an
+// earlier component is not ready yet) are set to Unknown. Ready is only true
+// when the overall status is ready and no component is failed or in progress.
 func setConditionsFromStatus(cctx ironic.ControllerContext, status ironic.Status, conditions *[]metav1.Condition, generation int64, resource string) {
-	message := fmt.Sprintf("%s: %s", resource, status)
+	for _, condType := range componentConditions {
+		componentStatus, ok := status.Components[condType]
+		if !ok {
+			setConditionStatus(conditions, condType, generation, metav1.ConditionUnknown,
+				metal3api.IronicReasonInProgress, "waiting for other components")
+			continue
+		}
 
-	if !status.IsReady() {
-		reason := metal3api.IronicReasonInProgress
-		if status.IsError() {
-			reason = metal3api.IronicReasonFailed
+		value, reason := statusReason(componentStatus)
+		setCondition(conditions, condType, generation, value, reason, componentStatus.String())
+		if !value && status.IsReady() {
+			// Should not happen, but do not report readiness with a component that is not ready.
+			status = componentStatus
 		}
+	}
 
+	message := fmt.Sprintf("%s: %s", resource, status)
+	value, reason := statusReason(status)
+	if !value {
 		cctx.Logger.Info(status.String())
-		setCondition(conditions, generation, false, reason, message)
-
-		return
+	} else {
+		// NotRequired only makes sense for individual components.
+		reason = metal3api.IronicReasonAvailable
 	}
 
-	setCondition(conditions, generation, true, metal3api.IronicReasonAvailable, message)
+	setCondition(conditions, metal3api.IronicStatusReady, generation, value, reason, message)
 }
 
 // isStatusReady checks if the Ironic status indicates it's ready by looking at the Ready condition.
@@ -18,38 +18,110 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+	metal3v1beta1 "github.com/metal3-io/ironic-standalone-operator/api/v1beta1"
 	validation "github.com/metal3-io/ironic-standalone-operator/pkg/ironic"
 )
 
 // log is for logging in this package.
 var ironiclog = logf.Log.WithName("webhooks").WithName("Ironic")
 
+const mutatingWebhookPath = "/mutate-ironic-metal3-io-v1alpha1-ironic"
+
 func SetupIronicWebhookWithManager(mgr ctrl.Manager) error {
+	// NOTE: the generic defaulter interface does not support
+	// warnings, so the defaulting handler is registered directly.
+	mgr.GetWebhookServer().Register(mutatingWebhookPath, &webhook.Admission{
+		Handler: &IronicCustomDefaulter{decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+
 	return ctrl.NewWebhookManagedBy(mgr, &metal3api.Ironic{}).
 		WithValidator(&IronicCustomValidator{}).
+		WithConverter(conversion.NewHubSpokeConverter(&metal3api.Ironic{},
+			conversion.NewSpokeConverter(&metal3v1beta1.Ironic{}, convertToV1beta1, convertFromV1beta1),
+		)).
 		Complete()
 }
 
+// The v1alpha1 version is the storage version and serves as the conversion
+// hub, conversion functions are implemented in the v1beta1 package.
+func convertToV1beta1(_ context.Context, src *metal3api.Ironic, dst *metal3v1beta1.Ironic) error {
+	metal3v1beta1.ConvertFromHub(src, dst)
+	return nil
+}
+
+func convertFromV1beta1(_ context.Context, src *metal3v1beta1.Ironic, dst *metal3api.Ironic) error {
+	metal3v1beta1.ConvertToHub(src, dst)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/mutate-ironic-metal3-io-v1alpha1-ironic,mutating=true,failurePolicy=fail,sideEffects=None,groups=ironic.metal3.io,resources=ironics,verbs=create;update,versions=v1alpha1,name=mutate-ironic.ironic.metal3.io,admissionReviewVersions=v1
+
+// IronicCustomDefaulter sets defaults and replaces deprecated fields with
+// their modern counterparts, returning a warning for each deprecated field.
+type IronicCustomDefaulter struct {
+	decoder admission.Decoder
+}
+
+// Default applies defaults to the provided object and returns deprecation warnings.
+func (r *IronicCustomDefaulter) Default(ironic *metal3api.Ironic) admission.Warnings {
+	warnings := validation.DeprecationWarnings(&ironic.Spec)
+	validation.NormalizeDeprecated(&ironic.Spec)
+	validation.SetDefaults(&ironic.Spec)
+	return warnings
+}
+
+// Handle implements admission.Handler.
+func (r *IronicCustomDefaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	ironic := &metal3api.Ironic{}
+	if err := r.decoder.Decode(req, ironic); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	ironiclog.Info("default", "name", ironic.Name)
+	warnings := r.Default(ironic)
+
+	marshalled, err := json.Marshal(ironic)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled).WithWarnings(warnings...)
+}
+
 // +kubebuilder:webhook:path=/validate-ironic-metal3-io-v1alpha1-ironic,mutating=false,failurePolicy=fail,sideEffects=None,groups=ironic.metal3.io,resources=ironics,verbs=create;update,versions=v1alpha1,name=validate-ironic.ironic.metal3.io,admissionReviewVersions=v1
 
 type IronicCustomValidator struct{}
 
+// invalidError converts validation errors into a structured Invalid error.
+func invalidError(ironic *metal3api.Ironic, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(metal3api.GroupVersion.WithKind("Ironic").GroupKind(), ironic.Name, errs)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *IronicCustomValidator) ValidateCreate(_ context.Context, ironic *metal3api.Ironic) (admission.Warnings, error) {
 	ironiclog.Info("validate create", "name", ironic.Name)
-	return nil, validation.ValidateIronic(&ironic.Spec, nil)
+	return nil, invalidError(ironic, validation.ValidateIronic(&ironic.Spec, nil))
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *IronicCustomValidator) ValidateUpdate(_ context.Context, oldIronic, ironic *metal3api.Ironic) (admission.Warnings, error) {
 	ironiclog.Info("validate update", "name", ironic.Name)
-	return nil, validation.ValidateIronic(&ironic.Spec, &oldIronic.Spec)
+	return nil, invalidError(ironic, validation.ValidateIronic(&ironic.Spec, &oldIronic.Spec))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
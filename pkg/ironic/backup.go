@@ -0,0 +1,419 @@
+package ironic
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	databaseBackupContainer = "database-backup"
+	databaseBackupDir       = "/backup"
+
+	defaultBackupRetain  = 3
+	maxBackupRetain      = 50
+	defaultBackupRegion  = "us-east-1"
+	backupAccessKeyIDKey = "accessKeyID"
+	backupSecretKeyKey   = "secretAccessKey"
+)
+
+// databaseClientScript prepares the mysql client arguments and the s3
+// helper shared by the backup and restore scripts.
+const databaseClientScript = `
+set -euo pipefail
+
+mysql_args=(--host="$MARIADB_HOST" --user="$(cat /auth/mariadb/username)")
+if [[ -d /certs/ca/mariadb ]]; then
+    ca_file=/certs/ca/mariadb/ca.crt
+    [[ -f "$ca_file" ]] || ca_file=/certs/ca/mariadb/tls.crt
+    mysql_args+=("--ssl-ca=$ca_file")
+fi
+if [[ -n "${MARIADB_CERT_FILE:-}" ]]; then
+    mysql_args+=("--ssl-cert=$MARIADB_CERT_FILE" "--ssl-key=$MARIADB_KEY_FILE")
+fi
+
+MYSQL_PWD="$(cat /auth/mariadb/password)"
+export MYSQL_PWD
+
+s3() {
+    curl --fail --silent --show-error --aws-sigv4 "aws:amz:$S3_REGION:s3" \
+        --user "$AWS_ACCESS_KEY_ID:$AWS_SECRET_ACCESS_KEY" "$@"
+}
+`
+
+// databaseBackupScript dumps the database into the backup directory and
+// uploads it to the object storage if configured. Backups listed in
+// DELETE_BACKUPS are removed afterwards.
+const databaseBackupScript = databaseClientScript + `
+mysqldump --single-transaction --routines --triggers "${mysql_args[@]}" \
+    "$MARIADB_DATABASE" | gzip > "/backup/$BACKUP_FILE.tmp"
+mv "/backup/$BACKUP_FILE.tmp" "/backup/$BACKUP_FILE"
+
+if [[ -n "${S3_ENDPOINT:-}" ]]; then
+    s3 --upload-file "/backup/$BACKUP_FILE" "$S3_ENDPOINT/$S3_BUCKET/$S3_PREFIX$BACKUP_FILE"
+    for name in $DELETE_BACKUPS; do
+        s3 --request DELETE "$S3_ENDPOINT/$S3_BUCKET/$S3_PREFIX$name"
+    done
+else
+    for name in $DELETE_BACKUPS; do
+        rm -f "/backup/$name"
+    done
+fi
+`
+
+// databaseRestoreScript replaces the database content with the backup,
+// downloading it from the object storage if configured.
+const databaseRestoreScript = databaseClientScript + `
+if [[ -n "${S3_ENDPOINT:-}" ]]; then
+    s3 --output "/backup/$BACKUP_FILE" "$S3_ENDPOINT/$S3_BUCKET/$S3_PREFIX$BACKUP_FILE"
+fi
+
+# Tables created by the failed migration are not in the dump
+tables="$(mysql "${mysql_args[@]}" --batch --skip-column-names --execute "SHOW TABLES" "$MARIADB_DATABASE")"
+{
+    echo "SET FOREIGN_KEY_CHECKS=0;"
+    for table in $tables; do
+        echo "DROP TABLE IF EXISTS $table;"
+    done
+    gunzip --stdout "/backup/$BACKUP_FILE"
+} | mysql "${mysql_args[@]}" "$MARIADB_DATABASE"
+`
+
+func backupRequired(cctx ControllerContext, ironic *metal3api.Ironic) bool {
+	// Nothing to back up on the initial installation
+	return ironic.Spec.Database.Backup != nil && ironic.Status.InstalledVersion != "" &&
+		upgradeJobRequired(cctx, ironic)
+}
+
+func databaseBackupJobName(cctx ControllerContext, ironic *metal3api.Ironic) string {
+	return fmt.Sprintf("%s-backup-%s-to-%s", ironic.Name, ironic.Status.InstalledVersion, cctx.VersionInfo.InstalledVersion)
+}
+
+// databaseBackupLocation returns the location of the backup file.
+func databaseBackupLocation(backup *metal3api.DatabaseBackup, fileName string) string {
+	if storage := backup.ObjectStorage; storage != nil {
+		return fmt.Sprintf("%s/%s/%s%s", strings.TrimSuffix(storage.Endpoint, "/"), storage.Bucket, storage.Prefix, fileName)
+	}
+	return fmt.Sprintf("pvc://%s/%s", backup.PersistentVolumeClaimName, fileName)
+}
+
+// RecordDatabaseBackup adds a new backup to the list, replacing any backup
+// with the same location, and drops the oldest backups beyond the limit.
+func RecordDatabaseBackup(backups []metal3api.DatabaseBackupStatus, backup metal3api.DatabaseBackupStatus, retain int32) []metal3api.DatabaseBackupStatus {
+	if retain <= 0 {
+		retain = defaultBackupRetain
+	}
+	retain = min(retain, maxBackupRetain)
+
+	result := slices.DeleteFunc(slices.Clone(backups), func(existing metal3api.DatabaseBackupStatus) bool {
+		return existing.Location == backup.Location
+	})
+	result = append(result, backup)
+	if excess := len(result) - int(retain); excess > 0 {
+		result = result[excess:]
+	}
+	return result
+}
+
+// expiredBackups returns the file names of the backups that will be dropped
+// once the new backup is recorded.
+func expiredBackups(ironic *metal3api.Ironic, location string) (result []string) {
+	kept := RecordDatabaseBackup(ironic.Status.DatabaseBackups,
+		metal3api.DatabaseBackupStatus{Location: location}, ironic.Spec.Database.Backup.Retain)
+	for _, backup := range ironic.Status.DatabaseBackups {
+		if !slices.ContainsFunc(kept, func(other metal3api.DatabaseBackupStatus) bool { return other.Location == backup.Location }) {
+			result = append(result, path.Base(backup.Location))
+		}
+	}
+	return result
+}
+
+// backupStorage returns the volume holding backup files and the environment
+// variables required to access the object storage, if configured.
+func backupStorage(backup *metal3api.DatabaseBackup) (corev1.Volume, []corev1.EnvVar) {
+	volume := corev1.Volume{Name: "database-backup"}
+	storage := backup.ObjectStorage
+	if storage == nil {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: backup.PersistentVolumeClaimName,
+		}
+		return volume, nil
+	}
+
+	// The backup is only stored until it is uploaded
+	volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+
+	region := storage.Region
+	if region == "" {
+		region = defaultBackupRegion
+	}
+	return volume, []corev1.EnvVar{
+		{
+			Name:  "S3_ENDPOINT",
+			Value: strings.TrimSuffix(storage.Endpoint, "/"),
+		},
+		{
+			Name:  "S3_BUCKET",
+			Value: storage.Bucket,
+		},
+		{
+			Name:  "S3_PREFIX",
+			Value: storage.Prefix,
+		},
+		{
+			Name:  "S3_REGION",
+			Value: region,
+		},
+		{
+			Name: "AWS_ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: storage.CredentialsName},
+					Key:                  backupAccessKeyIDKey,
+				},
+			},
+		},
+		{
+			Name: "AWS_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: storage.CredentialsName},
+					Key:                  backupSecretKeyKey,
+				},
+			},
+		},
+	}
+}
+
+func newBackupJobTemplate(cctx ControllerContext, resources Resources, script, version string, extraEnvVars []corev1.EnvVar) corev1.PodTemplateSpec {
+	ironic := resources.Ironic
+	database := ironic.Spec.Database
+
+	volumes, mounts := databaseClientMounts(database)
+	backupVolume, storageEnvVars := backupStorage(database.Backup)
+	volumes = append(volumes, backupVolume)
+	mounts = append(mounts, corev1.VolumeMount{
+		Name:      backupVolume.Name,
+		MountPath: databaseBackupDir,
+	})
+	envVars := append(databaseClientEnvVars(database), extraEnvVars...)
+	envVars = append(envVars, storageEnvVars...)
+
+	containers := []corev1.Container{
+		{
+			Name:         databaseBackupContainer,
+			Image:        cctx.VersionInfo.DatabaseBackupImage,
+			Command:      []string{"/bin/bash", "-c", script},
+			Env:          envVars,
+			VolumeMounts: mounts,
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:  ptr.To(ironicUser),
+				RunAsGroup: ptr.To(ironicGroup),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+	}
+	return applyOverridesToPod(ironic.Spec.Overrides, corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				metal3api.IronicServiceLabel: ironic.Name,
+				metal3api.IronicVersionLabel: version,
+			},
+			Annotations: databaseVersionAnnotations(resources),
+		},
+		Spec: corev1.PodSpec{
+			Containers: containers,
+			Volumes:    volumes,
+			// Make the persistent volume writable
+			SecurityContext: &corev1.PodSecurityContext{
+				FSGroup: ptr.To(ironicGroup),
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	})
+}
+
+func newDatabaseBackupTemplate(cctx ControllerContext, resources Resources, fileName string) corev1.PodTemplateSpec {
+	ironic := resources.Ironic
+	return newBackupJobTemplate(cctx, resources, databaseBackupScript, cctx.VersionInfo.InstalledVersion.String(), []corev1.EnvVar{
+		{
+			Name:  "BACKUP_FILE",
+			Value: fileName,
+		},
+		{
+			Name:  "DELETE_BACKUPS",
+			Value: strings.Join(expiredBackups(ironic, databaseBackupLocation(ironic.Spec.Database.Backup, fileName)), " "),
+		},
+	})
+}
+
+// ensureDatabaseBackupJob backs up the database before the schema upgrade.
+// The returned status contains the backup once it has been completed.
+func ensureDatabaseBackupJob(cctx ControllerContext, resources Resources) (Status, error) {
+	ironic := resources.Ironic
+	if !backupRequired(cctx, ironic) {
+		return ready()
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      databaseBackupJobName(cctx, ironic),
+			Namespace: ironic.Namespace,
+		},
+	}
+	fileName := job.Name + ".sql.gz"
+	backupStatus := metal3api.DatabaseBackupStatus{
+		Location:    databaseBackupLocation(ironic.Spec.Database.Backup, fileName),
+		FromVersion: ironic.Status.InstalledVersion,
+		ToVersion:   cctx.VersionInfo.InstalledVersion.String(),
+	}
+
+	// Already recorded (e.g. the job has expired)
+	if slices.ContainsFunc(ironic.Status.DatabaseBackups, func(existing metal3api.DatabaseBackupStatus) bool {
+		return existing.Location == backupStatus.Location && existing.FromVersion == backupStatus.FromVersion &&
+			existing.ToVersion == backupStatus.ToVersion
+	}) {
+		return ready()
+	}
+
+	err := cctx.Client.Get(cctx.Context, client.ObjectKeyFromObject(job), job)
+	if err == nil {
+		if !hasJobCondition(job, batchv1.JobComplete) {
+			return getJobStatus(cctx, job, "database backup")
+		}
+		if job.Status.CompletionTime != nil {
+			backupStatus.CompletionTime = *job.Status.CompletionTime
+		} else {
+			backupStatus.CompletionTime = metav1.Now()
+		}
+		cctx.Logger.Info("database backup completed", "Location", backupStatus.Location)
+		return Status{Ready: true, DatabaseBackup: &backupStatus}, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return transientError(err)
+	}
+
+	cctx.Logger.Info("creating a database backup job", "Job", job.Name, "Location", backupStatus.Location)
+	job.Labels = map[string]string{
+		metal3api.IronicServiceLabel: ironic.Name,
+		metal3api.IronicVersionLabel: cctx.VersionInfo.InstalledVersion.String(),
+	}
+	job.Spec = batchv1.JobSpec{
+		Template:                newDatabaseBackupTemplate(cctx, resources, fileName),
+		TTLSecondsAfterFinished: ptr.To(jobTTLSeconds),
+		PodReplacementPolicy:    ptr.To(batchv1.Failed),
+	}
+	err = controllerutil.SetControllerReference(ironic, job, cctx.Scheme)
+	if err == nil {
+		err = cctx.Client.Create(cctx.Context, job)
+	}
+	if err != nil {
+		return transientError(err)
+	}
+	return updated()
+}
+
+// FindDatabaseBackup returns the most recent backup taken before upgrading
+// between the provided versions, nil if there is none.
+func FindDatabaseBackup(ironic *metal3api.Ironic, fromVersion, toVersion string) *metal3api.DatabaseBackupStatus {
+	backups := ironic.Status.DatabaseBackups
+	for i := len(backups) - 1; i >= 0; i-- {
+		if backups[i].FromVersion == fromVersion && backups[i].ToVersion == toVersion {
+			return &backups[i]
+		}
+	}
+	return nil
+}
+
+func databaseRestoreJobName(ironic *metal3api.Ironic, backup *metal3api.DatabaseBackupStatus) string {
+	return fmt.Sprintf("%s-restore-%s-to-%s", ironic.Name, backup.ToVersion, backup.FromVersion)
+}
+
+// EnsureDatabaseRestore restores the database from a backup taken before
+// a failed upgrade. Once the restore is complete, the jobs of the failed
+// upgrade are removed so that it can be attempted again later.
+func EnsureDatabaseRestore(cctx ControllerContext, resources Resources, backup *metal3api.DatabaseBackupStatus) (Status, error) {
+	ironic := resources.Ironic
+	if ironic.Spec.Database == nil || ironic.Spec.Database.Backup == nil {
+		return Status{Fatal: errors.New("cannot restore the database: backups are not configured")}, nil
+	}
+	fileName := path.Base(backup.Location)
+	if databaseBackupLocation(ironic.Spec.Database.Backup, fileName) != backup.Location {
+		return Status{Fatal: fmt.Errorf("cannot restore the database: backup %s is not in the configured storage", backup.Location)}, nil
+	}
+
+	jobs, err := listUpgradeJobs(cctx, ironic, backup.ToVersion)
+	if err != nil {
+		return transientError(err)
+	}
+
+	jobName := databaseRestoreJobName(ironic, backup)
+	var job *batchv1.Job
+	for i := range jobs {
+		if jobs[i].Name == jobName {
+			job = &jobs[i]
+			continue
+		}
+		// Never restore under a running migration
+		if !hasJobCondition(&jobs[i], batchv1.JobComplete) && !hasJobCondition(&jobs[i], batchv1.JobFailed) {
+			return inProgress(fmt.Sprintf("waiting for job %s to finish before restoring the database", jobs[i].Name))
+		}
+	}
+
+	if job == nil {
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jobName,
+				Namespace: ironic.Namespace,
+				Labels: map[string]string{
+					metal3api.IronicServiceLabel: ironic.Name,
+					metal3api.IronicVersionLabel: backup.ToVersion,
+				},
+			},
+			Spec: batchv1.JobSpec{
+				Template: newBackupJobTemplate(cctx, resources, databaseRestoreScript, backup.ToVersion, []corev1.EnvVar{
+					{
+						Name:  "BACKUP_FILE",
+						Value: fileName,
+					},
+				}),
+				TTLSecondsAfterFinished: ptr.To(jobTTLSeconds),
+				PodReplacementPolicy:    ptr.To(batchv1.Failed),
+			},
+		}
+		cctx.Logger.Info("creating a database restore job", "Job", job.Name, "Location", backup.Location)
+		err = controllerutil.SetControllerReference(ironic, job, cctx.Scheme)
+		if err == nil {
+			err = cctx.Client.Create(cctx.Context, job)
+		}
+		if err != nil {
+			return transientError(err)
+		}
+		return updated()
+	}
+
+	if !hasJobCondition(job, batchv1.JobComplete) {
+		return getJobStatus(cctx, job, "database restore")
+	}
+
+	cctx.Logger.Info("database restored", "Location", backup.Location)
+	if err = deleteJobs(cctx, jobs); err != nil {
+		return transientError(err)
+	}
+	return ready()
+}
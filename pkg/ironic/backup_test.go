@@ -0,0 +1,349 @@
+package ironic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func envValues(container corev1.Container) map[string]string {
+	result := make(map[string]string, len(container.Env))
+	for _, env := range container.Env {
+		result[env.Name] = env.Value
+	}
+	return result
+}
+
+func TestRecordDatabaseBackup(t *testing.T) {
+	backup := func(location string) metal3api.DatabaseBackupStatus {
+		return metal3api.DatabaseBackupStatus{Location: location}
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Backups []metal3api.DatabaseBackupStatus
+		Retain  int32
+
+		Expected []metal3api.DatabaseBackupStatus
+	}{
+		{
+			Scenario: "first backup",
+			Expected: []metal3api.DatabaseBackupStatus{backup("new")},
+		},
+		{
+			Scenario: "default retention",
+			Backups:  []metal3api.DatabaseBackupStatus{backup("1"), backup("2"), backup("3")},
+			Expected: []metal3api.DatabaseBackupStatus{backup("2"), backup("3"), backup("new")},
+		},
+		{
+			Scenario: "explicit retention",
+			Backups:  []metal3api.DatabaseBackupStatus{backup("1"), backup("2"), backup("3")},
+			Retain:   1,
+			Expected: []metal3api.DatabaseBackupStatus{backup("new")},
+		},
+		{
+			Scenario: "same location",
+			Backups:  []metal3api.DatabaseBackupStatus{backup("new"), backup("2")},
+			Expected: []metal3api.DatabaseBackupStatus{backup("2"), backup("new")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			result := RecordDatabaseBackup(tc.Backups, backup("new"), tc.Retain)
+			assert.Equal(t, tc.Expected, result)
+		})
+	}
+}
+
+func TestEnsureDatabaseBackupJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	newIronic := func(backup *metal3api.DatabaseBackup, installedVersion string) *metal3api.Ironic {
+		return &metal3api.Ironic{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "abc-123"},
+			Spec: metal3api.IronicSpec{
+				Version: "38.0",
+				Database: &metal3api.Database{
+					CredentialsName: "db-credentials",
+					Host:            "db.example.com",
+					Name:            "ironic",
+					Backup:          backup,
+				},
+			},
+			Status: metal3api.IronicStatus{
+				InstalledVersion: installedVersion,
+				DatabaseBackups: []metal3api.DatabaseBackupStatus{
+					{Location: "pvc://backups/test-backup-35.0-to-37.0.sql.gz", FromVersion: "35.0", ToVersion: "37.0"},
+				},
+			},
+		}
+	}
+
+	setup := func(t *testing.T, ironic *metal3api.Ironic) (ControllerContext, client.Client) {
+		t.Helper()
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+		defaults, err := NewVersionInfo(metal3api.Images{}, "")
+		require.NoError(t, err)
+		cctx.VersionInfo, err = defaults.WithIronicOverrides(ironic)
+		require.NoError(t, err)
+		return cctx, c
+	}
+
+	t.Run("not required on initial installation", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{PersistentVolumeClaimName: "backups"}, "")
+		cctx, _ := setup(t, ironic)
+
+		status, err := ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+		assert.Nil(t, status.DatabaseBackup)
+	})
+
+	t.Run("persistent volume claim", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{PersistentVolumeClaimName: "backups", Retain: 1}, "37.0")
+		cctx, c := setup(t, ironic)
+
+		status, err := ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+
+		job := &batchv1.Job{}
+		key := client.ObjectKey{Namespace: "test", Name: "test-backup-37.0-to-38.0"}
+		require.NoError(t, c.Get(t.Context(), key, job))
+		podSpec := job.Spec.Template.Spec
+		assert.Equal(t, "quay.io/metal3-io/mariadb:latest", podSpec.Containers[0].Image)
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name: "database-backup",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+			},
+		})
+		env := envValues(podSpec.Containers[0])
+		assert.Equal(t, "test-backup-37.0-to-38.0.sql.gz", env["BACKUP_FILE"])
+		assert.Equal(t, "test-backup-35.0-to-37.0.sql.gz", env["DELETE_BACKUPS"])
+		assert.NotContains(t, env, "S3_ENDPOINT")
+
+		status, err = ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		assert.Equal(t, "database backup job not complete yet", status.Message)
+
+		completionTime := metav1.NewTime(time.Now().Truncate(time.Second))
+		job.Status.CompletionTime = &completionTime
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(t.Context(), job))
+
+		status, err = ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+		assert.Equal(t, &metal3api.DatabaseBackupStatus{
+			Location:       "pvc://backups/test-backup-37.0-to-38.0.sql.gz",
+			FromVersion:    "37.0",
+			ToVersion:      "38.0",
+			CompletionTime: completionTime,
+		}, status.DatabaseBackup)
+
+		// Once recorded, the backup is not repeated
+		ironic.Status.DatabaseBackups = RecordDatabaseBackup(ironic.Status.DatabaseBackups, *status.DatabaseBackup, 1)
+		require.NoError(t, c.Delete(t.Context(), job))
+		status, err = ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+		assert.Nil(t, status.DatabaseBackup)
+	})
+
+	t.Run("object storage", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{
+			ObjectStorage: &metal3api.ObjectStorage{
+				Endpoint:        "http://minio.test.svc:9000/",
+				Bucket:          "ironic",
+				Prefix:          "backups/",
+				CredentialsName: "minio",
+			},
+		}, "37.0")
+		cctx, c := setup(t, ironic)
+
+		_, err := ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+
+		job := &batchv1.Job{}
+		key := client.ObjectKey{Namespace: "test", Name: "test-backup-37.0-to-38.0"}
+		require.NoError(t, c.Get(t.Context(), key, job))
+		podSpec := job.Spec.Template.Spec
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name:         "database-backup",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		env := envValues(podSpec.Containers[0])
+		assert.Equal(t, "http://minio.test.svc:9000", env["S3_ENDPOINT"])
+		assert.Equal(t, "ironic", env["S3_BUCKET"])
+		assert.Equal(t, "backups/", env["S3_PREFIX"])
+		assert.Equal(t, "us-east-1", env["S3_REGION"])
+		// Default retention keeps the existing backup
+		assert.Empty(t, env["DELETE_BACKUPS"])
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{
+			Name: "AWS_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "minio"},
+					Key:                  "secretAccessKey",
+				},
+			},
+		})
+
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(t.Context(), job))
+
+		status, err := ensureDatabaseBackupJob(cctx, Resources{Ironic: ironic})
+		require.NoError(t, err)
+		require.NotNil(t, status.DatabaseBackup)
+		assert.Equal(t, "http://minio.test.svc:9000/ironic/backups/test-backup-37.0-to-38.0.sql.gz", status.DatabaseBackup.Location)
+	})
+}
+
+func TestEnsureDatabaseRestore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	backup := &metal3api.DatabaseBackupStatus{
+		Location:    "pvc://backups/test-backup-37.0-to-38.0.sql.gz",
+		FromVersion: "37.0",
+		ToVersion:   "38.0",
+	}
+	newIronic := func(backupSpec *metal3api.DatabaseBackup) *metal3api.Ironic {
+		return &metal3api.Ironic{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "abc-123"},
+			Spec: metal3api.IronicSpec{
+				Version: "38.0",
+				Database: &metal3api.Database{
+					CredentialsName: "db-credentials",
+					Host:            "db.example.com",
+					Name:            "ironic",
+					Backup:          backupSpec,
+				},
+			},
+			Status: metal3api.IronicStatus{
+				InstalledVersion: "37.0",
+				DatabaseBackups:  []metal3api.DatabaseBackupStatus{*backup},
+			},
+		}
+	}
+	upgradeJob := func(name string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels: map[string]string{
+					metal3api.IronicServiceLabel: "test",
+					metal3api.IronicVersionLabel: "38.0",
+				},
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	failed := batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}
+	complete := batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}
+
+	setup := func(t *testing.T, ironic *metal3api.Ironic, objects ...client.Object) (ControllerContext, client.Client) {
+		t.Helper()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&batchv1.Job{}).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+		defaults, err := NewVersionInfo(metal3api.Images{}, "")
+		require.NoError(t, err)
+		cctx.VersionInfo, err = defaults.WithIronicOverrides(ironic)
+		require.NoError(t, err)
+		return cctx, c
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{PersistentVolumeClaimName: "backups"})
+		cctx, c := setup(t, ironic, upgradeJob("test-pre-37.0-to-38.0", failed), upgradeJob("test-backup-37.0-to-38.0", complete))
+
+		status, err := EnsureDatabaseRestore(cctx, Resources{Ironic: ironic}, backup)
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+		assert.True(t, status.NeedsRequeue())
+
+		job := &batchv1.Job{}
+		key := client.ObjectKey{Namespace: "test", Name: "test-restore-38.0-to-37.0"}
+		require.NoError(t, c.Get(t.Context(), key, job))
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, []string{"/bin/bash", "-c", databaseRestoreScript}, container.Command)
+		assert.Equal(t, "test-backup-37.0-to-38.0.sql.gz", envValues(container)["BACKUP_FILE"])
+		assert.Equal(t, "38.0", job.Labels[metal3api.IronicVersionLabel])
+
+		status, err = EnsureDatabaseRestore(cctx, Resources{Ironic: ironic}, backup)
+		require.NoError(t, err)
+		assert.Equal(t, "database restore job not complete yet", status.Message)
+
+		job.Status.Conditions = []batchv1.JobCondition{complete}
+		require.NoError(t, c.Status().Update(t.Context(), job))
+
+		status, err = EnsureDatabaseRestore(cctx, Resources{Ironic: ironic}, backup)
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+
+		// All jobs of the failed upgrade are gone
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(t.Context(), jobs))
+		assert.Empty(t, jobs.Items)
+	})
+
+	t.Run("waits for running jobs", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{PersistentVolumeClaimName: "backups"})
+		cctx, c := setup(t, ironic, upgradeJob("test-pre-37.0-to-38.0"))
+
+		status, err := EnsureDatabaseRestore(cctx, Resources{Ironic: ironic}, backup)
+		require.NoError(t, err)
+		assert.Equal(t, "waiting for job test-pre-37.0-to-38.0 to finish before restoring the database", status.Message)
+
+		err = c.Get(t.Context(), client.ObjectKey{Namespace: "test", Name: "test-restore-38.0-to-37.0"}, &batchv1.Job{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("storage changed", func(t *testing.T) {
+		ironic := newIronic(&metal3api.DatabaseBackup{PersistentVolumeClaimName: "other"})
+		cctx, _ := setup(t, ironic)
+
+		status, err := EnsureDatabaseRestore(cctx, Resources{Ironic: ironic}, backup)
+		require.NoError(t, err)
+		require.Error(t, status.Fatal)
+		assert.Contains(t, status.Fatal.Error(), "is not in the configured storage")
+	})
+}
+
+func TestFindDatabaseBackup(t *testing.T) {
+	ironic := &metal3api.Ironic{
+		Status: metal3api.IronicStatus{
+			DatabaseBackups: []metal3api.DatabaseBackupStatus{
+				{Location: "1", FromVersion: "37.0", ToVersion: "38.0"},
+				{Location: "2", FromVersion: "36.0", ToVersion: "37.0"},
+				{Location: "3", FromVersion: "37.0", ToVersion: "38.0"},
+			},
+		},
+	}
+
+	backup := FindDatabaseBackup(ironic, "37.0", "38.0")
+	require.NotNil(t, backup)
+	assert.Equal(t, "3", backup.Location)
+	assert.Nil(t, FindDatabaseBackup(ironic, "38.0", "39.0"))
+}
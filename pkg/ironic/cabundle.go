@@ -0,0 +1,141 @@
+package ironic
+
+import (
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// CABundleKey is the key of the generated CA bundles.
+const CABundleKey = "ca-bundle.crt"
+
+// BMCCABundleName returns the name of the generated BMC CA bundle.
+func BMCCABundleName(ironic *metal3api.Ironic) string {
+	return ironic.Name + "-bmc-ca-bundle"
+}
+
+// TrustedCABundleName returns the name of the generated trusted CA bundle.
+func TrustedCABundleName(ironic *metal3api.Ironic) string {
+	return ironic.Name + "-trusted-ca-bundle"
+}
+
+// GetBMCCABundleSources returns all references that make up the BMC CA
+// bundle, or nil if no bundle is required.
+func GetBMCCABundleSources(tls *metal3api.TLS) []metal3api.ResourceReferenceWithKey {
+	if len(tls.AdditionalBMCCAs) == 0 {
+		return nil
+	}
+
+	var result []metal3api.ResourceReferenceWithKey
+	if ref := GetBMCCA(tls); ref != nil {
+		result = append(result, metal3api.ResourceReferenceWithKey{ResourceReference: *ref})
+	}
+	return append(result, tls.AdditionalBMCCAs...)
+}
+
+// GetTrustedCABundleSources returns all references that make up the trusted
+// CA bundle, or nil if no bundle is required.
+func GetTrustedCABundleSources(tls *metal3api.TLS) []metal3api.ResourceReferenceWithKey {
+	if len(tls.AdditionalTrustedCAs) == 0 {
+		return nil
+	}
+
+	var result []metal3api.ResourceReferenceWithKey
+	if tls.TrustedCA != nil {
+		result = append(result, *tls.TrustedCA)
+	} else if ref := GetTrustedCA(tls); ref != nil {
+		result = append(result, metal3api.ResourceReferenceWithKey{ResourceReference: *ref})
+	}
+	return append(result, tls.AdditionalTrustedCAs...)
+}
+
+// CABundleSource is a loaded resource with CA certificates to include into
+// a bundle. Exactly one of Secret and ConfigMap is set.
+type CABundleSource struct {
+	Secret    *corev1.Secret
+	ConfigMap *corev1.ConfigMap
+	// Key to use, all keys are used when empty.
+	Key string
+}
+
+func (source CABundleSource) data() (kind, name string, data map[string][]byte) {
+	if source.Secret != nil {
+		return metal3api.ResourceKindSecret, source.Secret.Name, source.Secret.Data
+	}
+
+	data = maps.Clone(source.ConfigMap.BinaryData)
+	if data == nil {
+		data = make(map[string][]byte, len(source.ConfigMap.Data))
+	}
+	for key, value := range source.ConfigMap.Data {
+		data[key] = []byte(value)
+	}
+	return metal3api.ResourceKindConfigMap, source.ConfigMap.Name, data
+}
+
+// pemCertificates returns only the certificates from PEM data, so that
+// private keys (e.g. tls.key of a TLS secret) never end up in a bundle.
+func pemCertificates(data []byte) []byte {
+	var result []byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return result
+		}
+		if block.Type == "CERTIFICATE" {
+			result = append(result, pem.EncodeToMemory(block)...)
+		}
+	}
+}
+
+// BuildCABundle concatenates the CA certificates from all sources. Anything
+// other than PEM-encoded certificates is ignored.
+func BuildCABundle(sources []CABundleSource) (string, error) {
+	var builder strings.Builder
+	for _, source := range sources {
+		kind, name, data := source.data()
+
+		keys := slices.Sorted(maps.Keys(data))
+		if source.Key != "" {
+			if _, ok := data[source.Key]; !ok {
+				return "", fmt.Errorf("%s %s does not contain the required key %s", kind, name, source.Key)
+			}
+			keys = []string{source.Key}
+		}
+
+		for _, key := range keys {
+			certs := pemCertificates(data[key])
+			if len(certs) == 0 && source.Key != "" {
+				return "", fmt.Errorf("key %s of %s %s does not contain any PEM certificates", key, kind, name)
+			}
+			builder.Write(certs)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// IsGeneratedCABundle checks if the ConfigMap has been generated by the operator.
+func IsGeneratedCABundle(configMap *corev1.ConfigMap) bool {
+	return configMap.Labels[metal3api.IronicCertificateLabel] == certificateTypeCABundle
+}
+
+// UpdateCABundle marks the ConfigMap as a CA bundle generated by the
+// operator and sets the bundle contents.
+func UpdateCABundle(configMap *corev1.ConfigMap, bundle string) {
+	if configMap.Labels == nil {
+		configMap.Labels = make(map[string]string, 2)
+	}
+	// Add the environment label so the ConfigMap is included in the filtered cache
+	configMap.Labels[metal3api.LabelEnvironmentName] = metal3api.LabelEnvironmentValue
+	configMap.Labels[metal3api.IronicCertificateLabel] = certificateTypeCABundle
+	configMap.Data = map[string]string{CABundleKey: bundle}
+	configMap.BinaryData = nil
+}
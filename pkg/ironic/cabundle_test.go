@@ -0,0 +1,97 @@
+package ironic
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestCABundleSources(t *testing.T) {
+	tls := &metal3api.TLS{
+		BMCCAName: "bmc-ca",
+		TrustedCA: &metal3api.ResourceReferenceWithKey{
+			ResourceReference: metal3api.ResourceReference{Name: "trusted", Kind: metal3api.ResourceKindConfigMap},
+			Key:               "ca.crt",
+		},
+	}
+	assert.Nil(t, GetBMCCABundleSources(tls))
+	assert.Nil(t, GetTrustedCABundleSources(tls))
+
+	extra := metal3api.ResourceReferenceWithKey{
+		ResourceReference: metal3api.ResourceReference{Name: "extra", Kind: metal3api.ResourceKindSecret},
+	}
+	tls.AdditionalBMCCAs = []metal3api.ResourceReferenceWithKey{extra}
+	tls.AdditionalTrustedCAs = []metal3api.ResourceReferenceWithKey{extra}
+	assert.Equal(t, []metal3api.ResourceReferenceWithKey{
+		{ResourceReference: metal3api.ResourceReference{Name: "bmc-ca", Kind: metal3api.ResourceKindSecret}},
+		extra,
+	}, GetBMCCABundleSources(tls))
+	assert.Equal(t, []metal3api.ResourceReferenceWithKey{*tls.TrustedCA, extra}, GetTrustedCABundleSources(tls))
+}
+
+func TestBuildCABundle(t *testing.T) {
+	newCert := func(name string) []byte {
+		certPEM, _, err := generateCertificate(&x509.Certificate{
+			Subject:  pkix.Name{CommonName: name},
+			NotAfter: time.Now().Add(time.Hour),
+		}, nil, nil)
+		require.NoError(t, err)
+		return certPEM
+	}
+	certA, certB, certC, certD := newCert("a"), newCert("b"), newCert("c"), newCert("d")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vendor"},
+		Data: map[string][]byte{
+			"b.crt": certB,
+			"a.crt": certA,
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirror"},
+		Data: map[string]string{
+			"ca.crt":    "# comment\n" + string(certC),
+			"other.crt": string(certD),
+		},
+	}
+
+	bundle, err := BuildCABundle([]CABundleSource{
+		{Secret: secret},
+		{ConfigMap: configMap, Key: "ca.crt"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(certA)+string(certB)+string(certC), bundle)
+
+	_, err = BuildCABundle([]CABundleSource{{ConfigMap: configMap, Key: "missing"}})
+	require.EqualError(t, err, "ConfigMap mirror does not contain the required key missing")
+
+	// Private keys of TLS secrets are never included
+	certPEM, keyPEM, err := generateCertificate(&x509.Certificate{
+		Subject:  pkix.Name{CommonName: "tls"},
+		NotAfter: time.Now().Add(time.Hour),
+	}, nil, nil)
+	require.NoError(t, err)
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	bundle, err = BuildCABundle([]CABundleSource{{Secret: tlsSecret}})
+	require.NoError(t, err)
+	assert.Equal(t, string(certPEM), bundle)
+	assert.NotContains(t, bundle, "PRIVATE KEY")
+
+	_, err = BuildCABundle([]CABundleSource{{Secret: tlsSecret, Key: corev1.TLSPrivateKeyKey}})
+	require.EqualError(t, err, "key tls.key of Secret tls does not contain any PEM certificates")
+}
@@ -0,0 +1,447 @@
+package ironic
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	certificateTypeCA       = "ca"
+	certificateTypeServing  = "serving"
+	certificateTypeCABundle = "ca-bundle"
+
+	caCertificateValidity         = 10 * 365 * 24 * time.Hour
+	defaultCertificateDuration    = 90 * 24 * time.Hour
+	defaultCertificateRenewBefore = 30 * 24 * time.Hour
+
+	// Tolerate small clock differences between the operator and the clients.
+	certificateBackdate = 5 * time.Minute
+)
+
+// SelfSignedCASecretName returns the name of the secret with the generated CA.
+func SelfSignedCASecretName(ironic *metal3api.Ironic) string {
+	return ironic.Name + "-ca"
+}
+
+// GeneratedCertificateName returns the name of the secret with the serving
+// certificate generated by the operator or by cert-manager.
+func GeneratedCertificateName(ironic *metal3api.Ironic) string {
+	return ironic.Name + "-tls"
+}
+
+// ServingCertificateName returns the name of the secret with the serving
+// certificate used by Ironic: the one from the spec or, if it is not set,
+// the one generated by the operator or by cert-manager. Returns an empty string if TLS is not
+// enabled. The spec is never updated with the generated name.
+func ServingCertificateName(ironic *metal3api.Ironic) string {
+	if ironic.Spec.TLS.CertificateName != "" {
+		return ironic.Spec.TLS.CertificateName
+	}
+	if ironic.Spec.TLS.SelfSigned != nil || ironic.Spec.TLS.CertificateIssuer != nil {
+		return GeneratedCertificateName(ironic)
+	}
+	return ""
+}
+
+// IsGeneratedCertificate returns true if the secret has been created by
+// the operator and can be safely updated.
+func IsGeneratedCertificate(secret *corev1.Secret) bool {
+	_, ok := secret.Labels[metal3api.IronicCertificateLabel]
+	return ok
+}
+
+// SetCertificateLabels marks the secret as a certificate generated by the operator.
+func SetCertificateLabels(secret *corev1.Secret, isCA bool) {
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string, 2)
+	}
+	// Add the environment label so the secret is included in the filtered cache
+	secret.Labels[metal3api.LabelEnvironmentName] = metal3api.LabelEnvironmentValue
+	secret.Labels[metal3api.IronicCertificateLabel] = certificateTypeServing
+	if isCA {
+		secret.Labels[metal3api.IronicCertificateLabel] = certificateTypeCA
+	}
+	secret.Type = corev1.SecretTypeTLS
+}
+
+// certificateSANs returns the DNS names and the IP addresses that the serving
+// certificate must be valid for.
+func certificateSANs(ironic *metal3api.Ironic, domain string) (dnsNames []string, ips []net.IP) {
+	dnsNames = []string{
+		ironic.Name,
+		fmt.Sprintf("%s.%s", ironic.Name, ironic.Namespace),
+		fmt.Sprintf("%s.%s.svc", ironic.Name, ironic.Namespace),
+	}
+	if serviceHost := ironicServiceHost(ironic, domain); !slices.Contains(dnsNames, serviceHost) {
+		dnsNames = append(dnsNames, serviceHost)
+	}
+
+	networking := &ironic.Spec.Networking
+	if networking.Ingress != nil && networking.Ingress.Host != "" {
+		dnsNames = append(dnsNames, networking.Ingress.Host)
+	}
+
+	addresses := []string{networking.IPAddress, networking.ExternalIP}
+	if networking.Keepalived != nil {
+		for _, vip := range networking.Keepalived.AdditionalVIPs {
+			addresses = append(addresses, vip.IPAddress)
+		}
+	}
+	for _, address := range addresses {
+		if ip := net.ParseIP(address); ip != nil && !slices.ContainsFunc(ips, ip.Equal) {
+			ips = append(ips, ip)
+		}
+	}
+
+	return dnsNames, ips
+}
+
+func generateCertificate(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot generate a private key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot generate a serial number: %w", err)
+	}
+	template.SerialNumber = serial
+
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create a certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot encode a private key: %w", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseCertificateAndKey(secret *corev1.Secret) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+	if block == nil {
+		return nil, nil, errors.New("no PEM encoded private key found")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
+// caNeedsUpdating returns a non-empty reason if the CA has to be generated.
+func caNeedsUpdating(secret *corev1.Secret, ironic *metal3api.Ironic, now time.Time) string {
+	if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return "no CA found"
+	}
+
+	cert, _, err := parseCertificateAndKey(secret)
+	if err != nil {
+		return fmt.Sprintf("invalid CA: %v", err)
+	}
+	if !cert.IsCA {
+		return "the certificate is not a CA"
+	}
+
+	if !now.Before(cert.NotAfter.Add(-certificateRenewBefore(ironic.Spec.TLS.SelfSigned))) {
+		return "the CA is about to expire"
+	}
+
+	return ""
+}
+
+// UpdateCA generates a new CA in the secret if it is missing, invalid or
+// about to expire. Returns true and the reason if the secret has been
+// modified. All certificates signed by the previous CA become untrusted.
+func UpdateCA(secret *corev1.Secret, ironic *metal3api.Ironic, now time.Time) (bool, string, error) {
+	reason := caNeedsUpdating(secret, ironic, now)
+	if reason == "" {
+		return false, "", nil
+	}
+
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s-%s-ca", ironic.Namespace, ironic.Name),
+			Organization: []string{"Metal3"},
+		},
+		NotBefore:             now.Add(-certificateBackdate),
+		NotAfter:              now.Add(caCertificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certPEM, keyPEM, err := generateCertificate(template, nil, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, 2)
+	}
+	secret.Data[corev1.TLSCertKey] = certPEM
+	secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	return true, reason, nil
+}
+
+func certificateDuration(selfSigned *metal3api.SelfSignedCertificate) time.Duration {
+	if selfSigned == nil || selfSigned.Duration == nil {
+		return defaultCertificateDuration
+	}
+	return selfSigned.Duration.Duration
+}
+
+func certificateRenewBefore(selfSigned *metal3api.SelfSignedCertificate) time.Duration {
+	if selfSigned == nil || selfSigned.RenewBefore == nil {
+		return defaultCertificateRenewBefore
+	}
+	return selfSigned.RenewBefore.Duration
+}
+
+// servingCertificateNeedsUpdating returns a non-empty reason if the serving
+// certificate has to be (re-)issued.
+func servingCertificateNeedsUpdating(secret *corev1.Secret, caCert *x509.Certificate, ironic *metal3api.Ironic, domain string, now time.Time) string {
+	cert, _, err := parseCertificateAndKey(secret)
+	if err != nil {
+		return fmt.Sprintf("invalid certificate: %v", err)
+	}
+
+	if err = cert.CheckSignatureFrom(caCert); err != nil || !bytes.Equal(secret.Data["ca.crt"], pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})) {
+		return "the CA has changed"
+	}
+
+	if !now.Before(cert.NotAfter.Add(-certificateRenewBefore(ironic.Spec.TLS.SelfSigned))) {
+		return "the certificate is about to expire"
+	}
+
+	dnsNames, ips := certificateSANs(ironic, domain)
+	if !slices.Equal(cert.DNSNames, dnsNames) || !slices.EqualFunc(cert.IPAddresses, ips, net.IP.Equal) {
+		return "the host names or IP addresses have changed"
+	}
+
+	return ""
+}
+
+// UpdateServingCertificate issues a new serving certificate signed by the CA
+// if the existing one is missing, invalid, about to expire or does not match
+// the current networking configuration. Returns true and the reason if the
+// secret has been modified.
+func UpdateServingCertificate(secret, caSecret *corev1.Secret, ironic *metal3api.Ironic, domain string, now time.Time) (bool, string, error) {
+	caCert, caKey, err := parseCertificateAndKey(caSecret)
+	if err != nil {
+		return false, "", fmt.Errorf("invalid CA in secret %s/%s: %w", caSecret.Namespace, caSecret.Name, err)
+	}
+
+	reason := servingCertificateNeedsUpdating(secret, caCert, ironic, domain, now)
+	if reason == "" {
+		return false, "", nil
+	}
+
+	dnsNames, ips := certificateSANs(ironic, domain)
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   ironic.Name,
+			Organization: []string{"Metal3"},
+		},
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+		NotBefore:   now.Add(-certificateBackdate),
+		NotAfter:    now.Add(certificateDuration(ironic.Spec.TLS.SelfSigned)),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		// Client authentication is required for the JSON RPC between replicas.
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	// The CA must never expire before the certificates it signs
+	if caCert.NotAfter.Before(template.NotAfter) {
+		template.NotAfter = caCert.NotAfter
+	}
+
+	certPEM, keyPEM, err := generateCertificate(template, caCert, caKey)
+	if err != nil {
+		return false, "", err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, 3)
+	}
+	secret.Data[corev1.TLSCertKey] = certPEM
+	secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	secret.Data["ca.crt"] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})
+	return true, reason, nil
+}
+
+var defaultCertificateExpirationThresholds = []time.Duration{
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+}
+
+// earliestExpiration returns the earliest expiration time of all PEM encoded
+// certificates in the provided values.
+func earliestExpiration(values ...[]byte) (notAfter time.Time, found bool) {
+	for _, rest := range values {
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			if !found || cert.NotAfter.Before(notAfter) {
+				notAfter = cert.NotAfter
+				found = true
+			}
+		}
+	}
+	return notAfter, found
+}
+
+func secretValues(secret *corev1.Secret) [][]byte {
+	return slices.Collect(maps.Values(secret.Data))
+}
+
+func configMapValues(configMap *corev1.ConfigMap) [][]byte {
+	result := slices.Collect(maps.Values(configMap.BinaryData))
+	for _, value := range configMap.Data {
+		result = append(result, []byte(value))
+	}
+	return result
+}
+
+// GetCertificateStatuses returns the expiration time of the certificates
+// in the resources used by Ironic.
+func GetCertificateStatuses(resources Resources) (result []metal3api.CertificateStatus) {
+	add := func(certType metal3api.CertificateType, kind, name string, values [][]byte) {
+		if notAfter, found := earliestExpiration(values...); found {
+			result = append(result, metal3api.CertificateStatus{
+				Type:     certType,
+				Kind:     kind,
+				Name:     name,
+				NotAfter: metav1.NewTime(notAfter),
+			})
+		}
+	}
+
+	if resources.TLSSecret != nil {
+		add(metal3api.CertificateTypeServing, metal3api.ResourceKindSecret, resources.TLSSecret.Name, secretValues(resources.TLSSecret))
+	}
+	if resources.BMCCASecret != nil {
+		add(metal3api.CertificateTypeBMCCA, metal3api.ResourceKindSecret, resources.BMCCASecret.Name, secretValues(resources.BMCCASecret))
+	} else if resources.BMCCAConfigMap != nil {
+		add(metal3api.CertificateTypeBMCCA, metal3api.ResourceKindConfigMap, resources.BMCCAConfigMap.Name, configMapValues(resources.BMCCAConfigMap))
+	}
+	if resources.TrustedCASecret != nil {
+		add(metal3api.CertificateTypeTrustedCA, metal3api.ResourceKindSecret, resources.TrustedCASecret.Name, secretValues(resources.TrustedCASecret))
+	} else if resources.TrustedCAConfigMap != nil {
+		add(metal3api.CertificateTypeTrustedCA, metal3api.ResourceKindConfigMap, resources.TrustedCAConfigMap.Name, configMapValues(resources.TrustedCAConfigMap))
+	}
+	if resources.ClientCASecret != nil {
+		add(metal3api.CertificateTypeClientCA, metal3api.ResourceKindSecret, resources.ClientCASecret.Name, secretValues(resources.ClientCASecret))
+	} else if resources.ClientCAConfigMap != nil {
+		add(metal3api.CertificateTypeClientCA, metal3api.ResourceKindConfigMap, resources.ClientCAConfigMap.Name, configMapValues(resources.ClientCAConfigMap))
+	}
+	if resources.DatabaseTLSSecret != nil {
+		add(metal3api.CertificateTypeDatabase, metal3api.ResourceKindSecret, resources.DatabaseTLSSecret.Name, secretValues(resources.DatabaseTLSSecret))
+	}
+	if resources.DatabaseClientSecret != nil {
+		add(metal3api.CertificateTypeDatabaseClient, metal3api.ResourceKindSecret, resources.DatabaseClientSecret.Name, secretValues(resources.DatabaseClientSecret))
+	}
+
+	return result
+}
+
+// CertificateExpirationThresholds returns the configured expiration warning
+// thresholds, the longest first.
+func CertificateExpirationThresholds(tls *metal3api.TLS) []time.Duration {
+	if len(tls.CertificateExpirationThresholds) == 0 {
+		return defaultCertificateExpirationThresholds
+	}
+
+	result := make([]time.Duration, 0, len(tls.CertificateExpirationThresholds))
+	for _, threshold := range tls.CertificateExpirationThresholds {
+		result = append(result, threshold.Duration)
+	}
+	slices.Sort(result)
+	slices.Reverse(result)
+	return result
+}
+
+// CrossedExpirationThreshold returns the smallest threshold that the
+// certificate expiration time has crossed, zero if it has already expired.
+func CrossedExpirationThreshold(notAfter, now time.Time, thresholds []time.Duration) (time.Duration, bool) {
+	remaining := notAfter.Sub(now)
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	var result time.Duration
+	var crossed bool
+	for _, threshold := range thresholds {
+		if remaining <= threshold && (!crossed || threshold < result) {
+			result = threshold
+			crossed = true
+		}
+	}
+	return result, crossed
+}
+
+// NextExpirationThreshold returns the time when the certificate expiration
+// crosses the next threshold, or the expiration time itself.
+func NextExpirationThreshold(notAfter, now time.Time, thresholds []time.Duration) (time.Time, bool) {
+	var result time.Time
+	var found bool
+	for _, threshold := range append([]time.Duration{0}, thresholds...) {
+		crossAt := notAfter.Add(-threshold)
+		if crossAt.After(now) && (!found || crossAt.Before(result)) {
+			result = crossAt
+			found = true
+		}
+	}
+	return result, found
+}
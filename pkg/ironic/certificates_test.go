@@ -0,0 +1,312 @@
+package ironic
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestCertificateSANs(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		Networking metal3api.Networking
+		Domain     string
+
+		ExpectedDNSNames []string
+		ExpectedIPs      []string
+	}{
+		{
+			Scenario: "service only",
+			ExpectedDNSNames: []string{
+				"test", "test.test-ns", "test.test-ns.svc",
+			},
+		},
+		{
+			Scenario: "cluster domain",
+			Domain:   ".cluster.local",
+			ExpectedDNSNames: []string{
+				"test", "test.test-ns", "test.test-ns.svc", "test.test-ns.svc.cluster.local",
+			},
+		},
+		{
+			Scenario: "cluster domain without leading dot",
+			Domain:   "cluster.local",
+			ExpectedDNSNames: []string{
+				"test", "test.test-ns", "test.test-ns.svc", "test.test-ns.svc.cluster.local",
+			},
+		},
+		{
+			Scenario: "all addresses",
+			Networking: metal3api.Networking{
+				IPAddress:  "192.0.2.1",
+				ExternalIP: "2001:db8::1",
+				Ingress:    &metal3api.Ingress{Host: "ironic.example.com"},
+				Keepalived: &metal3api.KeepalivedConfig{
+					Enabled: true,
+					AdditionalVIPs: []metal3api.KeepalivedIP{
+						{IPAddress: "192.0.2.2", Interface: "eth1"},
+						{IPAddress: "192.0.2.1", Interface: "eth2"},
+					},
+				},
+			},
+			ExpectedDNSNames: []string{
+				"test", "test.test-ns", "test.test-ns.svc", "ironic.example.com",
+			},
+			ExpectedIPs: []string{"192.0.2.1", "2001:db8::1", "192.0.2.2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+				Spec:       metal3api.IronicSpec{Networking: tc.Networking},
+			}
+			dnsNames, ips := certificateSANs(ironic, tc.Domain)
+			assert.Equal(t, tc.ExpectedDNSNames, dnsNames)
+			ipStrings := make([]string, 0, len(ips))
+			for _, ip := range ips {
+				ipStrings = append(ipStrings, ip.String())
+			}
+			assert.ElementsMatch(t, tc.ExpectedIPs, ipStrings)
+		})
+	}
+}
+
+func TestServingCertificateName(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		TLS      metal3api.TLS
+		Expected string
+	}{
+		{
+			Scenario: "no TLS",
+		},
+		{
+			Scenario: "provided certificate",
+			TLS:      metal3api.TLS{CertificateName: "my-cert"},
+			Expected: "my-cert",
+		},
+		{
+			Scenario: "self-signed",
+			TLS:      metal3api.TLS{SelfSigned: &metal3api.SelfSignedCertificate{}},
+			Expected: "test-tls",
+		},
+		{
+			Scenario: "cert-manager",
+			TLS:      metal3api.TLS{CertificateIssuer: &metal3api.IssuerReference{Name: "issuer"}},
+			Expected: "test-tls",
+		},
+		{
+			Scenario: "provided certificate takes precedence",
+			TLS:      metal3api.TLS{CertificateName: "my-cert", SelfSigned: &metal3api.SelfSignedCertificate{}},
+			Expected: "my-cert",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+				Spec:       metal3api.IronicSpec{TLS: tc.TLS},
+			}
+			assert.Equal(t, tc.Expected, ServingCertificateName(ironic))
+		})
+	}
+}
+
+func TestSelfSignedCertificate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+		Spec: metal3api.IronicSpec{
+			Networking: metal3api.Networking{IPAddress: "192.0.2.1"},
+			TLS: metal3api.TLS{
+				SelfSigned: &metal3api.SelfSignedCertificate{
+					Duration:    &metav1.Duration{Duration: 90 * 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				},
+			},
+		},
+	}
+
+	caSecret := &corev1.Secret{}
+	changed, reason, err := UpdateCA(caSecret, ironic, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "no CA found", reason)
+	changed, _, err = UpdateCA(caSecret, ironic, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	expiringCASecret := caSecret.DeepCopy()
+	changed, reason, err = UpdateCA(expiringCASecret, ironic, now.Add(caCertificateValidity-29*24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "the CA is about to expire", reason)
+	assert.NotEqual(t, caSecret.Data[corev1.TLSCertKey], expiringCASecret.Data[corev1.TLSCertKey])
+
+	secret := &corev1.Secret{}
+	changed, reason, err = UpdateServingCertificate(secret, caSecret, ironic, "", now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, reason, "invalid certificate")
+
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	caCert, err := parseCertificate(secret.Data["ca.crt"])
+	require.NoError(t, err)
+	require.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Contains(t, cert.DNSNames, "test.test-ns.svc")
+	assert.True(t, cert.IPAddresses[0].Equal(net.ParseIP("192.0.2.1")))
+	assert.Equal(t, now.Add(90*24*time.Hour), cert.NotAfter)
+
+	changed, _, err = UpdateServingCertificate(secret, caSecret, ironic, "", now.Add(59*24*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, reason, err = UpdateServingCertificate(secret, caSecret, ironic, "", now.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "the certificate is about to expire", reason)
+
+	ironic.Spec.Networking.ExternalIP = "192.0.2.42"
+	changed, reason, err = UpdateServingCertificate(secret, caSecret, ironic, "", now.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "the host names or IP addresses have changed", reason)
+
+	newCASecret := &corev1.Secret{}
+	_, _, err = UpdateCA(newCASecret, ironic, now)
+	require.NoError(t, err)
+	changed, reason, err = UpdateServingCertificate(secret, newCASecret, ironic, "", now.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "the CA has changed", reason)
+
+	_, _, err = UpdateServingCertificate(secret, &corev1.Secret{}, ironic, "", now)
+	assert.ErrorContains(t, err, "invalid CA")
+}
+
+func TestGetCertificateStatuses(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+		Spec: metal3api.IronicSpec{
+			TLS: metal3api.TLS{
+				SelfSigned: &metal3api.SelfSignedCertificate{
+					Duration:    &metav1.Duration{Duration: 90 * 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				},
+			},
+		},
+	}
+
+	caSecret := &corev1.Secret{}
+	_, _, err := UpdateCA(caSecret, ironic, now)
+	require.NoError(t, err)
+	tlsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls"}}
+	_, _, err = UpdateServingCertificate(tlsSecret, caSecret, ironic, "", now.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	resources := Resources{
+		Ironic:    ironic,
+		TLSSecret: tlsSecret,
+		BMCCAConfigMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca"},
+			Data: map[string]string{
+				"ca.crt": string(caSecret.Data[corev1.TLSCertKey]),
+				"notes":  "not a certificate",
+			},
+		},
+		TrustedCASecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty"},
+			Data:       map[string][]byte{"ca.crt": []byte("garbage")},
+		},
+	}
+
+	caCert, err := parseCertificate(caSecret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+
+	statuses := GetCertificateStatuses(resources)
+	assert.Equal(t, []metal3api.CertificateStatus{
+		{
+			Type:     metal3api.CertificateTypeServing,
+			Kind:     metal3api.ResourceKindSecret,
+			Name:     "test-tls",
+			NotAfter: metav1.NewTime(now.Add(91 * 24 * time.Hour)),
+		},
+		{
+			Type:     metal3api.CertificateTypeBMCCA,
+			Kind:     metal3api.ResourceKindConfigMap,
+			Name:     "bmc-ca",
+			NotAfter: metav1.NewTime(caCert.NotAfter),
+		},
+	}, statuses)
+}
+
+func TestExpirationThresholds(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	thresholds := CertificateExpirationThresholds(&metal3api.TLS{})
+
+	testCases := []struct {
+		Scenario string
+
+		NotAfter time.Time
+
+		ExpectedThreshold time.Duration
+		ExpectedCrossed   bool
+		ExpectedNext      time.Time
+	}{
+		{
+			Scenario:     "far away",
+			NotAfter:     now.Add(60 * day),
+			ExpectedNext: now.Add(30 * day),
+		},
+		{
+			Scenario:          "first threshold",
+			NotAfter:          now.Add(10 * day),
+			ExpectedThreshold: 30 * day,
+			ExpectedCrossed:   true,
+			ExpectedNext:      now.Add(3 * day),
+		},
+		{
+			Scenario:          "last threshold",
+			NotAfter:          now.Add(time.Hour),
+			ExpectedThreshold: day,
+			ExpectedCrossed:   true,
+			ExpectedNext:      now.Add(time.Hour),
+		},
+		{
+			Scenario:        "expired",
+			NotAfter:        now.Add(-time.Hour),
+			ExpectedCrossed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			threshold, crossed := CrossedExpirationThreshold(tc.NotAfter, now, thresholds)
+			assert.Equal(t, tc.ExpectedCrossed, crossed)
+			assert.Equal(t, tc.ExpectedThreshold, threshold)
+
+			next, found := NextExpirationThreshold(tc.NotAfter, now, thresholds)
+			assert.Equal(t, !tc.ExpectedNext.IsZero(), found)
+			assert.Equal(t, tc.ExpectedNext, next)
+		})
+	}
+
+	custom := CertificateExpirationThresholds(&metal3api.TLS{
+		CertificateExpirationThresholds: []metav1.Duration{{Duration: time.Hour}, {Duration: 14 * day}},
+	})
+	assert.Equal(t, []time.Duration{14 * day, time.Hour}, custom)
+}
@@ -0,0 +1,130 @@
+package ironic
+
+import (
+	"fmt"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// EnsureCertificate ensures that a cert-manager Certificate exists for the
+// Ironic serving certificate and reports whether it has been issued.
+func EnsureCertificate(cctx ControllerContext, ironic *metal3api.Ironic) (Status, error) {
+	issuer := ironic.Spec.TLS.CertificateIssuer
+
+	cert := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GeneratedCertificateName(ironic),
+			Namespace: ironic.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, cert, func() error {
+		if cert.Labels == nil {
+			cert.Labels = make(map[string]string, 1)
+		}
+		cert.Labels[metal3api.IronicServiceLabel] = ironic.Name
+
+		dnsNames, ips := certificateSANs(ironic, cctx.Domain)
+		cert.Spec.CommonName = ironic.Name
+		cert.Spec.DNSNames = dnsNames
+		cert.Spec.IPAddresses = make([]string, 0, len(ips))
+		for _, ip := range ips {
+			cert.Spec.IPAddresses = append(cert.Spec.IPAddresses, ip.String())
+		}
+		cert.Spec.SecretName = GeneratedCertificateName(ironic)
+		cert.Spec.SecretTemplate = &certmanagerv1.CertificateSecretTemplate{
+			Labels: map[string]string{
+				// Add the environment label so the secret is included in the filtered cache
+				metal3api.LabelEnvironmentName: metal3api.LabelEnvironmentValue,
+			},
+		}
+		cert.Spec.IssuerRef = cmmeta.ObjectReference{
+			Name:  issuer.Name,
+			Kind:  issuer.Kind,
+			Group: issuer.Group,
+		}
+		// Client authentication is required for the JSON RPC between replicas.
+		cert.Spec.Usages = []certmanagerv1.KeyUsage{
+			certmanagerv1.UsageDigitalSignature,
+			certmanagerv1.UsageKeyEncipherment,
+			certmanagerv1.UsageServerAuth,
+			certmanagerv1.UsageClientAuth,
+		}
+
+		return controllerutil.SetControllerReference(ironic, cert, cctx.Scheme)
+	})
+	if err != nil {
+		return transientError(err)
+	}
+	if result != controllerutil.OperationResultNone {
+		cctx.Logger.Info("Certificate", "Certificate", cert.Name, "Status", result)
+		return updated()
+	}
+
+	return getCertificateStatus(cert)
+}
+
+// RemoveCertificate deletes the cert-manager Certificate created for the
+// Ironic serving certificate together with the secret issued for it.
+// Does nothing if the Certificate is not owned by Ironic.
+func RemoveCertificate(cctx ControllerContext, ironic *metal3api.Ironic) error {
+	name := types.NamespacedName{Namespace: ironic.Namespace, Name: GeneratedCertificateName(ironic)}
+
+	cert := &certmanagerv1.Certificate{}
+	err := cctx.Client.Get(cctx.Context, name, cert)
+	if k8serrors.IsNotFound(err) || (err == nil && !metav1.IsControlledBy(cert, ironic)) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot check certificate %s: %w", name.Name, err)
+	}
+
+	cctx.Logger.Info("removing unused Certificate", "Certificate", cert.Name)
+	if err = cctx.Client.Delete(cctx.Context, cert); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot remove certificate %s: %w", cert.Name, err)
+	}
+
+	// cert-manager does not remove the issued secret by default. Keep it if
+	// it has been set as CertificateName.
+	if cert.Spec.SecretName == "" || cert.Spec.SecretName == ironic.Spec.TLS.CertificateName {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	err = cctx.Client.Get(cctx.Context, types.NamespacedName{Namespace: ironic.Namespace, Name: cert.Spec.SecretName}, secret)
+	if k8serrors.IsNotFound(err) || (err == nil && secret.Annotations[certmanagerv1.CertificateNameKey] != cert.Name) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot check secret %s: %w", cert.Spec.SecretName, err)
+	}
+	cctx.Logger.Info("removing the secret of the unused Certificate", "Secret", secret.Name)
+	if err = cctx.Client.Delete(cctx.Context, secret); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot remove secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
+
+func getCertificateStatus(cert *certmanagerv1.Certificate) (Status, error) {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type != certmanagerv1.CertificateConditionReady {
+			continue
+		}
+		if cond.ObservedGeneration != cert.Generation {
+			break
+		}
+		if cond.Status == cmmeta.ConditionTrue {
+			return ready()
+		}
+		return inProgress(fmt.Sprintf("certificate %s is not ready: %s", cert.Name, cond.Message))
+	}
+
+	return inProgress(fmt.Sprintf("certificate %s has not been processed yet", cert.Name))
+}
@@ -0,0 +1,184 @@
+package ironic
+
+import (
+	"testing"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestGetCertificateStatus(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		Conditions []certmanagerv1.CertificateCondition
+
+		ExpectedReady   bool
+		ExpectedMessage string
+	}{
+		{
+			Scenario:        "no conditions",
+			ExpectedMessage: "certificate test-tls has not been processed yet",
+		},
+		{
+			Scenario: "ready",
+			Conditions: []certmanagerv1.CertificateCondition{
+				{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2},
+			},
+			ExpectedReady: true,
+		},
+		{
+			Scenario: "not ready",
+			Conditions: []certmanagerv1.CertificateCondition{
+				{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionFalse, ObservedGeneration: 2, Message: "Issuing certificate"},
+			},
+			ExpectedMessage: "certificate test-tls is not ready: Issuing certificate",
+		},
+		{
+			Scenario: "outdated",
+			Conditions: []certmanagerv1.CertificateCondition{
+				{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 1},
+			},
+			ExpectedMessage: "certificate test-tls has not been processed yet",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			cert := &certmanagerv1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tls", Generation: 2},
+				Status:     certmanagerv1.CertificateStatus{Conditions: tc.Conditions},
+			}
+			status, err := getCertificateStatus(cert)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReady, status.IsReady())
+			if !tc.ExpectedReady {
+				assert.Equal(t, tc.ExpectedMessage, status.Message)
+			}
+		})
+	}
+}
+
+func TestEnsureCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, certmanagerv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironicObj := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns", UID: "abc-123"},
+		Spec: metal3api.IronicSpec{
+			Networking: metal3api.Networking{IPAddress: "192.0.2.1"},
+			TLS: metal3api.TLS{
+				CertificateIssuer: &metal3api.IssuerReference{Name: "ca-issuer", Kind: "ClusterIssuer"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard(), Domain: ".cluster.local"}
+
+	status, err := EnsureCertificate(cctx, ironicObj)
+	require.NoError(t, err)
+	assert.False(t, status.IsReady())
+
+	cert := &certmanagerv1.Certificate{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-tls"}, cert))
+	assert.Equal(t, "test-tls", cert.Spec.SecretName)
+	assert.Equal(t, metal3api.LabelEnvironmentValue, cert.Spec.SecretTemplate.Labels[metal3api.LabelEnvironmentName])
+	assert.Contains(t, cert.Spec.DNSNames, "test.test-ns.svc.cluster.local")
+	assert.Equal(t, []string{"192.0.2.1"}, cert.Spec.IPAddresses)
+	assert.Equal(t, cmmeta.ObjectReference{Name: "ca-issuer", Kind: "ClusterIssuer"}, cert.Spec.IssuerRef)
+	assert.True(t, metav1.IsControlledBy(cert, ironicObj))
+
+	cert.Status.Conditions = []certmanagerv1.CertificateCondition{
+		{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: cert.Generation},
+	}
+	require.NoError(t, c.Update(t.Context(), cert))
+
+	status, err = EnsureCertificate(cctx, ironicObj)
+	require.NoError(t, err)
+	assert.True(t, status.IsReady())
+}
+
+func TestRemoveCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, certmanagerv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironicObj := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns", UID: "abc-123"},
+		Spec: metal3api.IronicSpec{
+			TLS: metal3api.TLS{
+				CertificateIssuer: &metal3api.IssuerReference{Name: "ca-issuer"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-tls",
+			Namespace:   "test-ns",
+			Annotations: map[string]string{certmanagerv1.CertificateNameKey: "test-tls"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, secret).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	// Nothing to remove
+	require.NoError(t, RemoveCertificate(cctx, ironicObj))
+
+	_, err := EnsureCertificate(cctx, ironicObj)
+	require.NoError(t, err)
+
+	ironicObj.Spec.TLS.CertificateIssuer = nil
+	require.NoError(t, RemoveCertificate(cctx, ironicObj))
+
+	err = c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-tls"}, &certmanagerv1.Certificate{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-tls"}, &corev1.Secret{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestRemoveCertificate_KeepsUsedSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, certmanagerv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironicObj := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns", UID: "abc-123"},
+	}
+	cert := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tls", Namespace: "test-ns"},
+		Spec:       certmanagerv1.CertificateSpec{SecretName: "test-tls"},
+	}
+	require.NoError(t, controllerutil.SetControllerReference(ironicObj, cert, scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-tls",
+			Namespace:   "test-ns",
+			Annotations: map[string]string{certmanagerv1.CertificateNameKey: "test-tls"},
+		},
+	}
+	// The user has taken over the issued secret
+	ironicObj.Spec.TLS.CertificateName = "test-tls"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, cert, secret).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	require.NoError(t, RemoveCertificate(cctx, ironicObj))
+
+	err := c.Get(t.Context(), client.ObjectKeyFromObject(cert), &certmanagerv1.Certificate{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(secret), &corev1.Secret{}))
+}
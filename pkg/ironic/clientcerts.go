@@ -0,0 +1,111 @@
+package ironic
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// agentPaths are the API endpoints used by the ramdisk agent, which cannot
+// present a client certificate.
+var agentPaths = []string{"/v1/lookup", "/v1/heartbeat"}
+
+// A MAC address that does not belong to any node, used to probe the lookup
+// endpoint.
+const probeLookupAddress = "00:00:5e:00:53:00"
+
+// newIronicHTTPClient returns an HTTP client without a client certificate
+// and the base URL of the Ironic API. A variable so that unit tests can
+// replace it.
+var newIronicHTTPClient = func(cctx ControllerContext, resources Resources) (*http.Client, string) {
+	httpClient := &http.Client{Timeout: ironicAPITimeout}
+	if resources.TLSSecret != nil {
+		httpClient.Transport = ironicAPITransport(cctx, resources)
+	}
+	return httpClient, buildStatusEndpoints(cctx, resources).APIURLs[0]
+}
+
+// clientCertificatesVerified returns true if the last verification has shown
+// that the running Ironic API requires client certificates.
+func clientCertificatesVerified(ironic *metal3api.Ironic) bool {
+	cond := meta.FindStatusCondition(ironic.Status.Conditions, string(metal3api.IronicStatusClientCertificatesVerified))
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == metal3api.IronicReasonAvailable
+}
+
+// isRemoteTLSError returns true if the server has aborted the TLS handshake,
+// which httpd does when a required client certificate is missing.
+func isRemoteTLSError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "remote error"
+}
+
+// probeClientCertificate sends a request without a client certificate and
+// returns true if it has been rejected because of the missing certificate.
+func probeClientCertificate(cctx ControllerContext, httpClient *http.Client, url string, apiSecret *corev1.Secret) (rejected bool, err error) {
+	req, err := http.NewRequestWithContext(cctx.Context, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	if apiSecret != nil {
+		req.SetBasicAuth(string(apiSecret.Data[corev1.BasicAuthUsernameKey]), string(apiSecret.Data[corev1.BasicAuthPasswordKey]))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if isRemoteTLSError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusForbidden, nil
+}
+
+// verifyClientCertificates checks that the running Ironic API rejects
+// requests without a client certificate even with valid API credentials,
+// and that the agent endpoints can still be used without one. HTTP basic
+// authentication is only disabled once this check has passed.
+func verifyClientCertificates(cctx ControllerContext, resources Resources) (Status, error) {
+	if clientCAPath(resources) == "" {
+		return notRequired("client certificates are not used")
+	}
+
+	httpClient, apiURL := newIronicHTTPClient(cctx, resources)
+
+	rejected, err := probeClientCertificate(cctx, httpClient, apiURL+"/v1/nodes", resources.APISecret)
+	if err != nil {
+		return transientError(fmt.Errorf("cannot verify that the Ironic API requires client certificates: %w", err))
+	}
+	if !rejected {
+		// Requeue so that HTTP basic authentication is restored right away.
+		return Status{
+			Message: "the Ironic API accepts requests without a client certificate, the Ironic image may not support IRONIC_CLIENT_CA_FILE; HTTP basic authentication is kept",
+			Reason:  metal3api.IronicReasonFailed,
+			requeue: true,
+		}, nil
+	}
+
+	rejected, err = probeClientCertificate(cctx, httpClient, apiURL+agentPaths[0]+"?addresses="+probeLookupAddress, nil)
+	if err != nil {
+		return transientError(fmt.Errorf("cannot verify that the agent endpoints do not require client certificates: %w", err))
+	}
+	if rejected {
+		return Status{
+			Message: fmt.Sprintf("the agent endpoints (%s) require a client certificate, the Ironic image may not support IRONIC_CLIENT_CA_EXEMPT_PATHS and the ramdisk will not be able to reach Ironic; HTTP basic authentication is kept",
+				strings.Join(agentPaths, ", ")),
+			Reason:  metal3api.IronicReasonFailed,
+			requeue: true,
+		}, nil
+	}
+
+	return ready()
+}
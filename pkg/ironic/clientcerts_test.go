@@ -0,0 +1,147 @@
+package ironic
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestVerifyClientCertificates(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		NoClientCA    bool
+		RequireTLS    bool
+		NodesStatus   int
+		LookupStatus  int
+		ExpectedReady bool
+		ExpectedError string
+		ExpectedMsg   string
+	}{
+		{
+			Scenario:      "no client CA",
+			NoClientCA:    true,
+			ExpectedReady: true,
+		},
+		{
+			Scenario:      "certificate required with agent endpoints exempt",
+			NodesStatus:   http.StatusForbidden,
+			LookupStatus:  http.StatusNotFound,
+			ExpectedReady: true,
+		},
+		{
+			Scenario:     "certificate not enforced",
+			NodesStatus:  http.StatusOK,
+			LookupStatus: http.StatusNotFound,
+			ExpectedMsg:  "the Ironic API accepts requests without a client certificate",
+		},
+		{
+			Scenario:     "agent endpoints not exempt",
+			NodesStatus:  http.StatusForbidden,
+			LookupStatus: http.StatusForbidden,
+			ExpectedMsg:  "the agent endpoints (/v1/lookup, /v1/heartbeat) require a client certificate",
+		},
+		{
+			Scenario:    "certificate required in the handshake",
+			RequireTLS:  true,
+			ExpectedMsg: "the agent endpoints (/v1/lookup, /v1/heartbeat) require a client certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/nodes":
+					user, password, ok := r.BasicAuth()
+					assert.True(t, ok)
+					assert.Equal(t, "admin", user)
+					assert.Equal(t, "secret", password)
+					w.WriteHeader(tc.NodesStatus)
+				case "/v1/lookup":
+					_, _, ok := r.BasicAuth()
+					assert.False(t, ok)
+					assert.Equal(t, probeLookupAddress, r.URL.Query().Get("addresses"))
+					w.WriteHeader(tc.LookupStatus)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			if tc.RequireTLS {
+				server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+			}
+			server.StartTLS()
+			t.Cleanup(server.Close)
+
+			oldNewIronicHTTPClient := newIronicHTTPClient
+			t.Cleanup(func() { newIronicHTTPClient = oldNewIronicHTTPClient })
+			newIronicHTTPClient = func(ControllerContext, Resources) (*http.Client, string) {
+				return server.Client(), server.URL
+			}
+
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: metal3api.IronicSpec{
+					TLS: metal3api.TLS{
+						CertificateName: "tls",
+						ClientCA: &metal3api.ResourceReferenceWithKey{
+							ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+							Key:               "ca.crt",
+						},
+					},
+				},
+			}
+			resources := Resources{
+				Ironic: ironic,
+				APISecret: &corev1.Secret{Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("admin"),
+					corev1.BasicAuthPasswordKey: []byte("secret"),
+				}},
+				TLSSecret:         &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls"}},
+				ClientCAConfigMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "client-ca"}},
+			}
+			if tc.NoClientCA {
+				ironic.Spec.TLS.ClientCA = nil
+				resources.ClientCAConfigMap = nil
+			}
+
+			cctx := ControllerContext{Context: t.Context(), Logger: logr.Discard()}
+			status, err := verifyClientCertificates(cctx, resources)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReady, status.IsReady())
+			if tc.ExpectedMsg != "" {
+				assert.Contains(t, status.Message, tc.ExpectedMsg)
+				assert.Equal(t, metal3api.IronicReasonFailed, status.Reason)
+				assert.True(t, status.NeedsRequeue())
+			}
+		})
+	}
+}
+
+func TestClientCertificatesVerified(t *testing.T) {
+	ironic := &metal3api.Ironic{}
+	assert.False(t, clientCertificatesVerified(ironic))
+
+	ironic.Status.Conditions = []metav1.Condition{{
+		Type:   string(metal3api.IronicStatusClientCertificatesVerified),
+		Status: metav1.ConditionTrue,
+		Reason: metal3api.IronicReasonNotRequired,
+	}}
+	assert.False(t, clientCertificatesVerified(ironic))
+
+	ironic.Status.Conditions[0].Reason = metal3api.IronicReasonAvailable
+	assert.True(t, clientCertificatesVerified(ironic))
+
+	ironic.Status.Conditions[0].Status = metav1.ConditionFalse
+	ironic.Status.Conditions[0].Reason = metal3api.IronicReasonFailed
+	assert.False(t, clientCertificatesVerified(ironic))
+}
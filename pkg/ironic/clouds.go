@@ -0,0 +1,205 @@
+package ironic
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	cloudsYAMLKey     = "clouds.yaml"
+	cloudsYAMLCAKey   = "ca.crt"
+	defaultCloudName  = "ironic"
+	defaultCACertPath = "/etc/openstack/ca.crt"
+)
+
+var (
+	cloudsYAMLLabel = metal3api.IronicLabelPrefix + "/clouds-yaml"
+
+	errCloudsYAMLSecretNotManaged = errors.New("secret already exists and is not managed by the operator")
+)
+
+type cloudAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type cloudConfig struct {
+	AuthType                  string    `json:"auth_type"`
+	Auth                      cloudAuth `json:"auth"`
+	BaremetalEndpointOverride string    `json:"baremetal_endpoint_override"`
+	CACert                    string    `json:"cacert,omitempty"`
+}
+
+type cloudsFile struct {
+	Clouds map[string]cloudConfig `json:"clouds"`
+}
+
+// CloudsYAMLSecretName returns the name of the Secret with clouds.yaml.
+func CloudsYAMLSecretName(ironic *metal3api.Ironic) string {
+	if ironic.Spec.CloudsYAML != nil && ironic.Spec.CloudsYAML.SecretName != "" {
+		return ironic.Spec.CloudsYAML.SecretName
+	}
+	return ironic.Name + "-clouds-yaml"
+}
+
+// cloudsYAMLEndpoint picks the API endpoint to put into clouds.yaml.
+func cloudsYAMLEndpoint(ironic *metal3api.Ironic, endpoints *metal3api.IronicEndpoints) string {
+	if ironic.Spec.CloudsYAML.ExternalEndpoint {
+		networking := &ironic.Spec.Networking
+		if externalCallbackURL, _ := externalURLs(networking); externalCallbackURL != "" {
+			return externalCallbackURL
+		}
+		if networking.ExternalIP != "" {
+			proto := protoHTTP
+			if ServingCertificateName(ironic) != "" {
+				proto = protoHTTPS
+			}
+			return buildEndpoints([]string{networking.ExternalIP}, int(networking.APIPort), proto)[0]
+		}
+	}
+
+	return endpoints.APIURLs[0]
+}
+
+func buildCloudsYAML(resources Resources, endpoints *metal3api.IronicEndpoints) (map[string][]byte, error) {
+	settings := resources.Ironic.Spec.CloudsYAML
+
+	username, ok := resources.APISecret.Data[corev1.BasicAuthUsernameKey]
+	if !ok {
+		return nil, fmt.Errorf("missing username in secret %s/%s", resources.APISecret.Namespace, resources.APISecret.Name)
+	}
+	password, ok := resources.APISecret.Data[corev1.BasicAuthPasswordKey]
+	if !ok {
+		return nil, fmt.Errorf("missing password in secret %s/%s", resources.APISecret.Namespace, resources.APISecret.Name)
+	}
+
+	cloud := cloudConfig{
+		AuthType: "http_basic",
+		Auth: cloudAuth{
+			Username: normalizeSecretValue(username),
+			Password: normalizeSecretValue(password),
+		},
+		BaremetalEndpointOverride: cloudsYAMLEndpoint(resources.Ironic, endpoints),
+	}
+
+	result := make(map[string][]byte, 2)
+	if endpoints.TLS && resources.TLSSecret != nil {
+		if caCert, ok := resources.TLSSecret.Data[cloudsYAMLCAKey]; ok {
+			cloud.CACert = settings.CACertPath
+			if cloud.CACert == "" {
+				cloud.CACert = defaultCACertPath
+			}
+			result[cloudsYAMLCAKey] = caCert
+		}
+	}
+
+	cloudName := settings.CloudName
+	if cloudName == "" {
+		cloudName = defaultCloudName
+	}
+
+	data, err := yaml.Marshal(cloudsFile{Clouds: map[string]cloudConfig{cloudName: cloud}})
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialize clouds.yaml: %w", err)
+	}
+	result[cloudsYAMLKey] = data
+
+	return result, nil
+}
+
+// removeStaleCloudsYAMLSecrets removes generated Secrets that are no longer
+// required, e.g. because the generation was disabled or the name changed.
+func removeStaleCloudsYAMLSecrets(cctx ControllerContext, ironic *metal3api.Ironic, keep string) error {
+	secrets := &corev1.SecretList{}
+	err := cctx.Client.List(cctx.Context, secrets,
+		client.InNamespace(ironic.Namespace),
+		client.MatchingLabels{
+			metal3api.IronicServiceLabel: ironic.Name,
+			cloudsYAMLLabel:              "true",
+		})
+	if err != nil {
+		return fmt.Errorf("cannot list clouds.yaml secrets: %w", err)
+	}
+
+	var errs []error
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Name == keep || !metav1.IsControlledBy(secret, ironic) {
+			continue
+		}
+
+		cctx.Logger.Info("removing a stale clouds.yaml secret", "Secret", secret.Name)
+		err = cctx.Client.Delete(cctx.Context, secret)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensureCloudsYAMLSecret creates or updates the Secret with clouds.yaml.
+// The Secret is updated when the API credentials or the endpoints change.
+func ensureCloudsYAMLSecret(cctx ControllerContext, resources Resources, endpoints *metal3api.IronicEndpoints) (Status, error) {
+	ironic := resources.Ironic
+	if ironic.Spec.CloudsYAML == nil {
+		if err := removeStaleCloudsYAMLSecrets(cctx, ironic, ""); err != nil {
+			return transientError(err)
+		}
+		return ready()
+	}
+
+	data, err := buildCloudsYAML(resources, endpoints)
+	if err != nil {
+		return Status{Fatal: err}, nil
+	}
+
+	secretName := CloudsYAMLSecretName(ironic)
+	if err = removeStaleCloudsYAMLSecrets(cctx, ironic, secretName); err != nil {
+		return transientError(err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: ironic.Namespace},
+	}
+	result, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, secret, func() error {
+		if secret.ResourceVersion != "" && secret.Labels[cloudsYAMLLabel] != "true" {
+			// Never overwrite user-provided secrets
+			return errCloudsYAMLSecretNotManaged
+		}
+		if secret.Labels == nil {
+			cctx.Logger.Info("creating a clouds.yaml secret", "Secret", secretName)
+			secret.Labels = make(map[string]string, 4)
+		}
+		// Add the environment label so the secret is included in the filtered cache
+		secret.Labels[metal3api.LabelEnvironmentName] = metal3api.LabelEnvironmentValue
+		secret.Labels[managedSecretLabel] = managedSecretLabelValue
+		secret.Labels[metal3api.IronicServiceLabel] = ironic.Name
+		secret.Labels[cloudsYAMLLabel] = "true"
+
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = data
+
+		return controllerutil.SetControllerReference(ironic, secret, cctx.Scheme)
+	})
+	if errors.Is(err, errCloudsYAMLSecretNotManaged) {
+		return Status{Fatal: fmt.Errorf("cannot use %s for clouds.yaml: %w", secretName, err)}, nil
+	}
+	if err != nil {
+		return transientError(err)
+	}
+	if result != controllerutil.OperationResultNone {
+		cctx.Logger.Info("clouds.yaml secret", "Secret", secretName, "Status", result)
+	}
+
+	return ready()
+}
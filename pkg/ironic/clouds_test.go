@@ -0,0 +1,215 @@
+package ironic
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestBuildCloudsYAML(t *testing.T) {
+	apiSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("admin\n"),
+			corev1.BasicAuthPasswordKey: []byte("secret"),
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		CloudsYAML metal3api.CloudsYAML
+		Networking metal3api.Networking
+		TLSSecret  *corev1.Secret
+
+		ExpectedYAML string
+		ExpectedCA   string
+	}{
+		{
+			Scenario: "defaults",
+			ExpectedYAML: `clouds:
+  ironic:
+    auth:
+      password: secret
+      username: admin
+    auth_type: http_basic
+    baremetal_endpoint_override: http://test.test.svc
+`,
+		},
+		{
+			Scenario:   "TLS with CA",
+			CloudsYAML: metal3api.CloudsYAML{CloudName: "metal3", CACertPath: "/certs/ca.crt"},
+			TLSSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+				Data:       map[string][]byte{"ca.crt": []byte("my CA")},
+			},
+			ExpectedYAML: `clouds:
+  metal3:
+    auth:
+      password: secret
+      username: admin
+    auth_type: http_basic
+    baremetal_endpoint_override: https://test.test.svc
+    cacert: /certs/ca.crt
+`,
+			ExpectedCA: "my CA",
+		},
+		{
+			Scenario:   "external endpoint with ingress",
+			CloudsYAML: metal3api.CloudsYAML{ExternalEndpoint: true},
+			Networking: metal3api.Networking{Ingress: &metal3api.Ingress{Host: "ironic.example.com"}},
+			ExpectedYAML: `clouds:
+  ironic:
+    auth:
+      password: secret
+      username: admin
+    auth_type: http_basic
+    baremetal_endpoint_override: https://ironic.example.com
+`,
+		},
+		{
+			Scenario:   "external endpoint with external IP",
+			CloudsYAML: metal3api.CloudsYAML{ExternalEndpoint: true},
+			Networking: metal3api.Networking{APIPort: 6385, ExternalIP: "192.0.2.1"},
+			ExpectedYAML: `clouds:
+  ironic:
+    auth:
+      password: secret
+      username: admin
+    auth_type: http_basic
+    baremetal_endpoint_override: http://192.0.2.1:6385
+`,
+		},
+		{
+			Scenario:   "external endpoint not configured",
+			CloudsYAML: metal3api.CloudsYAML{ExternalEndpoint: true},
+			ExpectedYAML: `clouds:
+  ironic:
+    auth:
+      password: secret
+      username: admin
+    auth_type: http_basic
+    baremetal_endpoint_override: http://test.test.svc
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: metal3api.IronicSpec{
+					CloudsYAML: &tc.CloudsYAML,
+					Networking: tc.Networking,
+				},
+			}
+			if tc.TLSSecret != nil {
+				ironic.Spec.TLS.CertificateName = tc.TLSSecret.Name
+			}
+			resources := Resources{Ironic: ironic, APISecret: apiSecret, TLSSecret: tc.TLSSecret}
+			endpoints := buildStatusEndpoints(ControllerContext{}, resources)
+
+			data, err := buildCloudsYAML(resources, endpoints)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedYAML, string(data[cloudsYAMLKey]))
+			if tc.ExpectedCA != "" {
+				assert.Equal(t, tc.ExpectedCA, string(data["ca.crt"]))
+			} else {
+				assert.NotContains(t, data, "ca.crt")
+			}
+		})
+	}
+}
+
+func TestEnsureCloudsYAMLSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	newIronic := func() *metal3api.Ironic {
+		return &metal3api.Ironic{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ironic", Namespace: "test-ns", UID: "abc-123"},
+			Spec:       metal3api.IronicSpec{CloudsYAML: &metal3api.CloudsYAML{}},
+		}
+	}
+	newAPISecret := func(password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test-ns"},
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("admin"),
+				corev1.BasicAuthPasswordKey: []byte(password),
+			},
+		}
+	}
+	getSecret := func(t *testing.T, c client.Client, name string) (*corev1.Secret, error) {
+		t.Helper()
+		secret := &corev1.Secret{}
+		err := c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: name}, secret)
+		return secret, err
+	}
+
+	t.Run("creates and updates the secret", func(t *testing.T) {
+		ironicObj := newIronic()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+		resources := Resources{Ironic: ironicObj, APISecret: newAPISecret("password1")}
+		status, err := ensureCloudsYAMLSecret(cctx, resources, buildStatusEndpoints(cctx, resources))
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+
+		secret, err := getSecret(t, c, "test-ironic-clouds-yaml")
+		require.NoError(t, err)
+		assert.Contains(t, string(secret.Data[cloudsYAMLKey]), "password: password1")
+		assert.Equal(t, metal3api.LabelEnvironmentValue, secret.Labels[metal3api.LabelEnvironmentName])
+		assert.True(t, metav1.IsControlledBy(secret, ironicObj))
+
+		// The API secret has been rotated
+		resources.APISecret = newAPISecret("password2")
+		status, err = ensureCloudsYAMLSecret(cctx, resources, buildStatusEndpoints(cctx, resources))
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+
+		secret, err = getSecret(t, c, "test-ironic-clouds-yaml")
+		require.NoError(t, err)
+		assert.Contains(t, string(secret.Data[cloudsYAMLKey]), "password: password2")
+
+		// Generation is disabled
+		ironicObj.Spec.CloudsYAML = nil
+		status, err = ensureCloudsYAMLSecret(cctx, resources, buildStatusEndpoints(cctx, resources))
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+
+		_, err = getSecret(t, c, "test-ironic-clouds-yaml")
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("refuses to overwrite an unmanaged secret", func(t *testing.T) {
+		ironicObj := newIronic()
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-clouds-yaml", Namespace: "test-ns"},
+			Data:       map[string][]byte{cloudsYAMLKey: []byte("user data")},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, existing).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+		resources := Resources{Ironic: ironicObj, APISecret: newAPISecret("password")}
+		status, err := ensureCloudsYAMLSecret(cctx, resources, buildStatusEndpoints(cctx, resources))
+		require.NoError(t, err)
+		require.Error(t, status.Fatal)
+
+		secret, err := getSecret(t, c, "test-ironic-clouds-yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "user data", string(secret.Data[cloudsYAMLKey]))
+	})
+}
@@ -44,6 +44,8 @@ const (
 
 	trustedCAVolumeName = "trusted-ca"
 	bmcCAVolumeName     = "cert-bmc"
+	clientCAVolumeName  = "cert-client-ca"
+	htpasswdVolumeName  = "ironic-htpasswd"
 )
 
 func buildCommonEnvVars(ironic *metal3api.Ironic) []corev1.EnvVar {
@@ -112,7 +114,7 @@ func buildCommonEnvVars(ironic *metal3api.Ironic) []corev1.EnvVar {
 		)
 	}
 
-	if ironic.Spec.TLS.CertificateName != "" {
+	if ServingCertificateName(ironic) != "" {
 		// Ironic will listen on a Unix socket, httpd will be responsible for serving HTTPS.
 		result = append(result, []corev1.EnvVar{
 			{
@@ -133,6 +135,8 @@ func buildCommonEnvVars(ironic *metal3api.Ironic) []corev1.EnvVar {
 				},
 			)
 		}
+
+		result = append(result, buildTLSOptionsEnvVars(&ironic.Spec.TLS)...)
 	}
 
 	result = appendStringEnv(result,
@@ -218,6 +222,19 @@ func databaseClientEnvVars(db *metal3api.Database) []corev1.EnvVar {
 		},
 	}
 
+	if db.ClientCertificateName != "" {
+		envVars = append(envVars, []corev1.EnvVar{
+			{
+				Name:  "MARIADB_CERT_FILE",
+				Value: certsDir + "/mariadb/" + corev1.TLSCertKey,
+			},
+			{
+				Name:  "MARIADB_KEY_FILE",
+				Value: certsDir + "/mariadb/" + corev1.TLSPrivateKeyKey,
+			},
+		}...)
+	}
+
 	return envVars
 }
 
@@ -248,7 +265,8 @@ func buildTrustedCAEnvVars(cctx ControllerContext, resources Resources) []corev1
 
 	// Get the TrustedCA reference to check if a specific key was requested
 	var requestedKey string
-	if resources.Ironic.Spec.TLS.TrustedCA != nil {
+	// Generated bundles contain only one key
+	if resources.Ironic.Spec.TLS.TrustedCA != nil && len(resources.Ironic.Spec.TLS.AdditionalTrustedCAs) == 0 {
 		requestedKey = resources.Ironic.Spec.TLS.TrustedCA.Key
 	}
 
@@ -280,6 +298,31 @@ func buildTrustedCAEnvVars(cctx ControllerContext, resources Resources) []corev1
 	}
 }
 
+// clientCAPath returns the path to the CA used to validate client
+// certificates, or an empty string if client certificates are not used.
+func clientCAPath(resources Resources) string {
+	if resources.TLSSecret == nil || resources.Ironic.Spec.TLS.ClientCA == nil {
+		return ""
+	}
+
+	key := resources.Ironic.Spec.TLS.ClientCA.Key
+	if key == "" {
+		var keys []string
+		switch {
+		case resources.ClientCASecret != nil:
+			keys = slices.Sorted(maps.Keys(resources.ClientCASecret.Data))
+		case resources.ClientCAConfigMap != nil:
+			keys = slices.Sorted(maps.Keys(resources.ClientCAConfigMap.Data))
+		}
+		if len(keys) == 0 {
+			return ""
+		}
+		key = keys[0]
+	}
+
+	return fmt.Sprintf("%s/ca/client/%s", certsDir, key)
+}
+
 func buildIronicEnvVars(cctx ControllerContext, resources Resources) []corev1.EnvVar {
 	result := buildCommonEnvVars(resources.Ironic)
 	result = append(result, []corev1.EnvVar{
@@ -326,17 +369,7 @@ func buildIronicEnvVars(cctx ControllerContext, resources Resources) []corev1.En
 	// When JSON RPC is enabled, the password is required for it as well.
 	if resources.TLSSecret == nil || resources.Ironic.Spec.HighAvailability {
 		result = append(result,
-			corev1.EnvVar{
-				Name: "IRONIC_HTPASSWD",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: resources.APISecret.Name,
-						},
-						Key: htpasswdKey,
-					},
-				},
-			},
+			htpasswdEnvVar(),
 		)
 	}
 
@@ -414,22 +447,7 @@ func buildIronicEnvVars(cctx ControllerContext, resources Resources) []corev1.En
 
 	result = appendStringEnv(result, "IRONIC_EXTERNAL_IP", resources.Ironic.Spec.Networking.ExternalIP)
 
-	var externalCallbackURL, imageServerExternalURL string
-	if resources.Ironic.Spec.Networking.Ingress != nil {
-		externalCallbackURL = "https://" + resources.Ironic.Spec.Networking.Ingress.Host
-		imageServerExternalURL = "https://" + resources.Ironic.Spec.Networking.Ingress.Host
-		// allow overrides of the external URLs when ingress is used
-		if resources.Ironic.Spec.Networking.ExternalCallbackURL != "" {
-			externalCallbackURL = resources.Ironic.Spec.Networking.ExternalCallbackURL
-		}
-		if resources.Ironic.Spec.Networking.ImageServerExternalURL != "" {
-			imageServerExternalURL = resources.Ironic.Spec.Networking.ImageServerExternalURL
-		}
-	} else if resources.Ironic.Spec.Networking.ExternalCallbackURL != "" && resources.Ironic.Spec.Networking.ImageServerExternalURL != "" {
-		// only set the external URLs when both are provided, to avoid misconfiguration
-		externalCallbackURL = resources.Ironic.Spec.Networking.ExternalCallbackURL
-		imageServerExternalURL = resources.Ironic.Spec.Networking.ImageServerExternalURL
-	}
+	externalCallbackURL, imageServerExternalURL := externalURLs(&resources.Ironic.Spec.Networking)
 	if externalCallbackURL != "" && imageServerExternalURL != "" {
 		result = appendStringEnv(result, "IRONIC_EXTERNAL_CALLBACK_URL", externalCallbackURL)
 		result = appendStringEnv(result, "IRONIC_EXTERNAL_HTTP_URL", imageServerExternalURL)
@@ -470,20 +488,28 @@ func formatProviderNetwork(pn *metal3api.ProviderNetworkConfig) string {
 func buildHttpdEnvVars(resources Resources) []corev1.EnvVar {
 	result := buildCommonEnvVars(resources.Ironic)
 
-	// When TLS is used, httpd is responsible for authentication
-	if resources.TLSSecret != nil {
+	clientCAFile := clientCAPath(resources)
+	if clientCAFile != "" {
 		result = append(result,
 			corev1.EnvVar{
-				Name: "IRONIC_HTPASSWD",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: resources.APISecret.Name,
-						},
-						Key: htpasswdKey,
-					},
-				},
+				Name:  "IRONIC_CLIENT_CA_FILE",
+				Value: clientCAFile,
 			},
+			// The ramdisk agent cannot present a client certificate.
+			corev1.EnvVar{
+				Name:  "IRONIC_CLIENT_CA_EXEMPT_PATHS",
+				Value: strings.Join(agentPaths, " "),
+			},
+		)
+	}
+
+	// When TLS is used, httpd is responsible for authentication. Basic
+	// authentication is only disabled once the running Ironic has been
+	// verified to require client certificates.
+	certificateOnly := clientCAFile != "" && resources.Ironic.Spec.TLS.ClientCertificateOnly && clientCertificatesVerified(resources.Ironic)
+	if resources.TLSSecret != nil && !certificateOnly {
+		result = append(result,
+			htpasswdEnvVar(),
 		)
 	}
 
@@ -521,9 +547,57 @@ func databaseClientMounts(db *metal3api.Database) (volumes []corev1.Volume, moun
 		})
 	}
 
+	if db.ClientCertificateName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "client-cert-mariadb",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  db.ClientCertificateName,
+					DefaultMode: ptr.To(corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "client-cert-mariadb",
+			MountPath: certsDir + "/mariadb",
+		})
+	}
+
 	return volumes, mounts
 }
 
+// htpasswdEnvVar points Ironic and httpd to the mounted htpasswd file. Unlike
+// an environment variable with the file contents, the file is updated in
+// place when the credentials change and is re-read on each request, so
+// rotating the API credentials does not require a restart.
+func htpasswdEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name:  "IRONIC_HTPASSWD_FILE",
+		Value: authDir + "/htpasswd/" + htpasswdKey,
+	}
+}
+
+// htpasswdVolumeAndMount mounts the htpasswd of all API users, see
+// EnsureHtpasswdSecret.
+func htpasswdVolumeAndMount(ironic *metal3api.Ironic) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: htpasswdVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  HtpasswdSecretName(ironic),
+				Items:       []corev1.KeyToPath{{Key: htpasswdKey, Path: htpasswdKey}},
+				DefaultMode: ptr.To(corev1.SecretVolumeSourceDefaultMode),
+			},
+		},
+	}
+	mount := corev1.VolumeMount{
+		Name:      htpasswdVolumeName,
+		MountPath: authDir + "/htpasswd",
+		ReadOnly:  true,
+	}
+	return volume, mount
+}
+
 // isAuthVolumeRequired returns true when the ironic-rpc auth mount is needed.
 // HA requires it for inter-conductor RPC; networking service requires it for
 // authenticated JSON-RPC between Ironic and the networking service.
@@ -562,6 +636,10 @@ func buildIronicVolumesAndMounts(resources Resources) (volumes []corev1.Volume,
 		})
 	}
 
+	htpasswdVolume, htpasswdMount := htpasswdVolumeAndMount(resources.Ironic)
+	volumes = append(volumes, htpasswdVolume)
+	mounts = append(mounts, htpasswdMount)
+
 	if resources.TLSSecret != nil {
 		volumes = append(volumes,
 			corev1.Volume{
@@ -614,6 +692,19 @@ func buildIronicVolumesAndMounts(resources Resources) (volumes []corev1.Volume,
 		)
 	}
 
+	if resources.TLSSecret != nil {
+		if maybeVolume := volumeForSecretOrConfigMap(clientCAVolumeName, resources.ClientCASecret, resources.ClientCAConfigMap); maybeVolume != nil {
+			volumes = append(volumes, *maybeVolume)
+			mounts = append(mounts,
+				corev1.VolumeMount{
+					Name:      clientCAVolumeName,
+					MountPath: certsDir + "/ca/client",
+					ReadOnly:  true,
+				},
+			)
+		}
+	}
+
 	if resources.Ironic.Spec.Database != nil {
 		dbVolumes, dbMounts := databaseClientMounts(resources.Ironic.Spec.Database)
 		volumes = append(volumes, dbVolumes...)
@@ -640,7 +731,7 @@ func buildIronicHttpdPorts(ironic *metal3api.Ironic) (ironicPorts []corev1.Conta
 		HostPort:      ironic.Spec.Networking.APIPort,
 	}
 
-	if ironic.Spec.TLS.CertificateName == "" {
+	if ServingCertificateName(ironic) == "" {
 		ironicPorts = append(ironicPorts, apiPort)
 	} else {
 		httpdPorts = append(httpdPorts, apiPort)
@@ -992,6 +1083,58 @@ func newPrometheusExporterContainer(versionInfo VersionInfo, ironic *metal3api.I
 	return container
 }
 
+// databaseVersionAnnotations returns annotations with hashes of the database
+// secrets, which are used both by Ironic and by the upgrade jobs.
+func databaseVersionAnnotations(resources Resources) map[string]string {
+	annotations := make(map[string]string, 3)
+	for secretType, secret := range map[string]*corev1.Secret{
+		"database-secret":        resources.DatabaseSecret,
+		"database-tls-secret":    resources.DatabaseTLSSecret,
+		"database-client-secret": resources.DatabaseClientSecret,
+	} {
+		if secret != nil {
+			maps.Copy(annotations, secretVersionAnnotations(secretType, secret))
+		}
+	}
+	return annotations
+}
+
+// resourceVersionAnnotations returns annotations with hashes of all secrets
+// and config maps used by Ironic to make sure the pod is restarted when any
+// of them changes.
+func resourceVersionAnnotations(resources Resources) map[string]string {
+	// The htpasswd is re-read from the mounted file, only the JSON RPC
+	// credentials are loaded on start-up.
+	annotations := make(map[string]string)
+	if isAuthVolumeRequired(resources) {
+		annotations = versionAnnotation("api-secret", map[string][]byte{
+			corev1.BasicAuthUsernameKey: resources.APISecret.Data[corev1.BasicAuthUsernameKey],
+			corev1.BasicAuthPasswordKey: resources.APISecret.Data[corev1.BasicAuthPasswordKey],
+		})
+	}
+	for secretType, secret := range map[string]*corev1.Secret{
+		"tls-secret":        resources.TLSSecret,
+		"bmc-ca-secret":     resources.BMCCASecret,
+		"trusted-ca-secret": resources.TrustedCASecret,
+		"client-ca-secret":  resources.ClientCASecret,
+	} {
+		if secret != nil {
+			maps.Copy(annotations, secretVersionAnnotations(secretType, secret))
+		}
+	}
+	maps.Copy(annotations, databaseVersionAnnotations(resources))
+	for configMapType, configMap := range map[string]*corev1.ConfigMap{
+		"bmc-ca-configmap":     resources.BMCCAConfigMap,
+		"trusted-ca-configmap": resources.TrustedCAConfigMap,
+		"client-ca-configmap":  resources.ClientCAConfigMap,
+	} {
+		if configMap != nil {
+			maps.Copy(annotations, configMapVersionAnnotations(configMapType, configMap))
+		}
+	}
+	return annotations
+}
+
 func newIronicPodTemplate(cctx ControllerContext, resources Resources) (corev1.PodTemplateSpec, error) {
 	if len(resources.APISecret.Data[htpasswdKey]) == 0 {
 		return corev1.PodTemplateSpec{}, errors.New("no htpasswd in the API secret")
@@ -1035,7 +1178,17 @@ func newIronicPodTemplate(cctx ControllerContext, resources Resources) (corev1.P
 
 	ironicPorts, httpdPorts := buildIronicHttpdPorts(resources.Ironic)
 
-	ironicHandler := newURLProbeHandler(resources.TLSSecret != nil, int(resources.Ironic.Spec.Networking.APIPort), "/v1", true)
+	var ironicHandler corev1.ProbeHandler
+	if clientCAPath(resources) != "" {
+		// NOTE: the probe cannot present a client certificate, only check that httpd accepts connections.
+		ironicHandler = corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt32(resources.Ironic.Spec.Networking.APIPort),
+			},
+		}
+	} else {
+		ironicHandler = newURLProbeHandler(resources.TLSSecret != nil, int(resources.Ironic.Spec.Networking.APIPort), "/v1", true)
+	}
 
 	// Httpd probes are always exec-based (curl). HTTP 2xx is only required when the default IPA
 	// kernel is expected to be served (i.e. no custom images and downloader is enabled).
@@ -1126,11 +1279,7 @@ func newIronicPodTemplate(cctx ControllerContext, resources Resources) (corev1.P
 		containers = append(containers, newPrometheusExporterContainer(cctx.VersionInfo, resources.Ironic, sharedVolumeMount))
 	}
 
-	// Make sure the pod is restarted when secrets change.
-	annotations := secretVersionAnnotations("api-secret", resources.APISecret)
-	if resources.TLSSecret != nil {
-		maps.Copy(annotations, secretVersionAnnotations("tls-secret", resources.TLSSecret))
-	}
+	annotations := resourceVersionAnnotations(resources)
 
 	hostNetwork := !resources.Ironic.Spec.Networking.DisableHostNetwork
 	dnsPolicy := corev1.DNSClusterFirstWithHostNet
@@ -298,11 +298,230 @@ func TestAnnotationLabelOverrides(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "my-annotation", podTemplate.Annotations["annotation.example.com"])
-	assert.NotEmpty(t, podTemplate.Annotations["ironic.metal3.io/api-secret-version"])
 	assert.Equal(t, "my-label", podTemplate.Labels["label.example.com"])
 	assert.Equal(t, "test", podTemplate.Labels[metal3api.IronicServiceLabel])
 }
 
+func TestResourceVersionAnnotations(t *testing.T) {
+	cctx := ControllerContext{}
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test",
+		},
+		Spec: metal3api.IronicSpec{
+			Database: &metal3api.Database{
+				CredentialsName:    "db-credentials",
+				TLSCertificateName: "db-tls",
+				Host:               "db.example.com",
+				Name:               "ironic",
+			},
+		},
+	}
+
+	version, err := cctx.VersionInfo.WithIronicOverrides(ironic)
+	require.NoError(t, err)
+	cctx.VersionInfo = version
+
+	resources := Resources{
+		Ironic:             ironic,
+		APISecret:          &corev1.Secret{Data: map[string][]byte{"htpasswd": []byte("abcd")}},
+		BMCCASecret:        &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("bmc-ca")}},
+		TrustedCAConfigMap: &corev1.ConfigMap{Data: map[string]string{"ca.crt": "trusted-ca"}},
+		DatabaseSecret:     &corev1.Secret{Data: map[string][]byte{"password": []byte("pass")}},
+		DatabaseTLSSecret:  &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("db-ca")}},
+	}
+	podTemplate, err := newIronicPodTemplate(cctx, resources)
+	require.NoError(t, err)
+
+	for _, key := range []string{"bmc-ca-secret", "trusted-ca-configmap", "database-secret", "database-tls-secret"} {
+		assert.Contains(t, podTemplate.Annotations, "ironic.metal3.io/"+key+"-version")
+	}
+	// The htpasswd is re-read from the mounted file
+	assert.NotContains(t, podTemplate.Annotations, "ironic.metal3.io/api-secret-version")
+	assert.NotContains(t, podTemplate.Annotations, "ironic.metal3.io/tls-secret-version")
+
+	oldHash := podTemplate.Annotations["ironic.metal3.io/trusted-ca-configmap-version"]
+	resources.TrustedCAConfigMap.Data["ca.crt"] = "new-trusted-ca"
+	podTemplate, err = newIronicPodTemplate(cctx, resources)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldHash, podTemplate.Annotations["ironic.metal3.io/trusted-ca-configmap-version"])
+
+	// JSON RPC credentials are only read on start-up
+	ironic.Spec.HighAvailability = true
+	resources.APISecret.Data["username"] = []byte("admin")
+	resources.APISecret.Data["password"] = []byte("pwd1")
+	oldHash = resourceVersionAnnotations(resources)["ironic.metal3.io/api-secret-version"]
+	assert.NotEmpty(t, oldHash)
+	resources.APISecret.Data["htpasswd"] = []byte("efgh")
+	assert.Equal(t, oldHash, resourceVersionAnnotations(resources)["ironic.metal3.io/api-secret-version"])
+	resources.APISecret.Data["password"] = []byte("pwd2")
+	assert.NotEqual(t, oldHash, resourceVersionAnnotations(resources)["ironic.metal3.io/api-secret-version"])
+}
+
+func TestHtpasswdMount(t *testing.T) {
+	resources := Resources{
+		Ironic:    &metal3api.Ironic{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}},
+		APISecret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api"}, Data: map[string][]byte{"htpasswd": []byte("abcd")}},
+	}
+	volumes, mounts := buildIronicVolumesAndMounts(resources)
+
+	var volume *corev1.Volume
+	for i := range volumes {
+		if volumes[i].Name == htpasswdVolumeName {
+			volume = &volumes[i]
+		}
+	}
+	require.NotNil(t, volume)
+	assert.Equal(t, "test-htpasswd", volume.Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "htpasswd", Path: "htpasswd"}}, volume.Secret.Items)
+	assert.Contains(t, mounts, corev1.VolumeMount{Name: htpasswdVolumeName, MountPath: "/auth/htpasswd", ReadOnly: true})
+
+	env := buildIronicEnvVars(ControllerContext{}, resources)
+	assert.Contains(t, env, corev1.EnvVar{Name: "IRONIC_HTPASSWD_FILE", Value: "/auth/htpasswd/htpasswd"})
+	for _, envVar := range env {
+		assert.NotEqual(t, "IRONIC_HTPASSWD", envVar.Name)
+	}
+}
+
+func TestClientCA(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		ClientCA              *metal3api.ResourceReferenceWithKey
+		ClientCertificateOnly bool
+		Verified              bool
+		WithTLS               bool
+
+		ExpectedClientCAFile string
+		ExpectedHtpasswd     bool
+	}{
+		{
+			Scenario:         "TLS without client CA",
+			WithTLS:          true,
+			ExpectedHtpasswd: true,
+		},
+		{
+			Scenario: "client CA with basic auth",
+			ClientCA: &metal3api.ResourceReferenceWithKey{
+				ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+			},
+			WithTLS:              true,
+			ExpectedClientCAFile: "/certs/ca/client/a.crt",
+			ExpectedHtpasswd:     true,
+		},
+		{
+			Scenario: "client CA only before verification",
+			ClientCA: &metal3api.ResourceReferenceWithKey{
+				ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+				Key:               "b.crt",
+			},
+			ClientCertificateOnly: true,
+			WithTLS:               true,
+			ExpectedClientCAFile:  "/certs/ca/client/b.crt",
+			ExpectedHtpasswd:      true,
+		},
+		{
+			Scenario: "client CA only",
+			ClientCA: &metal3api.ResourceReferenceWithKey{
+				ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+				Key:               "b.crt",
+			},
+			ClientCertificateOnly: true,
+			Verified:              true,
+			WithTLS:               true,
+			ExpectedClientCAFile:  "/certs/ca/client/b.crt",
+		},
+		{
+			Scenario: "client CA without TLS",
+			ClientCA: &metal3api.ResourceReferenceWithKey{
+				ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			cctx := ControllerContext{}
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "test",
+				},
+				Spec: metal3api.IronicSpec{
+					TLS: metal3api.TLS{
+						ClientCA:              tc.ClientCA,
+						ClientCertificateOnly: tc.ClientCertificateOnly,
+					},
+				},
+			}
+
+			resources := Resources{
+				Ironic:    ironic,
+				APISecret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api"}, Data: map[string][]byte{"htpasswd": []byte("abcd")}},
+			}
+			if tc.Verified {
+				ironic.Status.Conditions = []metav1.Condition{{
+					Type:   string(metal3api.IronicStatusClientCertificatesVerified),
+					Status: metav1.ConditionTrue,
+					Reason: metal3api.IronicReasonAvailable,
+				}}
+			}
+			if tc.WithTLS {
+				ironic.Spec.TLS.CertificateName = "tls"
+				resources.TLSSecret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls"}}
+			}
+			if tc.ClientCA != nil {
+				resources.ClientCAConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "client-ca"},
+					Data:       map[string]string{"a.crt": "a", "b.crt": "b"},
+				}
+			}
+
+			podTemplate, err := newIronicPodTemplate(cctx, resources)
+			require.NoError(t, err)
+
+			var httpd, ironicContainer *corev1.Container
+			for i := range podTemplate.Spec.Containers {
+				switch podTemplate.Spec.Containers[i].Name {
+				case "httpd":
+					httpd = &podTemplate.Spec.Containers[i]
+				case ironicContainerName:
+					ironicContainer = &podTemplate.Spec.Containers[i]
+				}
+			}
+			require.NotNil(t, httpd)
+			require.NotNil(t, ironicContainer)
+
+			env := make(map[string]corev1.EnvVar, len(httpd.Env))
+			for _, envVar := range httpd.Env {
+				env[envVar.Name] = envVar
+			}
+			assert.Equal(t, tc.ExpectedClientCAFile, env["IRONIC_CLIENT_CA_FILE"].Value)
+			if tc.ExpectedClientCAFile != "" {
+				assert.Equal(t, "/v1/lookup /v1/heartbeat", env["IRONIC_CLIENT_CA_EXEMPT_PATHS"].Value)
+			}
+			_, hasHtpasswd := env["IRONIC_HTPASSWD_FILE"]
+			assert.Equal(t, tc.ExpectedHtpasswd, hasHtpasswd)
+
+			var foundMount bool
+			for _, mount := range httpd.VolumeMounts {
+				if mount.MountPath == "/certs/ca/client" {
+					foundMount = true
+				}
+			}
+			assert.Equal(t, tc.ExpectedClientCAFile != "", foundMount)
+
+			if tc.ExpectedClientCAFile != "" {
+				assert.NotNil(t, ironicContainer.ReadinessProbe.TCPSocket)
+				assert.Contains(t, podTemplate.Annotations, "ironic.metal3.io/client-ca-configmap-version")
+			} else {
+				assert.NotNil(t, ironicContainer.ReadinessProbe.Exec)
+			}
+		})
+	}
+}
+
 func TestTrustedCAConfigMap(t *testing.T) {
 	testCases := []struct {
 		Scenario                string
@@ -0,0 +1,259 @@
+package ironic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	databaseCheckContainer = "database-check"
+
+	databaseCheckExitUnreachable int32 = 2
+	databaseCheckExitAuthFailed  int32 = 3
+	databaseCheckExitTLSError    int32 = 4
+
+	// Retry a few times in case the database is still starting.
+	databaseCheckBackoffLimit int32 = 3
+
+	// DatabaseCheckRetryInterval is how long to wait after the database
+	// check has failed because the database is unreachable before running
+	// it again.
+	DatabaseCheckRetryInterval = 5 * time.Minute
+)
+
+var databaseCheckReasons = map[int32]string{
+	databaseCheckExitUnreachable: metal3api.IronicReasonDatabaseUnreachable,
+	databaseCheckExitAuthFailed:  metal3api.IronicReasonDatabaseAuthFailed,
+	databaseCheckExitTLSError:    metal3api.IronicReasonDatabaseTLSError,
+}
+
+// databaseCheckScript connects to the database using the same credentials
+// and certificates as Ironic. The exit code identifies the kind of failure,
+// the details are written to the termination log.
+const databaseCheckScript = `
+import os
+import ssl
+import sys
+
+import pymysql
+
+
+def read(path):
+    with open(path) as f:
+        return f.read().strip()
+
+
+def fail(code, message):
+    with open("/dev/termination-log", "w") as f:
+        f.write(message)
+    sys.exit(code)
+
+
+ssl_args = None
+if os.path.isdir("/certs/ca/mariadb"):
+    ca_file = "/certs/ca/mariadb/ca.crt"
+    if not os.path.exists(ca_file):
+        ca_file = "/certs/ca/mariadb/tls.crt"
+    ssl_args = {"ca": ca_file}
+    if os.environ.get("MARIADB_CERT_FILE"):
+        ssl_args["cert"] = os.environ["MARIADB_CERT_FILE"]
+        ssl_args["key"] = os.environ["MARIADB_KEY_FILE"]
+
+try:
+    pymysql.connect(
+        host=os.environ["MARIADB_HOST"],
+        database=os.environ["MARIADB_DATABASE"],
+        user=read("/auth/mariadb/username"),
+        password=read("/auth/mariadb/password"),
+        ssl=ssl_args,
+        connect_timeout=10,
+    ).close()
+except ssl.SSLError as exc:
+    fail(4, "TLS error: %s" % exc)
+except pymysql.err.OperationalError as exc:
+    if isinstance(getattr(exc, "original_exception", None), ssl.SSLError):
+        fail(4, "TLS error: %s" % exc.original_exception)
+    if exc.args and exc.args[0] in (1044, 1045, 1698):
+        fail(3, "authentication failed: %s" % exc.args[-1])
+    fail(2, "database unreachable: %s" % exc.args[-1])
+`
+
+func databaseCheckJobName(cctx ControllerContext, ironic *metal3api.Ironic) string {
+	return fmt.Sprintf("%s-database-check-%s", ironic.Name, cctx.VersionInfo.InstalledVersion)
+}
+
+func newDatabaseCheckTemplate(cctx ControllerContext, resources Resources) corev1.PodTemplateSpec {
+	ironic := resources.Ironic
+	volumes, mounts := databaseClientMounts(ironic.Spec.Database)
+
+	containers := []corev1.Container{
+		{
+			Name:                     databaseCheckContainer,
+			Image:                    cctx.VersionInfo.IronicImage,
+			Command:                  []string{"python3", "-c", databaseCheckScript},
+			Env:                      databaseClientEnvVars(ironic.Spec.Database),
+			VolumeMounts:             mounts,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:  ptr.To(ironicUser),
+				RunAsGroup: ptr.To(ironicGroup),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+	}
+	return applyOverridesToPod(ironic.Spec.Overrides, corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				metal3api.IronicServiceLabel: ironic.Name,
+				metal3api.IronicVersionLabel: cctx.VersionInfo.InstalledVersion.String(),
+			},
+			Annotations: databaseVersionAnnotations(resources),
+		},
+		Spec: corev1.PodSpec{
+			Containers:    containers,
+			Volumes:       volumes,
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	})
+}
+
+// databaseCheckFailure returns the reason and the termination message of
+// the most recent failed database check pod, if any.
+func databaseCheckFailure(cctx ControllerContext, job *batchv1.Job) (reason, message string, err error) {
+	failed, err := latestFailedContainer(cctx, job, databaseCheckContainer)
+	if err != nil || failed == nil {
+		return "", "", err
+	}
+	latest := failed.state
+
+	reason = databaseCheckReasons[latest.ExitCode]
+	if reason == "" {
+		reason = metal3api.IronicReasonFailed
+	}
+	message = strings.TrimSpace(latest.Message)
+	if message == "" {
+		message = fmt.Sprintf("exit code %d", latest.ExitCode)
+	}
+	return reason, message, nil
+}
+
+// ensureDatabaseCheckJob verifies that the database is reachable with the
+// provided credentials before any migrations are started.
+func ensureDatabaseCheckJob(cctx ControllerContext, resources Resources) (Status, error) {
+	if !upgradeJobRequired(cctx, resources.Ironic) {
+		return ready()
+	}
+
+	template := newDatabaseCheckTemplate(cctx, resources)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      databaseCheckJobName(cctx, resources.Ironic),
+			Namespace: resources.Ironic.Namespace,
+			Labels: map[string]string{
+				metal3api.IronicServiceLabel: resources.Ironic.Name,
+				metal3api.IronicVersionLabel: cctx.VersionInfo.InstalledVersion.String(),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template:                template,
+			BackoffLimit:            ptr.To(databaseCheckBackoffLimit),
+			TTLSecondsAfterFinished: ptr.To(jobTTLSeconds),
+			PodReplacementPolicy:    ptr.To(batchv1.Failed),
+			// Retrying does not help with invalid credentials or certificates
+			PodFailurePolicy: &batchv1.PodFailurePolicy{
+				Rules: []batchv1.PodFailurePolicyRule{
+					{
+						Action: batchv1.PodFailurePolicyActionFailJob,
+						OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+							ContainerName: ptr.To(databaseCheckContainer),
+							Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+							Values:        []int32{databaseCheckExitAuthFailed, databaseCheckExitTLSError},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	existing := &batchv1.Job{}
+	err := cctx.Client.Get(cctx.Context, client.ObjectKeyFromObject(job), existing)
+	if k8serrors.IsNotFound(err) {
+		cctx.Logger.Info("checking database connectivity", "Job", job.Name)
+		err = controllerutil.SetControllerReference(resources.Ironic, job, cctx.Scheme)
+		if err == nil {
+			err = cctx.Client.Create(cctx.Context, job)
+		}
+		if err != nil {
+			return transientError(err)
+		}
+		return updated()
+	}
+	if err != nil {
+		return transientError(err)
+	}
+
+	// Always re-run the check when credentials change
+	if databaseCredentialsChanged(existing, template) {
+		cctx.Logger.Info("database credentials changed, restarting the database check", "Job", existing.Name)
+		err = cctx.Client.Delete(cctx.Context, existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return transientError(err)
+		}
+		return updated()
+	}
+
+	if hasJobCondition(existing, batchv1.JobComplete) {
+		return ready()
+	}
+
+	reason, message, err := databaseCheckFailure(cctx, existing)
+	if err != nil {
+		return transientError(err)
+	}
+
+	if !hasJobCondition(existing, batchv1.JobFailed) {
+		if reason == "" {
+			return inProgress("database check job not complete yet")
+		}
+		return Status{Message: "database check is being retried: " + message, Reason: reason}, nil
+	}
+
+	if reason == metal3api.IronicReasonDatabaseUnreachable {
+		// The database may become available later, start over once the
+		// retry interval has passed. The controller requeues accordingly.
+		retryTime := jobFailureTime(existing).Add(DatabaseCheckRetryInterval)
+		if time.Now().Before(retryTime) {
+			return Status{
+				Message: fmt.Sprintf("database check failed: %s; retrying after %s", message, retryTime.UTC().Format(time.RFC3339)),
+				Reason:  reason,
+			}, nil
+		}
+
+		cctx.Logger.Info("database is still unreachable, restarting the database check", "Job", existing.Name, "Message", message)
+		err = cctx.Client.Delete(cctx.Context, existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return transientError(err)
+		}
+		return Status{Message: "database check failed: " + message, Reason: reason, requeue: true}, nil
+	}
+
+	if reason == "" {
+		// Pods are gone, fall back to the job conditions
+		return getJobStatus(cctx, existing, "database check")
+	}
+	return Status{Fatal: fmt.Errorf("database check failed: %s", message), Reason: reason}, nil
+}
@@ -0,0 +1,193 @@
+package ironic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestEnsureDatabaseCheckJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	resources := Resources{
+		Ironic: &metal3api.Ironic{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "abc-123"},
+			Spec: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "db-credentials",
+					Host:            "db.example.com",
+					Name:            "ironic",
+				},
+			},
+		},
+		DatabaseSecret: &corev1.Secret{Data: map[string][]byte{"password": []byte("password")}},
+	}
+
+	failedPod := func(name string, exitCode int32, message string, finishedAt time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels:    map[string]string{batchv1.JobNameLabel: "test-database-check-latest"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: databaseCheckContainer,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:   exitCode,
+								Message:    message,
+								FinishedAt: metav1.NewTime(finishedAt),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+
+	testCases := []struct {
+		Scenario string
+
+		JobConditions []batchv1.JobCondition
+		Pods          []runtime.Object
+
+		ExpectedReady   bool
+		ExpectedFatal   string
+		ExpectedMessage string
+		ExpectedReason  string
+		ExpectedDeleted bool
+	}{
+		{
+			Scenario:        "running",
+			ExpectedMessage: "database check job not complete yet",
+		},
+		{
+			Scenario:      "succeeded",
+			JobConditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			ExpectedReady: true,
+		},
+		{
+			Scenario: "retrying",
+			Pods: []runtime.Object{
+				failedPod("pod-1", databaseCheckExitUnreachable, "database unreachable: timed out", now),
+			},
+			ExpectedMessage: "database check is being retried: database unreachable: timed out",
+			ExpectedReason:  metal3api.IronicReasonDatabaseUnreachable,
+		},
+		{
+			Scenario:      "authentication failed",
+			JobConditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			Pods: []runtime.Object{
+				failedPod("pod-1", databaseCheckExitUnreachable, "database unreachable: timed out", now.Add(-time.Minute)),
+				failedPod("pod-2", databaseCheckExitAuthFailed, "authentication failed: Access denied\n", now),
+			},
+			ExpectedFatal:  "database check failed: authentication failed: Access denied",
+			ExpectedReason: metal3api.IronicReasonDatabaseAuthFailed,
+		},
+		{
+			Scenario:      "TLS error",
+			JobConditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			Pods: []runtime.Object{
+				failedPod("pod-1", databaseCheckExitTLSError, "TLS error: certificate verify failed", now),
+			},
+			ExpectedFatal:  "database check failed: TLS error: certificate verify failed",
+			ExpectedReason: metal3api.IronicReasonDatabaseTLSError,
+		},
+		{
+			Scenario: "unreachable",
+			JobConditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+			},
+			Pods: []runtime.Object{
+				failedPod("pod-1", databaseCheckExitUnreachable, "database unreachable: timed out", now.Add(-time.Minute)),
+			},
+			ExpectedMessage: "database check failed: database unreachable: timed out; retrying after " +
+				now.Add(-time.Minute).Add(DatabaseCheckRetryInterval).UTC().Format(time.RFC3339),
+			ExpectedReason: metal3api.IronicReasonDatabaseUnreachable,
+		},
+		{
+			Scenario: "unreachable after retry interval",
+			JobConditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-DatabaseCheckRetryInterval))},
+			},
+			Pods: []runtime.Object{
+				failedPod("pod-1", databaseCheckExitUnreachable, "database unreachable: timed out", now.Add(-DatabaseCheckRetryInterval)),
+			},
+			ExpectedMessage: "database check failed: database unreachable: timed out",
+			ExpectedReason:  metal3api.IronicReasonDatabaseUnreachable,
+			ExpectedDeleted: true,
+		},
+		{
+			Scenario:      "pods are gone",
+			JobConditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+			ExpectedFatal: "database check job failed: BackoffLimitExceeded",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cctx := ControllerContext{
+				Context:    t.Context(),
+				Client:     c,
+				KubeClient: kubefake.NewClientset(tc.Pods...),
+				Scheme:     scheme,
+				Logger:     logr.Discard(),
+			}
+			version, err := cctx.VersionInfo.WithIronicOverrides(resources.Ironic)
+			require.NoError(t, err)
+			cctx.VersionInfo = version
+
+			status, err := ensureDatabaseCheckJob(cctx, resources)
+			require.NoError(t, err)
+			assert.True(t, status.NeedsRequeue())
+
+			job := &batchv1.Job{}
+			key := client.ObjectKey{Namespace: "test", Name: "test-database-check-latest"}
+			require.NoError(t, c.Get(t.Context(), key, job))
+			assert.Equal(t, []string{"python3", "-c", databaseCheckScript}, job.Spec.Template.Spec.Containers[0].Command)
+
+			job.Status.Conditions = tc.JobConditions
+			require.NoError(t, c.Status().Update(t.Context(), job))
+
+			status, err = ensureDatabaseCheckJob(cctx, resources)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReady, status.IsReady())
+			assert.Equal(t, tc.ExpectedReason, status.Reason)
+			if tc.ExpectedFatal != "" {
+				require.Error(t, status.Fatal)
+				assert.Equal(t, tc.ExpectedFatal, status.Fatal.Error())
+			} else {
+				require.NoError(t, status.Fatal)
+				assert.Equal(t, tc.ExpectedMessage, status.Message)
+			}
+
+			err = c.Get(t.Context(), key, job)
+			if tc.ExpectedDeleted {
+				assert.True(t, k8serrors.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
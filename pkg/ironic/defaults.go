@@ -0,0 +1,85 @@
+package ironic
+
+import (
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// Default ports, must match the defaults in the CRD.
+const (
+	DefaultAPIPort            = 6385
+	DefaultImageServerPort    = 6180
+	DefaultImageServerTLSPort = 6183
+	DefaultRPCPort            = 6189
+)
+
+// SetDefaults sets defaults for fields that are required by the operator.
+// Useful when the object does not come from the Kubernetes API, e.g. for
+// local installations or in the defaulting webhook.
+func SetDefaults(ironic *metal3api.IronicSpec) {
+	net := &ironic.Networking
+	if net.APIPort == 0 {
+		net.APIPort = DefaultAPIPort
+	}
+	if net.ImageServerPort == 0 {
+		net.ImageServerPort = DefaultImageServerPort
+	}
+	if net.ImageServerTLSPort == 0 {
+		net.ImageServerTLSPort = DefaultImageServerTLSPort
+	}
+	if net.RPCPort == 0 {
+		net.RPCPort = DefaultRPCPort
+	}
+}
+
+// DeprecationWarnings returns a human-readable warning for each deprecated
+// field used in the provided specification.
+func DeprecationWarnings(ironic *metal3api.IronicSpec) (warnings []string) {
+	if ironic.Networking.IPAddressManager != "" { //nolint:staticcheck // backward compat
+		warnings = append(warnings, "spec.networking.ipAddressManager is deprecated, use spec.networking.keepalived instead")
+	}
+	if ironic.TLS.BMCCAName != "" {
+		warnings = append(warnings, "spec.tls.bmcCAName is deprecated, use spec.tls.bmcCA instead")
+	}
+	if ironic.TLS.TrustedCAName != "" {
+		warnings = append(warnings, "spec.tls.trustedCAName is deprecated, use spec.tls.trustedCA instead")
+	}
+	return
+}
+
+// NormalizeDeprecated replaces deprecated fields with their modern
+// counterparts. Fields are left intact if the conversion is ambiguous, so
+// that validation can report the conflict.
+func NormalizeDeprecated(ironic *metal3api.IronicSpec) {
+	net := &ironic.Networking
+	if net.IPAddressManager == metal3api.IPAddressManagerKeepalived && net.Keepalived == nil { //nolint:staticcheck // backward compat
+		net.Keepalived = &metal3api.KeepalivedConfig{Enabled: true}
+		net.IPAddressManager = metal3api.IPAddressManagerNone //nolint:staticcheck // backward compat
+	}
+
+	tls := &ironic.TLS
+	if tls.BMCCAName != "" {
+		if tls.BMCCA == nil {
+			tls.BMCCA = &metal3api.ResourceReference{
+				Name: tls.BMCCAName,
+				Kind: metal3api.ResourceKindSecret,
+			}
+		}
+		if tls.BMCCA.Kind == metal3api.ResourceKindSecret && tls.BMCCA.Name == tls.BMCCAName {
+			tls.BMCCAName = ""
+		}
+	}
+
+	if tls.TrustedCAName != "" {
+		if tls.TrustedCA == nil {
+			tls.TrustedCA = &metal3api.ResourceReferenceWithKey{
+				ResourceReference: metal3api.ResourceReference{
+					Name: tls.TrustedCAName,
+					Kind: metal3api.ResourceKindConfigMap,
+				},
+			}
+		}
+		if tls.TrustedCA.Kind == metal3api.ResourceKindConfigMap && tls.TrustedCA.Name == tls.TrustedCAName {
+			tls.TrustedCAName = ""
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ironic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestSetDefaults(t *testing.T) {
+	spec := &metal3api.IronicSpec{
+		Networking: metal3api.Networking{APIPort: 8000},
+	}
+	SetDefaults(spec)
+
+	assert.Equal(t, int32(8000), spec.Networking.APIPort)
+	assert.Equal(t, int32(DefaultImageServerPort), spec.Networking.ImageServerPort)
+	assert.Equal(t, int32(DefaultImageServerTLSPort), spec.Networking.ImageServerTLSPort)
+	assert.Equal(t, int32(DefaultRPCPort), spec.Networking.RPCPort)
+}
+
+func TestNormalizeDeprecated(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		Spec metal3api.IronicSpec
+
+		Expected         metal3api.IronicSpec
+		ExpectedWarnings int
+	}{
+		{
+			Scenario: "nothing deprecated",
+			Spec: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					BMCCA: &metal3api.ResourceReference{Name: "bmc", Kind: metal3api.ResourceKindConfigMap},
+				},
+			},
+			Expected: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					BMCCA: &metal3api.ResourceReference{Name: "bmc", Kind: metal3api.ResourceKindConfigMap},
+				},
+			},
+		},
+		{
+			Scenario: "all deprecated fields",
+			Spec: metal3api.IronicSpec{
+				Networking: metal3api.Networking{
+					IPAddressManager: metal3api.IPAddressManagerKeepalived,
+				},
+				TLS: metal3api.TLS{
+					BMCCAName:     "bmc",
+					TrustedCAName: "trusted",
+				},
+			},
+			Expected: metal3api.IronicSpec{
+				Networking: metal3api.Networking{
+					Keepalived: &metal3api.KeepalivedConfig{Enabled: true},
+				},
+				TLS: metal3api.TLS{
+					BMCCA: &metal3api.ResourceReference{Name: "bmc", Kind: metal3api.ResourceKindSecret},
+					TrustedCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "trusted", Kind: metal3api.ResourceKindConfigMap},
+					},
+				},
+			},
+			ExpectedWarnings: 3,
+		},
+		{
+			Scenario: "consistent old and new fields",
+			Spec: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					BMCCA:     &metal3api.ResourceReference{Name: "bmc", Kind: metal3api.ResourceKindSecret},
+					BMCCAName: "bmc",
+				},
+			},
+			Expected: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					BMCCA: &metal3api.ResourceReference{Name: "bmc", Kind: metal3api.ResourceKindSecret},
+				},
+			},
+			ExpectedWarnings: 1,
+		},
+		{
+			Scenario: "inconsistent fields are left for validation",
+			Spec: metal3api.IronicSpec{
+				Networking: metal3api.Networking{
+					IPAddressManager: metal3api.IPAddressManagerKeepalived,
+					Keepalived:       &metal3api.KeepalivedConfig{Enabled: true},
+				},
+				TLS: metal3api.TLS{
+					TrustedCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "other", Kind: metal3api.ResourceKindConfigMap},
+					},
+					TrustedCAName: "trusted",
+				},
+			},
+			Expected: metal3api.IronicSpec{
+				Networking: metal3api.Networking{
+					IPAddressManager: metal3api.IPAddressManagerKeepalived,
+					Keepalived:       &metal3api.KeepalivedConfig{Enabled: true},
+				},
+				TLS: metal3api.TLS{
+					TrustedCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "other", Kind: metal3api.ResourceKindConfigMap},
+					},
+					TrustedCAName: "trusted",
+				},
+			},
+			ExpectedWarnings: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			warnings := DeprecationWarnings(&tc.Spec)
+			assert.Len(t, warnings, tc.ExpectedWarnings)
+
+			NormalizeDeprecated(&tc.Spec)
+			assert.Equal(t, tc.Expected, tc.Spec)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ironic
+
+import (
+	"fmt"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// externalURLs returns the external callback URL and the external image
+// server URL, or empty strings if they are not configured.
+func externalURLs(networking *metal3api.Networking) (externalCallbackURL, imageServerExternalURL string) {
+	if networking.Ingress != nil {
+		externalCallbackURL = "https://" + networking.Ingress.Host
+		imageServerExternalURL = "https://" + networking.Ingress.Host
+		// allow overrides of the external URLs when ingress is used
+		if networking.ExternalCallbackURL != "" {
+			externalCallbackURL = networking.ExternalCallbackURL
+		}
+		if networking.ImageServerExternalURL != "" {
+			imageServerExternalURL = networking.ImageServerExternalURL
+		}
+	} else if networking.ExternalCallbackURL != "" && networking.ImageServerExternalURL != "" {
+		// only set the external URLs when both are provided, to avoid misconfiguration
+		externalCallbackURL = networking.ExternalCallbackURL
+		imageServerExternalURL = networking.ImageServerExternalURL
+	}
+	return
+}
+
+// serviceHost returns the in-cluster DNS name of a service. The cluster
+// domain may be provided with or without the leading dot.
+func serviceHost(name, namespace, domain string) string {
+	if domain != "" && domain[0] != '.' {
+		domain = "." + domain
+	}
+	return fmt.Sprintf("%s.%s.svc%s", name, namespace, domain)
+}
+
+func ironicServiceHost(ironic *metal3api.Ironic, domain string) string {
+	return serviceHost(ironic.Name, ironic.Namespace, domain)
+}
+
+// caCertificateReference returns the CA that can be used to validate the
+// Ironic API certificate, following the same rules as IRONIC_CACERT_FILE.
+func caCertificateReference(resources Resources) *metal3api.ResourceReferenceWithKey {
+	if resources.TLSSecret == nil {
+		return nil
+	}
+
+	if _, hasCACert := resources.TLSSecret.Data["ca.crt"]; hasCACert {
+		return &metal3api.ResourceReferenceWithKey{
+			ResourceReference: metal3api.ResourceReference{
+				Name: resources.TLSSecret.Name,
+				Kind: metal3api.ResourceKindSecret,
+			},
+			Key: "ca.crt",
+		}
+	}
+
+	tls := &resources.Ironic.Spec.TLS
+	if tls.TrustedCA != nil {
+		return tls.TrustedCA.DeepCopy()
+	}
+	if trustedCA := GetTrustedCA(tls); trustedCA != nil {
+		return &metal3api.ResourceReferenceWithKey{ResourceReference: *trustedCA}
+	}
+
+	return nil
+}
+
+// buildStatusEndpoints returns the effective endpoints of the Ironic API and
+// the image server, matching the configuration of the service, the ingress
+// and the environment variables passed to Ironic.
+func buildStatusEndpoints(cctx ControllerContext, resources Resources) *metal3api.IronicEndpoints {
+	ironic := resources.Ironic
+	networking := &ironic.Spec.Networking
+	tlsEnabled := ServingCertificateName(ironic) != ""
+	virtualMediaTLS := tlsEnabled && !ironic.Spec.TLS.DisableVirtualMediaTLS
+
+	apiProto := protoHTTP
+	exposedPort := defaultExposedPort
+	imagesExposedPort := defaultImageExposedPort
+	if tlsEnabled {
+		apiProto = protoHTTPS
+		exposedPort = httpsExposedPort
+		imagesExposedPort = httpsImageExposedPort
+	}
+
+	serviceHosts := []string{ironicServiceHost(ironic, cctx.Domain)}
+	result := &metal3api.IronicEndpoints{
+		APIURLs: buildEndpoints(serviceHosts, exposedPort, apiProto),
+		TLS:     tlsEnabled,
+	}
+	// The service only exposes the image server in the non-HA mode.
+	if !ironic.Spec.HighAvailability {
+		result.ImageServerURLs = buildEndpoints(serviceHosts, imagesExposedPort, apiProto)
+	}
+
+	var hostIPs []string
+	if !networking.DisableHostNetwork && networking.IPAddress != "" {
+		hostIPs = append(hostIPs, networking.IPAddress)
+	}
+	if networking.ExternalIP != "" {
+		hostIPs = append(hostIPs, networking.ExternalIP)
+	}
+	if len(hostIPs) > 0 {
+		result.APIURLs = append(result.APIURLs, buildEndpoints(hostIPs, int(networking.APIPort), apiProto)...)
+		result.ImageServerURLs = append(result.ImageServerURLs, buildEndpoints(hostIPs, int(networking.ImageServerPort), protoHTTP)...)
+		if virtualMediaTLS {
+			result.ImageServerURLs = append(result.ImageServerURLs, buildEndpoints(hostIPs, int(networking.ImageServerTLSPort), protoHTTPS)...)
+		}
+	}
+
+	externalCallbackURL, imageServerExternalURL := externalURLs(networking)
+	if externalCallbackURL != "" && imageServerExternalURL != "" {
+		result.APIURLs = append(result.APIURLs, externalCallbackURL)
+		result.ImageServerURLs = append(result.ImageServerURLs, imageServerExternalURL)
+	}
+
+	if tlsEnabled {
+		result.CACertificate = caCertificateReference(resources)
+	}
+
+	return result
+}
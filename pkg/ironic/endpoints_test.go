@@ -0,0 +1,179 @@
+package ironic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestBuildStatusEndpoints(t *testing.T) {
+	defaultNetworking := metal3api.Networking{
+		APIPort:            6385,
+		ImageServerPort:    6180,
+		ImageServerTLSPort: 6183,
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Spec      metal3api.IronicSpec
+		TLSSecret *corev1.Secret
+		Domain    string
+
+		Expected metal3api.IronicEndpoints
+	}{
+		{
+			Scenario: "defaults",
+			Spec: metal3api.IronicSpec{
+				Networking: defaultNetworking,
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"http://test.test.svc"},
+				ImageServerURLs: []string{"http://test.test.svc:8080"},
+			},
+		},
+		{
+			Scenario: "cluster domain",
+			Spec: metal3api.IronicSpec{
+				Networking: defaultNetworking,
+			},
+			Domain: "cluster.local",
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"http://test.test.svc.cluster.local"},
+				ImageServerURLs: []string{"http://test.test.svc.cluster.local:8080"},
+			},
+		},
+		{
+			Scenario: "cluster domain with leading dot",
+			Spec: metal3api.IronicSpec{
+				Networking: defaultNetworking,
+			},
+			Domain: ".cluster.local",
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"http://test.test.svc.cluster.local"},
+				ImageServerURLs: []string{"http://test.test.svc.cluster.local:8080"},
+			},
+		},
+		{
+			Scenario: "host network with IP and TLS",
+			Spec: metal3api.IronicSpec{
+				Networking: func() metal3api.Networking {
+					networking := defaultNetworking
+					networking.IPAddress = "192.0.2.42"
+					return networking
+				}(),
+				TLS: metal3api.TLS{CertificateName: "tls"},
+			},
+			TLSSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+				Data:       map[string][]byte{"ca.crt": []byte("ca")},
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs: []string{"https://test.test.svc", "https://192.0.2.42:6385"},
+				ImageServerURLs: []string{
+					"https://test.test.svc:8443",
+					"http://192.0.2.42:6180",
+					"https://192.0.2.42:6183",
+				},
+				TLS: true,
+				CACertificate: &metal3api.ResourceReferenceWithKey{
+					ResourceReference: metal3api.ResourceReference{Name: "tls", Kind: metal3api.ResourceKindSecret},
+					Key:               "ca.crt",
+				},
+			},
+		},
+		{
+			Scenario: "external IP without host network",
+			Spec: metal3api.IronicSpec{
+				Networking: func() metal3api.Networking {
+					networking := defaultNetworking
+					networking.DisableHostNetwork = true
+					networking.IPAddress = "192.0.2.42"
+					networking.ExternalIP = "198.51.100.1"
+					return networking
+				}(),
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"http://test.test.svc", "http://198.51.100.1:6385"},
+				ImageServerURLs: []string{"http://test.test.svc:8080", "http://198.51.100.1:6180"},
+			},
+		},
+		{
+			Scenario: "ingress with image server override",
+			Spec: metal3api.IronicSpec{
+				Networking: func() metal3api.Networking {
+					networking := defaultNetworking
+					networking.Ingress = &metal3api.Ingress{Host: "ironic.example.com"}
+					networking.ImageServerExternalURL = "https://images.example.com"
+					return networking
+				}(),
+				TLS: metal3api.TLS{
+					CertificateName: "tls",
+					TrustedCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "trusted", Kind: metal3api.ResourceKindConfigMap},
+						Key:               "bundle.pem",
+					},
+				},
+			},
+			TLSSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"https://test.test.svc", "https://ironic.example.com"},
+				ImageServerURLs: []string{"https://test.test.svc:8443", "https://images.example.com"},
+				TLS:             true,
+				CACertificate: &metal3api.ResourceReferenceWithKey{
+					ResourceReference: metal3api.ResourceReference{Name: "trusted", Kind: metal3api.ResourceKindConfigMap},
+					Key:               "bundle.pem",
+				},
+			},
+		},
+		{
+			Scenario: "external URLs require both to be set",
+			Spec: metal3api.IronicSpec{
+				Networking: func() metal3api.Networking {
+					networking := defaultNetworking
+					networking.ExternalCallbackURL = "https://ironic.example.com"
+					return networking
+				}(),
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs:         []string{"http://test.test.svc"},
+				ImageServerURLs: []string{"http://test.test.svc:8080"},
+			},
+		},
+		{
+			Scenario: "high availability",
+			Spec: metal3api.IronicSpec{
+				Networking:       defaultNetworking,
+				HighAvailability: true,
+			},
+			Expected: metal3api.IronicEndpoints{
+				APIURLs: []string{"http://test.test.svc"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			cctx := ControllerContext{Domain: tc.Domain}
+			resources := Resources{
+				Ironic: &metal3api.Ironic{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "test",
+					},
+					Spec: tc.Spec,
+				},
+				TLSSecret: tc.TLSSecret,
+			}
+
+			result := buildStatusEndpoints(cctx, resources)
+			assert.Equal(t, tc.Expected, *result)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package ironic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+var errHtpasswdSecretNotManaged = errors.New("secret already exists and is not managed by the operator")
+
+// HtpasswdSecretName returns the name of the operator-owned Secret with the
+// htpasswd of all API users that is mounted into the Ironic pods.
+func HtpasswdSecretName(ironic *metal3api.Ironic) string {
+	return ironic.Name + "-htpasswd"
+}
+
+// buildHtpasswd combines the htpasswd of the API secret with the entries of
+// the additional API users. Entries from the existing htpasswd are only
+// regenerated when the corresponding credentials change.
+func buildHtpasswd(apiSecret *corev1.Secret, existingHtpasswd []byte, userSecrets []*corev1.Secret, logger logr.Logger) ([]byte, error) {
+	existing := make(map[string]string)
+	for line := range strings.SplitSeq(string(existingHtpasswd), "\n") {
+		if user, _, ok := strings.Cut(line, ":"); ok {
+			existing[user] = line
+		}
+	}
+
+	entries := make([]string, 0, len(userSecrets)+1)
+	entries = append(entries, strings.TrimSpace(string(apiSecret.Data[htpasswdKey])))
+	seen := map[string]bool{
+		normalizeSecretValue(apiSecret.Data[corev1.BasicAuthUsernameKey]): true,
+	}
+	for _, userSecret := range userSecrets {
+		user := normalizeSecretValue(userSecret.Data[corev1.BasicAuthUsernameKey])
+		if seen[user] {
+			return nil, fmt.Errorf("duplicate API user %q in secret %s/%s", user, userSecret.Namespace, userSecret.Name)
+		}
+		seen[user] = true
+
+		if line, ok := existing[user]; ok {
+			_, hashed, _ := strings.Cut(line, ":")
+			password := normalizeSecretValue(userSecret.Data[corev1.BasicAuthPasswordKey])
+			if password != "" && bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil {
+				entries = append(entries, line)
+				continue
+			}
+		}
+
+		logger.Info("generating htpasswd for an additional API user", "User", user, "Secret", userSecret.Name)
+		line, err := htpasswdFromSecret(userSecret)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, line)
+	}
+
+	return []byte(strings.Join(entries, "\n")), nil
+}
+
+// EnsureHtpasswdSecret creates or updates the Secret with the htpasswd of the
+// API secret and of the additional API users.
+func EnsureHtpasswdSecret(cctx ControllerContext, ironic *metal3api.Ironic, apiSecret *corev1.Secret, userSecrets []*corev1.Secret) error {
+	secretName := HtpasswdSecretName(ironic)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: ironic.Namespace},
+	}
+	result, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, ironic) {
+			// Never overwrite user-provided secrets
+			return errHtpasswdSecretNotManaged
+		}
+
+		htpasswd, err := buildHtpasswd(apiSecret, secret.Data[htpasswdKey], userSecrets, cctx.Logger)
+		if err != nil {
+			return err
+		}
+
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string, 3)
+		}
+		// Add the environment label so the secret is included in the filtered cache
+		secret.Labels[metal3api.LabelEnvironmentName] = metal3api.LabelEnvironmentValue
+		secret.Labels[managedSecretLabel] = managedSecretLabelValue
+		secret.Labels[metal3api.IronicServiceLabel] = ironic.Name
+
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{htpasswdKey: htpasswd}
+
+		return controllerutil.SetControllerReference(ironic, secret, cctx.Scheme)
+	})
+	if errors.Is(err, errHtpasswdSecretNotManaged) {
+		return fmt.Errorf("cannot use %s for htpasswd: %w", secretName, err)
+	}
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		cctx.Logger.Info("htpasswd secret", "Secret", secretName, "Status", result)
+	}
+
+	return nil
+}
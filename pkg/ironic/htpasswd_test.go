@@ -0,0 +1,125 @@
+package ironic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestBuildHtpasswd(t *testing.T) {
+	apiSecret, err := GenerateSecret(&metav1.ObjectMeta{Name: "my-ironic", Namespace: "test"}, "service", true)
+	require.NoError(t, err)
+	ownHtpasswd := string(apiSecret.Data[htpasswdKey])
+
+	userSecret := func(user, password string) *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{
+				"username": []byte(user),
+				"password": []byte(password),
+			},
+		}
+	}
+
+	htpasswd, err := buildHtpasswd(apiSecret, nil, nil, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, ownHtpasswd, string(htpasswd))
+
+	htpasswd, err = buildHtpasswd(apiSecret, htpasswd, []*corev1.Secret{userSecret("monitoring", "pwd1"), userSecret("bmo", "pwd2")}, logr.Discard())
+	require.NoError(t, err)
+	lines := strings.Split(string(htpasswd), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, ownHtpasswd, lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "monitoring:"))
+	assert.True(t, strings.HasPrefix(lines[2], "bmo:"))
+
+	// Nothing changed, bcrypt hashes are not regenerated
+	unchanged, err := buildHtpasswd(apiSecret, htpasswd, []*corev1.Secret{userSecret("monitoring", "pwd1"), userSecret("bmo", "pwd2")}, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, string(htpasswd), string(unchanged))
+
+	// Revoking a user
+	revoked, err := buildHtpasswd(apiSecret, htpasswd, []*corev1.Secret{userSecret("bmo", "pwd2")}, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []string{lines[0], lines[2]}, strings.Split(string(revoked), "\n"))
+
+	// Changing a password
+	changed, err := buildHtpasswd(apiSecret, htpasswd, []*corev1.Secret{userSecret("bmo", "pwd3")}, logr.Discard())
+	require.NoError(t, err)
+	assert.NotEqual(t, string(revoked), string(changed))
+	assert.True(t, strings.HasPrefix(strings.Split(string(changed), "\n")[1], "bmo:"))
+
+	_, err = buildHtpasswd(apiSecret, nil, []*corev1.Secret{userSecret("bmo", "pwd1"), userSecret("bmo", "pwd2")}, logr.Discard())
+	require.ErrorContains(t, err, "duplicate API user")
+
+	_, err = buildHtpasswd(apiSecret, nil, []*corev1.Secret{userSecret("my-ironic", "pwd1")}, logr.Discard())
+	assert.ErrorContains(t, err, "duplicate API user")
+}
+
+func TestEnsureHtpasswdSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironicObj := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ironic", Namespace: "test-ns", UID: "abc-123"},
+	}
+	apiSecret, err := GenerateSecret(&ironicObj.ObjectMeta, "service", true)
+	require.NoError(t, err)
+	apiSecret.Name = "api"
+	apiData := apiSecret.DeepCopy().Data
+	userSecrets := []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			"username": []byte("monitoring"),
+			"password": []byte("pwd"),
+		},
+	}}
+
+	t.Run("creates and updates the secret", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+		require.NoError(t, EnsureHtpasswdSecret(cctx, ironicObj, apiSecret, userSecrets))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-htpasswd"}, secret))
+		assert.True(t, metav1.IsControlledBy(secret, ironicObj))
+		assert.Equal(t, metal3api.LabelEnvironmentValue, secret.Labels[metal3api.LabelEnvironmentName])
+		assert.Contains(t, string(secret.Data[htpasswdKey]), "\nmonitoring:")
+		resourceVersion := secret.ResourceVersion
+
+		// Nothing changed, the secret is not updated
+		require.NoError(t, EnsureHtpasswdSecret(cctx, ironicObj, apiSecret, userSecrets))
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-htpasswd"}, secret))
+		assert.Equal(t, resourceVersion, secret.ResourceVersion)
+
+		require.NoError(t, EnsureHtpasswdSecret(cctx, ironicObj, apiSecret, nil))
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "test-ns", Name: "test-ironic-htpasswd"}, secret))
+		assert.Equal(t, string(apiSecret.Data[htpasswdKey]), string(secret.Data[htpasswdKey]))
+
+		// The provided secrets are not modified
+		assert.Equal(t, apiData, apiSecret.Data)
+	})
+
+	t.Run("refuses to overwrite an unmanaged secret", func(t *testing.T) {
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ironic-htpasswd", Namespace: "test-ns"},
+			Data:       map[string][]byte{htpasswdKey: []byte("user data")},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ironicObj, existing).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+		err := EnsureHtpasswdSecret(cctx, ironicObj, apiSecret, userSecrets)
+		require.ErrorContains(t, err, "not managed by the operator")
+	})
+}
@@ -34,7 +34,7 @@ func ensureIronicIngress(cctx ControllerContext, ironic *metal3api.Ironic) (Stat
 		}}
 
 		imagesPortNameIngress := imagesPortName
-		if ironic.Spec.TLS.CertificateName != "" {
+		if ServingCertificateName(ironic) != "" {
 			imagesPortNameIngress = imagesTLSPortName
 		}
 
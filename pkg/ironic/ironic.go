@@ -5,6 +5,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -40,6 +41,18 @@ func switchCredentialsSecretName(ironic *metal3api.Ironic) string {
 	return ironic.Name + "-switch-credentials"
 }
 
+func populateIronicDaemonSet(cctx ControllerContext, resources Resources, deploy *appsv1.DaemonSet, template corev1.PodTemplateSpec) {
+	if deploy.Labels == nil {
+		deploy.Labels = make(map[string]string, 2)
+	}
+	deploy.Labels[metal3api.IronicServiceLabel] = resources.Ironic.Name
+	deploy.Labels[metal3api.IronicVersionLabel] = cctx.VersionInfo.InstalledVersion.String()
+
+	matchLabels := map[string]string{metal3api.IronicAppLabel: ironicDeploymentName(resources.Ironic)}
+	deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	mergePodTemplates(&deploy.Spec.Template, template)
+}
+
 func ensureIronicDaemonSet(cctx ControllerContext, resources Resources) (Status, error) {
 	template, err := newIronicPodTemplate(cctx, resources)
 	if err != nil {
@@ -56,15 +69,7 @@ func ensureIronicDaemonSet(cctx ControllerContext, resources Resources) (Status,
 		if deploy.CreationTimestamp.IsZero() {
 			cctx.Logger.Info("creating a new ironic daemon set")
 		}
-		if deploy.Labels == nil {
-			deploy.Labels = make(map[string]string, 2)
-		}
-		deploy.Labels[metal3api.IronicServiceLabel] = resources.Ironic.Name
-		deploy.Labels[metal3api.IronicVersionLabel] = cctx.VersionInfo.InstalledVersion.String()
-
-		matchLabels := map[string]string{metal3api.IronicAppLabel: ironicDeploymentName(resources.Ironic)}
-		deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
-		mergePodTemplates(&deploy.Spec.Template, template)
+		populateIronicDaemonSet(cctx, resources, deploy, template)
 		return controllerutil.SetControllerReference(resources.Ironic, deploy, cctx.Scheme)
 	})
 	if err != nil {
@@ -133,7 +138,7 @@ func ensureIronicService(cctx ControllerContext, ironic *metal3api.Ironic) (Stat
 	imagesPortNameSvc := imagesPortName
 	exposedPort := int32(defaultExposedPort)
 	imagesExposedPort := int32(defaultImageExposedPort)
-	if ironic.Spec.TLS.CertificateName != "" {
+	if ServingCertificateName(ironic) != "" {
 		imagesPortNameSvc = imagesTLSPortName
 		exposedPort = httpsExposedPort
 		imagesExposedPort = httpsImageExposedPort
@@ -210,19 +215,88 @@ func removeIronicDeployment(cctx ControllerContext, ironic *metal3api.Ironic) er
 	return client.IgnoreNotFound(err)
 }
 
+// StopIronic removes the Ironic Deployment or DaemonSet and waits for its pods
+// to terminate, so that nothing uses the database while it is being restored.
+// The workload is created again by EnsureIronic.
+func StopIronic(cctx ControllerContext, ironic *metal3api.Ironic) (Status, error) {
+	key := client.ObjectKey{Namespace: ironic.Namespace, Name: ironicDeploymentName(ironic)}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &appsv1.DaemonSet{}} {
+		err := cctx.Client.Get(cctx.Context, key, obj)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return transientError(err)
+		}
+
+		if obj.GetDeletionTimestamp() == nil {
+			cctx.Logger.Info("stopping ironic", "Name", key.Name)
+			// Foreground deletion keeps the object until all pods are gone
+			err = cctx.Client.Delete(cctx.Context, obj, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return transientError(err)
+			}
+		}
+		return inProgress("waiting for the ironic pods to terminate")
+	}
+
+	return ready()
+}
+
 // EnsureIronic deploys Ironic either as a Deployment or as a DaemonSet.
 func EnsureIronic(cctx ControllerContext, resources Resources) (status Status, err error) {
+	components := Components{}
+	var endpoints *metal3api.IronicEndpoints
+	var backup *metal3api.DatabaseBackupStatus
+	var upgrade *metal3api.UpgradeStatus
+	defer func() {
+		status.Components = components
+		status.Endpoints = endpoints
+		status.DatabaseBackup = backup
+		status.Upgrade = upgrade
+	}()
+
 	if validationErr := resources.Validate(); validationErr != nil {
 		status = Status{Fatal: validationErr}
 		return status, nil //nolint:nilerr // validation errors are reported in status, not as return error
 	}
 
+	// Do not interrupt operations on nodes by restarting Ironic
+	upgradeStatus, err := ensureUpgradeAllowed(cctx, resources)
+	upgrade = upgradeStatus.Upgrade
+	if err != nil || !upgradeStatus.IsReady() {
+		components[metal3api.IronicStatusWorkloadAvailable] = upgradeStatus
+		return upgradeStatus, err
+	}
+
 	if resources.Ironic.Spec.Database != nil {
 		var jobStatus Status
+		jobStatus, err = ensureDatabaseCheckJob(cctx, resources)
+		if err != nil || !jobStatus.IsReady() {
+			components[metal3api.IronicStatusDatabaseMigrated] = jobStatus
+			return jobStatus, err
+		}
+
+		jobStatus, err = ensureDatabaseBackupJob(cctx, resources)
+		backup = jobStatus.DatabaseBackup
+		if err != nil || !jobStatus.IsReady() {
+			components[metal3api.IronicStatusDatabaseMigrated] = jobStatus
+			return jobStatus, err
+		}
+
+		jobStatus, err = ensureUpgradeSteps(cctx, resources, upgrade)
+		if err != nil || !jobStatus.IsReady() {
+			components[metal3api.IronicStatusDatabaseMigrated] = jobStatus
+			return jobStatus, err
+		}
+
 		jobStatus, err = ensureIronicUpgradeJob(cctx, resources, preUpgrade)
 		if err != nil || !jobStatus.IsReady() {
+			components[metal3api.IronicStatusDatabaseMigrated] = jobStatus
 			return jobStatus, err
 		}
+	} else {
+		components[metal3api.IronicStatusDatabaseMigrated], _ = notRequired("no external database is used")
 	}
 
 	if resources.Ironic.Spec.HighAvailability {
@@ -239,6 +313,7 @@ func EnsureIronic(cctx ControllerContext, resources Resources) (status Status, e
 		status, err = ensureIronicDeployment(cctx, resources)
 	}
 
+	components[metal3api.IronicStatusWorkloadAvailable] = status
 	if err != nil || status.IsError() {
 		return status, err
 	}
@@ -246,20 +321,32 @@ func EnsureIronic(cctx ControllerContext, resources Resources) (status Status, e
 	// Let the service be created while Ironic is being deployed, but do
 	// not report overall success until both are done.
 	serviceStatus, serviceErr := ensureIronicService(cctx, resources.Ironic)
+	components[metal3api.IronicStatusServiceReady] = serviceStatus
 	if serviceErr != nil || !serviceStatus.IsReady() {
 		return serviceStatus, serviceErr
 	}
 
 	if resources.Ironic.Spec.Networking.Ingress != nil {
 		ingressStatus, ingressErr := ensureIronicIngress(cctx, resources.Ironic)
+		components[metal3api.IronicStatusIngressReady] = ingressStatus
 		if ingressErr != nil || !ingressStatus.IsReady() {
 			return ingressStatus, ingressErr
 		}
+	} else {
+		components[metal3api.IronicStatusIngressReady], _ = notRequired("ingress is not configured")
+	}
+
+	endpoints = buildStatusEndpoints(cctx, resources)
+
+	cloudsStatus, err := ensureCloudsYAMLSecret(cctx, resources, endpoints)
+	if err != nil || !cloudsStatus.IsReady() {
+		return cloudsStatus, err
 	}
 
 	if resources.Ironic.Spec.Database != nil {
 		var jobStatus Status
 		jobStatus, err = ensureIronicUpgradeJob(cctx, resources, postUpgrade)
+		components[metal3api.IronicStatusDatabaseMigrated] = jobStatus
 		if err != nil || !jobStatus.IsReady() {
 			return jobStatus, err
 		}
@@ -267,14 +354,25 @@ func EnsureIronic(cctx ControllerContext, resources Resources) (status Status, e
 
 	// Ensure ServiceMonitor is created or removed based on PrometheusExporter configuration
 	smStatus, err := ensureServiceMonitor(cctx, resources.Ironic)
+	components[metal3api.IronicStatusMonitoringReady] = smStatus
 	if err != nil || !smStatus.IsReady() {
 		return smStatus, err
 	}
 
+	// Only verify client certificates once the pods are up-to-date.
+	if status.IsReady() {
+		clientCertStatus, clientCertErr := verifyClientCertificates(cctx, resources)
+		components[metal3api.IronicStatusClientCertificatesVerified] = clientCertStatus
+		if clientCertErr != nil || !clientCertStatus.IsReady() {
+			return clientCertStatus, clientCertErr
+		}
+	}
+
 	return status, err
 }
 
 // RemoveIronic removes all bits of the Ironic deployment.
-func RemoveIronic(_ ControllerContext, _ *metal3api.Ironic) error {
+func RemoveIronic(_ ControllerContext, ironic *metal3api.Ironic) error {
+	forgetIronicTransport(ironic)
 	return nil // rely on ownership-based clean up
 }
@@ -0,0 +1,114 @@
+package ironic
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// How many lines of the job output to keep in the status.
+	jobOutputMaxLines = 5
+	// Events are limited to 1 KiB, leave space for the rest of the message.
+	jobOutputMaxLength = 512
+	// Only the last lines are used, don't fetch huge logs.
+	jobOutputLimitBytes int64 = 16 * 1024
+)
+
+// JobFailedError is reported when one of the jobs run by the operator has
+// failed.
+type JobFailedError struct {
+	// JobName is the name of the failed job.
+	JobName string
+	// JobType is a human-readable description of the job.
+	JobType string
+	// Reason is the message from the Failed condition of the job.
+	Reason string
+	// Output is the trimmed termination message or log of the last failed
+	// pod, empty if not available.
+	Output string
+}
+
+func (err *JobFailedError) Error() string {
+	message := fmt.Sprintf("%s job failed: %s", err.JobType, err.Reason)
+	if err.Output != "" {
+		message = fmt.Sprintf("%s; output: %s", message, err.Output)
+	}
+	return message
+}
+
+// failedContainer is a terminated container of a pod created by a job.
+type failedContainer struct {
+	podName       string
+	containerName string
+	state         *corev1.ContainerStateTerminated
+}
+
+// latestFailedContainer returns the most recently failed container among the
+// pods of the job, nil if there are none. If containerName is not empty,
+// other containers are ignored.
+func latestFailedContainer(cctx ControllerContext, job *batchv1.Job, containerName string) (*failedContainer, error) {
+	pods, err := cctx.KubeClient.CoreV1().Pods(job.Namespace).List(cctx.Context, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", batchv1.JobNameLabel, job.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *failedContainer
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			terminated := cs.State.Terminated
+			if (containerName != "" && cs.Name != containerName) || terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if latest == nil || latest.state.FinishedAt.Before(&terminated.FinishedAt) {
+				latest = &failedContainer{podName: pod.Name, containerName: cs.Name, state: terminated}
+			}
+		}
+	}
+	return latest, nil
+}
+
+// jobFailureOutput returns the trimmed termination message of the last failed
+// container of the job or, if there is none, the tail of its log.
+func jobFailureOutput(cctx ControllerContext, job *batchv1.Job) (string, error) {
+	failed, err := latestFailedContainer(cctx, job, "")
+	if err != nil || failed == nil {
+		return "", err
+	}
+
+	if output := trimJobOutput(failed.state.Message); output != "" {
+		return output, nil
+	}
+
+	logs, err := cctx.KubeClient.CoreV1().Pods(job.Namespace).GetLogs(failed.podName, &corev1.PodLogOptions{
+		Container:  failed.containerName,
+		TailLines:  ptr.To(int64(jobOutputMaxLines)),
+		LimitBytes: ptr.To(jobOutputLimitBytes),
+	}).DoRaw(cctx.Context)
+	if err != nil {
+		return "", err
+	}
+	return trimJobOutput(string(logs)), nil
+}
+
+// trimJobOutput keeps the last lines of the output that fit into a condition
+// message or an event.
+func trimJobOutput(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) > jobOutputMaxLines {
+		lines = lines[len(lines)-jobOutputMaxLines:]
+	}
+	output = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	if len(output) > jobOutputMaxLength {
+		// Cutting may leave a partial UTF-8 sequence at the start
+		output = "..." + strings.ToValidUTF8(output[len(output)-jobOutputMaxLength:], "")
+	}
+	return output
+}
@@ -0,0 +1,118 @@
+package ironic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetJobStatusFailed(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, exitCode int32, message string, finishedAt time.Time) runtime.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels:    map[string]string{batchv1.JobNameLabel: "test-pre-37.0-to-38.0"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "upgrade",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:   exitCode,
+								Message:    message,
+								FinishedAt: metav1.NewTime(finishedAt),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Pods []runtime.Object
+
+		ExpectedOutput string
+	}{
+		{
+			Scenario: "termination message",
+			Pods: []runtime.Object{
+				pod("pod-1", 1, "older failure", now.Add(-time.Minute)),
+				pod("pod-2", 1, "Traceback (most recent call last):\n  ...\noslo_db.exception.DBError: table already exists\n", now),
+			},
+			ExpectedOutput: "Traceback (most recent call last):\n  ...\noslo_db.exception.DBError: table already exists",
+		},
+		{
+			Scenario:       "log tail",
+			Pods:           []runtime.Object{pod("pod-1", 1, "", now)},
+			ExpectedOutput: "fake logs",
+		},
+		{
+			Scenario: "pods are gone",
+		},
+		{
+			Scenario: "no failed containers",
+			Pods:     []runtime.Object{pod("pod-1", 0, "done", now)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			cctx := ControllerContext{
+				Context:    t.Context(),
+				KubeClient: kubefake.NewClientset(tc.Pods...),
+				Logger:     logr.Discard(),
+			}
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pre-37.0-to-38.0", Namespace: "test"},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+					},
+				},
+			}
+
+			status, err := getJobStatus(cctx, job, "pre-upgrade")
+			require.NoError(t, err)
+			require.Error(t, status.Fatal)
+
+			var jobErr *JobFailedError
+			require.ErrorAs(t, status.Fatal, &jobErr)
+			assert.Equal(t, "test-pre-37.0-to-38.0", jobErr.JobName)
+			assert.Equal(t, tc.ExpectedOutput, jobErr.Output)
+			if tc.ExpectedOutput != "" {
+				assert.Equal(t, "pre-upgrade job failed: Job has reached the specified backoff limit; output: "+tc.ExpectedOutput, status.Fatal.Error())
+			} else {
+				assert.Equal(t, "pre-upgrade job failed: Job has reached the specified backoff limit", status.Fatal.Error())
+			}
+		})
+	}
+}
+
+func TestTrimJobOutput(t *testing.T) {
+	assert.Empty(t, trimJobOutput(" \n"))
+	assert.Equal(t, "3\n4\n5\n6\n7", trimJobOutput("1\n2\n3\n4\n5\n6\n7\n"))
+
+	long := strings.Repeat("x", 2*jobOutputMaxLength)
+	assert.Equal(t, "..."+strings.Repeat("x", jobOutputMaxLength), trimJobOutput(long))
+
+	// Multi-byte characters are not cut in half
+	trimmed := trimJobOutput(strings.Repeat("ü", jobOutputMaxLength))
+	assert.True(t, strings.HasPrefix(trimmed, "..."))
+	assert.LessOrEqual(t, len(trimmed), jobOutputMaxLength+3)
+	assert.NotContains(t, trimmed, "�")
+}
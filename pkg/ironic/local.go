@@ -77,13 +77,14 @@ func ParseLocalIronic(inputFile string, scheme *runtime.Scheme) (*Resources, err
 	// Get effective CA references
 	bmcCARef := GetBMCCA(&resources.Ironic.Spec.TLS)
 	trustedCARef := GetTrustedCA(&resources.Ironic.Spec.TLS)
+	clientCARef := resources.Ironic.Spec.TLS.ClientCA
 
 	for _, secretObj := range secrets {
 		// Determine secret type based on name patterns or labels
 		switch secretObj.Name {
 		case resources.Ironic.Spec.APICredentialsName:
 			resources.APISecret = secretObj
-		case resources.Ironic.Spec.TLS.CertificateName:
+		case ServingCertificateName(resources.Ironic):
 			resources.TLSSecret = secretObj
 		default:
 			matched := false
@@ -95,6 +96,24 @@ func ParseLocalIronic(inputFile string, scheme *runtime.Scheme) (*Resources, err
 				resources.TrustedCASecret = secretObj
 				matched = true
 			}
+			if clientCARef != nil && clientCARef.Kind == metal3api.ResourceKindSecret && clientCARef.Name == secretObj.Name {
+				resources.ClientCASecret = secretObj
+				matched = true
+			}
+			if db := resources.Ironic.Spec.Database; db != nil {
+				if db.CredentialsName == secretObj.Name {
+					resources.DatabaseSecret = secretObj
+					matched = true
+				}
+				if db.TLSCertificateName == secretObj.Name {
+					resources.DatabaseTLSSecret = secretObj
+					matched = true
+				}
+				if db.ClientCertificateName == secretObj.Name {
+					resources.DatabaseClientSecret = secretObj
+					matched = true
+				}
+			}
 			if !matched {
 				return nil, fmt.Errorf("secret %s does not belong to the Ironic resource", secretObj.Name)
 			}
@@ -112,6 +131,10 @@ func ParseLocalIronic(inputFile string, scheme *runtime.Scheme) (*Resources, err
 			resources.TrustedCAConfigMap = configMapObj
 			matched = true
 		}
+		if clientCARef != nil && clientCARef.Kind == metal3api.ResourceKindConfigMap && clientCARef.Name == configMapObj.Name {
+			resources.ClientCAConfigMap = configMapObj
+			matched = true
+		}
 		if !matched {
 			return nil, fmt.Errorf("configmap %s does not belong to the Ironic resource", configMapObj.Name)
 		}
@@ -138,6 +161,10 @@ func checkAndUpdateIronicForLocal(ironicSpec *metal3api.IronicSpec) error {
 		return errors.New("highAvailability does not make sense for local installations")
 	}
 
+	if len(ironicSpec.TLS.AdditionalBMCCAs) > 0 || len(ironicSpec.TLS.AdditionalTrustedCAs) > 0 {
+		return errors.New("additional CA certificates are not supported for local installations")
+	}
+
 	net := &ironicSpec.Networking
 
 	// It's not possible to use hostIP on podman, but localhost is a reasonable default for this case
@@ -151,18 +178,7 @@ func checkAndUpdateIronicForLocal(ironicSpec *metal3api.IronicSpec) error {
 	}
 
 	// There is no Kubernetes API to apply defaults
-	if net.APIPort == 0 {
-		net.APIPort = 6385
-	}
-	if net.ImageServerPort == 0 {
-		net.ImageServerPort = 6180
-	}
-	if net.ImageServerTLSPort == 0 {
-		net.ImageServerTLSPort = 6183
-	}
-	if net.RPCPort == 0 {
-		net.RPCPort = 6189
-	}
+	SetDefaults(ironicSpec)
 
 	return nil
 }
@@ -0,0 +1,143 @@
+package ironic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// parseSchedule parses a cron schedule, using UTC unless a time zone is
+// provided explicitly.
+func parseSchedule(schedule string) (cron.Schedule, error) {
+	if !strings.HasPrefix(schedule, "CRON_TZ=") && !strings.HasPrefix(schedule, "TZ=") {
+		schedule = "CRON_TZ=UTC " + schedule
+	}
+	return cron.ParseStandard(schedule)
+}
+
+// inMaintenanceWindow checks whether one of the windows covers the given time.
+func inMaintenanceWindow(window *metal3api.MaintenanceWindow, now time.Time) (bool, error) {
+	schedule, err := parseSchedule(window.Schedule)
+	if err != nil {
+		return false, err
+	}
+	// The first window starting after (now - duration) is still open if it
+	// has already started.
+	start := schedule.Next(now.Add(-window.Duration.Duration))
+	return !start.After(now), nil
+}
+
+// NextMaintenanceWindow returns the start of the next maintenance window,
+// zero if no window is configured.
+func NextMaintenanceWindow(ironic *metal3api.Ironic, now time.Time) (time.Time, error) {
+	window := ironic.Spec.MaintenanceWindow
+	if window == nil {
+		return time.Time{}, nil
+	}
+
+	schedule, err := parseSchedule(window.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(now), nil
+}
+
+// IsPaused checks whether reconciliation of the workloads is paused either
+// through the spec or through the annotation.
+func IsPaused(ironic *metal3api.Ironic) bool {
+	_, hasAnnotation := ironic.Annotations[metal3api.IronicPausedAnnotation]
+	return ironic.Spec.Paused || hasAnnotation
+}
+
+// ChangesHeldReason returns the reason why changes to the Ironic workload
+// cannot be applied at the given time, empty if they can.
+func ChangesHeldReason(ironic *metal3api.Ironic, now time.Time) (string, error) {
+	if IsPaused(ironic) {
+		return metal3api.IronicReasonPaused, nil
+	}
+
+	// The initial deployment is never delayed
+	window := ironic.Spec.MaintenanceWindow
+	if window == nil || ironic.Status.InstalledVersion == "" {
+		return "", nil
+	}
+
+	// Do not leave the database migrated while the old version is running
+	// just because the window has closed.
+	if upgrade := ironic.Status.Upgrade; upgrade != nil && upgrade.StartTime != nil && upgrade.ToVersion != ironic.Status.InstalledVersion {
+		return "", nil
+	}
+
+	active, err := inMaintenanceWindow(window, now)
+	if err != nil || active {
+		return "", err
+	}
+	return metal3api.IronicReasonOutsideMaintenanceWindow, nil
+}
+
+// PendingWorkloadChanges returns a human-readable list of changes that
+// EnsureIronic would apply to the Ironic workload, as well as the status of
+// the workload as it currently exists.
+func PendingWorkloadChanges(cctx ControllerContext, resources Resources) ([]string, Status, error) {
+	ironic := resources.Ironic
+	var changes []string
+
+	requestedVersion := cctx.VersionInfo.InstalledVersion.String()
+	if installed := ironic.Status.InstalledVersion; installed != "" && installed != requestedVersion {
+		changes = append(changes, fmt.Sprintf("upgrade from version %s to %s", installed, requestedVersion))
+	}
+
+	template, err := newIronicPodTemplate(cctx, resources)
+	if err != nil {
+		return nil, Status{}, err
+	}
+
+	name := ironicDeploymentName(ironic)
+	key := client.ObjectKey{Namespace: ironic.Namespace, Name: name}
+	var status Status
+	if ironic.Spec.HighAvailability {
+		daemonSet := &appsv1.DaemonSet{}
+		err = cctx.Client.Get(cctx.Context, key, daemonSet)
+		if k8serrors.IsNotFound(err) {
+			changes = append(changes, "creation of daemon set "+name)
+			status, err = inProgress("ironic daemon set does not exist yet")
+			return changes, status, err
+		} else if err != nil {
+			return nil, Status{}, err
+		}
+
+		desired := daemonSet.DeepCopy()
+		populateIronicDaemonSet(cctx, resources, desired, template)
+		if !apiequality.Semantic.DeepEqual(daemonSet, desired) {
+			changes = append(changes, "update of daemon set "+name)
+		}
+		status, err = getDaemonSetStatus(cctx, daemonSet)
+	} else {
+		deployment := &appsv1.Deployment{}
+		err = cctx.Client.Get(cctx.Context, key, deployment)
+		if k8serrors.IsNotFound(err) {
+			changes = append(changes, "creation of deployment "+name)
+			status, err = inProgress("ironic deployment does not exist yet")
+			return changes, status, err
+		} else if err != nil {
+			return nil, Status{}, err
+		}
+
+		desired := deployment.DeepCopy()
+		populateIronicDeployment(cctx, resources, desired, template)
+		if !apiequality.Semantic.DeepEqual(deployment, desired) {
+			changes = append(changes, "update of deployment "+name)
+		}
+		status, err = getDeploymentStatus(cctx, deployment)
+	}
+
+	return changes, status, err
+}
@@ -0,0 +1,265 @@
+package ironic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestChangesHeldReason(t *testing.T) {
+	// A Saturday
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+	window := func(schedule string) *metal3api.MaintenanceWindow {
+		return &metal3api.MaintenanceWindow{
+			Schedule: schedule,
+			Duration: metav1.Duration{Duration: 2 * time.Hour},
+		}
+	}
+
+	testCases := []struct {
+		Scenario       string
+		Spec           metal3api.IronicSpec
+		Annotations    map[string]string
+		Status         metal3api.IronicStatus
+		ExpectedReason string
+		ExpectedNext   time.Time
+	}{
+		{
+			Scenario: "no restrictions",
+		},
+		{
+			Scenario:       "paused",
+			Spec:           metal3api.IronicSpec{Paused: true},
+			ExpectedReason: metal3api.IronicReasonPaused,
+		},
+		{
+			Scenario:       "paused through annotation",
+			Annotations:    map[string]string{metal3api.IronicPausedAnnotation: ""},
+			ExpectedReason: metal3api.IronicReasonPaused,
+		},
+		{
+			Scenario:     "inside window",
+			Spec:         metal3api.IronicSpec{MaintenanceWindow: window("0 2 * * 6")},
+			ExpectedNext: time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario:     "window starts now",
+			Spec:         metal3api.IronicSpec{MaintenanceWindow: window("0 3 * * 6")},
+			ExpectedNext: time.Date(2026, 10, 24, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario:       "window has ended",
+			Status:         metal3api.IronicStatus{InstalledVersion: "38.0"},
+			Spec:           metal3api.IronicSpec{MaintenanceWindow: window("0 1 * * 6")},
+			ExpectedReason: metal3api.IronicReasonOutsideMaintenanceWindow,
+			ExpectedNext:   time.Date(2026, 10, 24, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario:       "window has not started",
+			Status:         metal3api.IronicStatus{InstalledVersion: "38.0"},
+			Spec:           metal3api.IronicSpec{MaintenanceWindow: window("0 4 * * *")},
+			ExpectedReason: metal3api.IronicReasonOutsideMaintenanceWindow,
+			ExpectedNext:   time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario:     "initial deployment outside window",
+			Spec:         metal3api.IronicSpec{MaintenanceWindow: window("0 4 * * *")},
+			ExpectedNext: time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario:     "window in a different time zone",
+			Spec:         metal3api.IronicSpec{MaintenanceWindow: window("CRON_TZ=Europe/Prague 0 4 * * *")},
+			ExpectedNext: time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario: "upgrade in progress",
+			Spec:     metal3api.IronicSpec{MaintenanceWindow: window("0 4 * * *")},
+			Status: metal3api.IronicStatus{
+				InstalledVersion: "37.0",
+				Upgrade: &metal3api.UpgradeStatus{
+					FromVersion: "37.0",
+					ToVersion:   "38.0",
+					StartTime:   ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+				},
+			},
+			ExpectedNext: time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			Scenario: "upgrade finished",
+			Spec:     metal3api.IronicSpec{MaintenanceWindow: window("0 4 * * *")},
+			Status: metal3api.IronicStatus{
+				InstalledVersion: "38.0",
+				Upgrade: &metal3api.UpgradeStatus{
+					FromVersion: "37.0",
+					ToVersion:   "38.0",
+					StartTime:   ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+				},
+			},
+			ExpectedReason: metal3api.IronicReasonOutsideMaintenanceWindow,
+			ExpectedNext:   time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Annotations: tc.Annotations},
+				Spec:       tc.Spec,
+				Status:     tc.Status,
+			}
+
+			reason, err := ChangesHeldReason(ironic, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReason, reason)
+
+			next, err := NextMaintenanceWindow(ironic, now)
+			require.NoError(t, err)
+			assert.True(t, tc.ExpectedNext.Equal(next), "expected %s, got %s", tc.ExpectedNext, next)
+		})
+	}
+}
+
+func TestPendingWorkloadChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"htpasswd": []byte("abcd"),
+		},
+	}
+	newIronic := func(image string) *metal3api.Ironic {
+		return &metal3api.Ironic{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: metal3api.IronicSpec{
+				Images: metal3api.Images{Ironic: image},
+			},
+			Status: metal3api.IronicStatus{InstalledVersion: "latest"},
+		}
+	}
+	newContext := func(t *testing.T, ironic *metal3api.Ironic, objects ...runtime.Object) ControllerContext {
+		t.Helper()
+		cctx := ControllerContext{
+			Context: t.Context(),
+			Client:  fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+			Scheme:  scheme,
+			Logger:  logr.Discard(),
+		}
+		version, err := cctx.VersionInfo.WithIronicOverrides(ironic)
+		require.NoError(t, err)
+		cctx.VersionInfo = version
+		return cctx
+	}
+
+	// The deployment as created by the current configuration
+	deployedIronic := newIronic("myorg/myironic:old")
+	cctx := newContext(t, deployedIronic)
+	deployedResources := Resources{Ironic: deployedIronic, APISecret: secret}
+	template, err := newIronicPodTemplate(cctx, deployedResources)
+	require.NoError(t, err)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test"},
+	}
+	populateIronicDeployment(cctx, deployedResources, deployment, template)
+
+	t.Run("no changes", func(t *testing.T) {
+		ironic := newIronic("myorg/myironic:old")
+		cctx := newContext(t, ironic, deployment.DeepCopy())
+
+		changes, status, err := PendingWorkloadChanges(cctx, Resources{Ironic: ironic, APISecret: secret})
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.False(t, status.IsError())
+	})
+
+	t.Run("new image", func(t *testing.T) {
+		ironic := newIronic("myorg/myironic:new")
+		cctx := newContext(t, ironic, deployment.DeepCopy())
+
+		changes, _, err := PendingWorkloadChanges(cctx, Resources{Ironic: ironic, APISecret: secret})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"update of deployment test-service"}, changes)
+	})
+
+	t.Run("new version", func(t *testing.T) {
+		ironic := newIronic("myorg/myironic:old")
+		ironic.Spec.Version = "38.0"
+		ironic.Status.InstalledVersion = "37.0"
+		cctx := newContext(t, ironic, deployment.DeepCopy())
+
+		changes, _, err := PendingWorkloadChanges(cctx, Resources{Ironic: ironic, APISecret: secret})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"upgrade from version 37.0 to 38.0", "update of deployment test-service"}, changes)
+	})
+
+	t.Run("not deployed", func(t *testing.T) {
+		ironic := newIronic("myorg/myironic:old")
+		cctx := newContext(t, ironic)
+
+		changes, status, err := PendingWorkloadChanges(cctx, Resources{Ironic: ironic, APISecret: secret})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"creation of deployment test-service"}, changes)
+		assert.False(t, status.IsReady())
+	})
+
+	t.Run("switching to high availability", func(t *testing.T) {
+		ironic := newIronic("myorg/myironic:old")
+		ironic.Spec.HighAvailability = true
+		cctx := newContext(t, ironic, deployment.DeepCopy())
+
+		changes, _, err := PendingWorkloadChanges(cctx, Resources{Ironic: ironic, APISecret: secret})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"creation of daemon set test-service"}, changes)
+	})
+}
+
+func TestStopIronic(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec:       metal3api.IronicSpec{HighAvailability: true},
+	}
+	// The finalizer stands in for the pods that are still terminating
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test", Finalizers: []string{"test"}},
+	}
+	cctx := ControllerContext{
+		Context: t.Context(),
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(daemonSet).Build(),
+		Scheme:  scheme,
+		Logger:  logr.Discard(),
+	}
+
+	status, err := StopIronic(cctx, ironic)
+	require.NoError(t, err)
+	assert.False(t, status.IsReady())
+	assert.Equal(t, "waiting for the ironic pods to terminate", status.Message)
+
+	require.NoError(t, cctx.Client.Get(t.Context(), client.ObjectKeyFromObject(daemonSet), daemonSet))
+	assert.NotNil(t, daemonSet.DeletionTimestamp)
+
+	status, err = StopIronic(cctx, ironic)
+	require.NoError(t, err)
+	assert.False(t, status.IsReady())
+
+	daemonSet.Finalizers = nil
+	require.NoError(t, cctx.Client.Update(t.Context(), daemonSet))
+
+	status, err = StopIronic(cctx, ironic)
+	require.NoError(t, err)
+	assert.True(t, status.IsReady())
+}
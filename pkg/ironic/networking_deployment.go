@@ -78,19 +78,7 @@ func buildNetworkingContainerEnv(resources Resources) []corev1.EnvVar {
 		Value: defaultSwitchDriver,
 	})
 
-	envVars = append(envVars,
-		corev1.EnvVar{
-			Name: "IRONIC_HTPASSWD",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.APISecret.Name,
-					},
-					Key: htpasswdKey,
-				},
-			},
-		},
-	)
+	envVars = append(envVars, htpasswdEnvVar())
 
 	// Get Ironic IP
 	if resources.Ironic.Spec.Networking.IPAddress != "" {
@@ -154,6 +142,10 @@ func buildNetworkingVolumesAndMounts(resources Resources) (volumes []corev1.Volu
 		MountPath: authDir + "/ironic-rpc",
 	})
 
+	htpasswdVolume, htpasswdMount := htpasswdVolumeAndMount(resources.Ironic)
+	volumes = append(volumes, htpasswdVolume)
+	mounts = append(mounts, htpasswdMount)
+
 	// Switch config (always required for networking service)
 	volumes = append(volumes, corev1.Volume{
 		Name: "switch-config",
@@ -296,10 +288,7 @@ func BuildNetworkingService(ironic *metal3api.Ironic) *corev1.Service {
 }
 
 func networkingServiceEndpoint(ironic *metal3api.Ironic, domain string) string {
-	if domain != "" && domain[0] != '.' {
-		domain = "." + domain
-	}
-	return fmt.Sprintf("%s.%s.svc%s", NetworkingServiceName(ironic), ironic.Namespace, domain)
+	return serviceHost(NetworkingServiceName(ironic), ironic.Namespace, domain)
 }
 
 // EnsureIronicNetworking manages the networking service state.
@@ -313,7 +302,7 @@ func EnsureIronicNetworking(cctx ControllerContext, resources Resources) (Status
 		if err := removeNetworkingResources(cctx, ironic); err != nil {
 			return transientError(err)
 		}
-		return ready()
+		return notRequired("networking service is not enabled")
 	}
 
 	if err := ensureNetworkingDeployment(cctx, resources); err != nil {
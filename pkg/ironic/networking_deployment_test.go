@@ -675,7 +675,7 @@ func TestBuildNetworkingContainerEnv(t *testing.T) {
 			NotExpectedEnvs: []string{"IRONIC_INSECURE"},
 		},
 		{
-			Scenario: "API secret present sets IRONIC_HTPASSWD from secret ref",
+			Scenario: "API secret present sets IRONIC_HTPASSWD_FILE",
 			Ironic: &metal3api.Ironic{
 				Spec: metal3api.IronicSpec{
 					NetworkingService: &metal3api.NetworkingService{
@@ -730,14 +730,9 @@ func TestBuildNetworkingContainerEnv(t *testing.T) {
 				assert.Equal(t, "status.podIP", rpcHostEnv.ValueFrom.FieldRef.FieldPath)
 			}
 
-			// Check IRONIC_HTPASSWD is set from a secret ref
+			// Check IRONIC_HTPASSWD_FILE points to the mounted htpasswd
 			if tc.ExpectHTPasswdFromRef {
-				htpasswdEnv, ok := envVarList["IRONIC_HTPASSWD"]
-				require.True(t, ok, "Expected IRONIC_HTPASSWD env var to be present")
-				require.NotNil(t, htpasswdEnv.ValueFrom, "Expected IRONIC_HTPASSWD to have ValueFrom")
-				require.NotNil(t, htpasswdEnv.ValueFrom.SecretKeyRef, "Expected IRONIC_HTPASSWD to have SecretKeyRef")
-				assert.Equal(t, tc.APISecret.Name, htpasswdEnv.ValueFrom.SecretKeyRef.Name)
-				assert.Equal(t, "htpasswd", htpasswdEnv.ValueFrom.SecretKeyRef.Key)
+				assert.Equal(t, "/auth/htpasswd/htpasswd", envMap["IRONIC_HTPASSWD_FILE"])
 			}
 		})
 	}
@@ -0,0 +1,343 @@
+package ironic
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/httpbasic"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+const (
+	defaultNodeOperationsTimeout = time.Hour
+	ironicAPITimeout             = 30 * time.Second
+	// The first version that reports the servicing states.
+	ironicAPIMicroversion = "1.87"
+	// Do not make the condition message too long.
+	maxReportedNodes = 5
+)
+
+// transientProvisionStates are the provision states in which restarting
+// Ironic interrupts an ongoing operation.
+var transientProvisionStates = []nodes.ProvisionState{
+	nodes.Adopting,
+	nodes.Cleaning,
+	nodes.CleanWait,
+	nodes.Deleting,
+	nodes.Deploying,
+	nodes.DeployWait,
+	nodes.Inspecting,
+	nodes.InspectWait,
+	nodes.Rescuing,
+	nodes.RescueWait,
+	nodes.Servicing,
+	nodes.ServiceWait,
+	nodes.Unrescuing,
+	nodes.Verifying,
+}
+
+// ironicTransport is an HTTP transport for the Ironic API of one Ironic
+// object together with the TLS settings it has been created for.
+type ironicTransport struct {
+	key       string
+	transport *http.Transport
+}
+
+// ironicTransports keeps one transport per Ironic object so that connections
+// are reused across reconciliations.
+var (
+	ironicTransports      = map[types.NamespacedName]*ironicTransport{}
+	ironicTransportsMutex sync.Mutex
+)
+
+// tlsServerName returns the name to verify the Ironic certificate against:
+// the service host if the certificate covers it, otherwise the first name or
+// IP address from the certificate.
+func tlsServerName(certPEM []byte, host string) string {
+	cert, err := parseCertificate(certPEM)
+	if err != nil || cert.VerifyHostname(host) == nil {
+		return host
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	if len(cert.IPAddresses) > 0 {
+		return cert.IPAddresses[0].String()
+	}
+	return host
+}
+
+// getIronicTransport returns the cached transport for the Ironic object,
+// replacing it when the TLS settings have changed.
+func getIronicTransport(ironic *metal3api.Ironic, caCert []byte, serverName string) *http.Transport {
+	key := serverName + "\n" + string(caCert)
+	name := types.NamespacedName{Namespace: ironic.Namespace, Name: ironic.Name}
+
+	ironicTransportsMutex.Lock()
+	defer ironicTransportsMutex.Unlock()
+	if cached := ironicTransports[name]; cached != nil {
+		if cached.key == key {
+			return cached.transport
+		}
+		cached.transport.CloseIdleConnections()
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		certPool = x509.NewCertPool()
+	}
+	certPool.AppendCertsFromPEM(caCert)
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs:    certPool,
+			ServerName: serverName,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+	ironicTransports[name] = &ironicTransport{key: key, transport: transport}
+	return transport
+}
+
+// forgetIronicTransport closes the cached transport of a removed Ironic.
+func forgetIronicTransport(ironic *metal3api.Ironic) {
+	name := types.NamespacedName{Namespace: ironic.Namespace, Name: ironic.Name}
+
+	ironicTransportsMutex.Lock()
+	defer ironicTransportsMutex.Unlock()
+	if cached := ironicTransports[name]; cached != nil {
+		cached.transport.CloseIdleConnections()
+		delete(ironicTransports, name)
+	}
+}
+
+// newIronicClient creates a client for the Ironic API using the in-cluster
+// service endpoint. A variable so that unit tests can replace it.
+var newIronicClient = func(cctx ControllerContext, resources Resources) (*gophercloud.ServiceClient, error) {
+	if resources.APISecret == nil {
+		return nil, errors.New("API credentials are not available")
+	}
+
+	serviceClient, err := httpbasic.NewBareMetalHTTPBasic(httpbasic.EndpointOpts{
+		IronicEndpoint:     buildStatusEndpoints(cctx, resources).APIURLs[0] + "/v1/",
+		IronicUser:         string(resources.APISecret.Data[corev1.BasicAuthUsernameKey]),
+		IronicUserPassword: string(resources.APISecret.Data[corev1.BasicAuthPasswordKey]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serviceClient.Microversion = ironicAPIMicroversion
+	serviceClient.HTTPClient.Timeout = ironicAPITimeout
+	if resources.TLSSecret != nil {
+		serviceClient.HTTPClient.Transport = ironicAPITransport(cctx, resources)
+	}
+	return serviceClient, nil
+}
+
+// ironicAPITransport returns the transport to use for the Ironic API when
+// TLS is enabled. It does not present a client certificate.
+func ironicAPITransport(cctx ControllerContext, resources Resources) *http.Transport {
+	caCert, hasCACert := resources.TLSSecret.Data["ca.crt"]
+	if !hasCACert {
+		// Self-signed certificate
+		caCert = resources.TLSSecret.Data[corev1.TLSCertKey]
+	}
+	// User-provided certificates do not always cover the service host
+	serverName := tlsServerName(resources.TLSSecret.Data[corev1.TLSCertKey], ironicServiceHost(resources.Ironic, cctx.Domain))
+	return getIronicTransport(resources.Ironic, caCert, serverName)
+}
+
+// listBusyNodes returns the nodes in transient provision states.
+func listBusyNodes(cctx ControllerContext, resources Resources) ([]nodes.Node, error) {
+	serviceClient, err := newIronicClient(cctx, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []nodes.Node
+	for _, state := range transientProvisionStates {
+		pages, err := nodes.List(serviceClient, nodes.ListOpts{
+			ProvisionState: state,
+			Fields:         []string{"uuid", "name", "provision_state"},
+		}).AllPages(cctx.Context)
+		if err != nil {
+			return nil, err
+		}
+		stateNodes, err := nodes.ExtractNodes(pages)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stateNodes...)
+	}
+	return result, nil
+}
+
+func describeNodes(busyNodes []nodes.Node) string {
+	descriptions := make([]string, 0, maxReportedNodes)
+	for i, node := range busyNodes {
+		if i == maxReportedNodes {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(busyNodes)-maxReportedNodes))
+			break
+		}
+		name := node.Name
+		if name == "" {
+			name = node.UUID
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", name, node.ProvisionState))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// runningIronicImage returns the Ironic image of the existing Deployment or
+// DaemonSet, empty if Ironic is not running yet.
+func runningIronicImage(cctx ControllerContext, ironic *metal3api.Ironic) (string, error) {
+	key := client.ObjectKey{Namespace: ironic.Namespace, Name: ironicDeploymentName(ironic)}
+	var template *corev1.PodTemplateSpec
+	if ironic.Spec.HighAvailability {
+		daemonSet := &appsv1.DaemonSet{}
+		if err := cctx.Client.Get(cctx.Context, key, daemonSet); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		template = &daemonSet.Spec.Template
+	} else {
+		deployment := &appsv1.Deployment{}
+		if err := cctx.Client.Get(cctx.Context, key, deployment); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		template = &deployment.Spec.Template
+	}
+
+	for _, container := range template.Spec.Containers {
+		if container.Name == "ironic" {
+			return container.Image, nil
+		}
+	}
+	return "", nil
+}
+
+// NodeOperationsTimeout returns how long changes that restart Ironic may be
+// delayed while nodes are in transient provision states.
+func NodeOperationsTimeout(ironic *metal3api.Ironic) time.Duration {
+	if policy := ironic.Spec.UpgradePolicy; policy != nil && policy.NodeOperationsTimeout != nil {
+		return policy.NodeOperationsTimeout.Duration
+	}
+	return defaultNodeOperationsTimeout
+}
+
+// BusyNodes returns a description of the nodes in transient provision
+// states, empty if there are none.
+func BusyNodes(cctx ControllerContext, resources Resources) (string, error) {
+	if clientCAPath(resources) != "" {
+		return "", errors.New("node states cannot be checked because the Ironic API requires client certificates")
+	}
+	busyNodes, err := listBusyNodes(cctx, resources)
+	if err != nil || len(busyNodes) == 0 {
+		return "", err
+	}
+	return describeNodes(busyNodes), nil
+}
+
+// ensureUpgradeAllowed delays an upgrade of a running Ironic until no nodes
+// are in transient provision states, the timeout passes or the upgrade is
+// forced through an annotation. The returned status contains the upgrade.
+func ensureUpgradeAllowed(cctx ControllerContext, resources Resources) (Status, error) {
+	ironic := resources.Ironic
+	if ironic.Status.InstalledVersion == "" {
+		return ready()
+	}
+
+	runningImage, err := runningIronicImage(cctx, ironic)
+	if err != nil {
+		return transientError(err)
+	}
+	toVersion := cctx.VersionInfo.InstalledVersion.String()
+	if toVersion == ironic.Status.InstalledVersion && (runningImage == "" || runningImage == cctx.VersionInfo.IronicImage) {
+		return ready()
+	}
+
+	upgrade := ironic.Status.Upgrade.DeepCopy()
+	if upgrade == nil || upgrade.ToVersion != toVersion || upgrade.IronicImage != cctx.VersionInfo.IronicImage {
+		steps, err := upgradeSteps(cctx, ironic)
+		if err != nil {
+			return Status{Fatal: err}, nil
+		}
+		upgrade = &metal3api.UpgradeStatus{
+			FromVersion:     ironic.Status.InstalledVersion,
+			ToVersion:       toVersion,
+			FromIronicImage: runningImage,
+			IronicImage:     cctx.VersionInfo.IronicImage,
+			RequestTime:     metav1.Now(),
+			Steps:           steps,
+		}
+	}
+	if upgrade.StartTime != nil {
+		return Status{Ready: true, Upgrade: upgrade}, nil
+	}
+
+	proceed := func(reason string) (Status, error) {
+		cctx.Logger.Info("starting upgrade", "Reason", reason, "FromVersion", upgrade.FromVersion,
+			"ToVersion", upgrade.ToVersion, "IronicImage", upgrade.IronicImage)
+		upgrade.StartTime = ptr.To(metav1.Now())
+		return Status{Ready: true, Upgrade: upgrade}, nil
+	}
+
+	if runningImage == "" {
+		return proceed("ironic is not running")
+	}
+
+	if _, ok := ironic.Annotations[metal3api.IronicForceUpgradeAnnotation]; ok {
+		return proceed("upgrade forced via annotation")
+	}
+	if timeout := NodeOperationsTimeout(ironic); time.Since(upgrade.RequestTime.Time) >= timeout {
+		return proceed(fmt.Sprintf("nodes are still busy after %s", timeout))
+	}
+
+	// The operator has no certificate signed by the client CA, so it cannot
+	// query the nodes. Rely on the timeout and the annotation instead.
+	if clientCAPath(resources) != "" {
+		return Status{
+			Message: fmt.Sprintf("upgrade blocked: node states cannot be checked because the Ironic API requires client certificates; "+
+				"waiting until %s or for the %s annotation",
+				upgrade.RequestTime.Add(NodeOperationsTimeout(ironic)).UTC().Format(time.RFC3339), metal3api.IronicForceUpgradeAnnotation),
+			Reason:  metal3api.IronicReasonUpgradeBlocked,
+			Upgrade: upgrade,
+			requeue: true,
+		}, nil
+	}
+
+	busyNodes, err := listBusyNodes(cctx, resources)
+	if err != nil {
+		cctx.Logger.Info("cannot check node states before upgrading", "Error", err.Error())
+		return Status{
+			Message: fmt.Sprintf("upgrade blocked: cannot check node states: %v", err),
+			Reason:  metal3api.IronicReasonUpgradeBlocked,
+			Upgrade: upgrade,
+			requeue: true,
+		}, nil
+	}
+	if len(busyNodes) == 0 {
+		return proceed("no nodes in transient provision states")
+	}
+
+	return Status{
+		Message: fmt.Sprintf("upgrade blocked: %d node(s) in transient provision states: %s", len(busyNodes), describeNodes(busyNodes)),
+		Reason:  metal3api.IronicReasonUpgradeBlocked,
+		Upgrade: upgrade,
+		requeue: true,
+	}, nil
+}
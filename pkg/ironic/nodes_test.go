@@ -0,0 +1,415 @@
+package ironic
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestEnsureUpgradeAllowed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	const oldImage = "quay.io/metal3-io/ironic:release-37.0"
+	const newImage = "quay.io/metal3-io/ironic:release-38.0"
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ironic", Image: oldImage}},
+				},
+			},
+		},
+	}
+
+	// Fake Ironic API returning the provided nodes for their provision state
+	newServer := func(t *testing.T, busyNodes map[string][]string) *httptest.Server {
+		t.Helper()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := r.URL.Query().Get("provision_state")
+			result := []map[string]string{}
+			for _, name := range busyNodes[state] {
+				result = append(result, map[string]string{"name": name, "provision_state": state})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"nodes": result})
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	longAgo := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	recently := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	testCases := []struct {
+		Scenario string
+
+		InstalledVersion string
+		Image            string
+		NoDeployment     bool
+		Annotations      map[string]string
+		Upgrade          *metal3api.UpgradeStatus
+		BusyNodes        map[string][]string
+		APIError         bool
+		Database         bool
+		ClientCA         bool
+
+		ExpectedReady   bool
+		ExpectedMessage string
+		ExpectedError   string
+		ExpectedStarted bool
+		ExpectedUpgrade bool
+		ExpectedSteps   []string
+	}{
+		{
+			Scenario:      "initial installation",
+			ExpectedReady: true,
+		},
+		{
+			Scenario:         "no upgrade",
+			InstalledVersion: "38.0",
+			Image:            oldImage,
+			ExpectedReady:    true,
+		},
+		{
+			Scenario:         "not running",
+			InstalledVersion: "38.0",
+			NoDeployment:     true,
+			ExpectedReady:    true,
+		},
+		{
+			Scenario:         "not running with version change",
+			InstalledVersion: "37.0",
+			NoDeployment:     true,
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "skipping versions",
+			InstalledVersion: "35.0",
+			Database:         true,
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+			ExpectedSteps:    []string{"37.0"},
+		},
+		{
+			Scenario:         "skipping versions without database",
+			InstalledVersion: "35.0",
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "skipping versions with custom image",
+			InstalledVersion: "35.0",
+			Image:            "myorg/ironic:38.0",
+			Database:         true,
+			ExpectedError:    "cannot upgrade from 35.0 to 38.0 with a custom Ironic image: provide the images of 37.0 in upgradePolicy.intermediateImages or upgrade to these versions first",
+		},
+		{
+			Scenario:         "no busy nodes",
+			InstalledVersion: "37.0",
+			BusyNodes:        map[string][]string{"active": {"node-0"}},
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "image change with busy nodes",
+			InstalledVersion: "38.0",
+			BusyNodes: map[string][]string{
+				"deploying":  {"node-1"},
+				"clean wait": {"node-2"},
+			},
+			ExpectedMessage: "upgrade blocked: 2 node(s) in transient provision states: node-2 (clean wait), node-1 (deploying)",
+			ExpectedUpgrade: true,
+		},
+		{
+			Scenario:         "still waiting",
+			InstalledVersion: "37.0",
+			Upgrade:          &metal3api.UpgradeStatus{FromVersion: "37.0", ToVersion: "38.0", IronicImage: newImage, RequestTime: recently},
+			BusyNodes:        map[string][]string{"inspecting": {"node-1"}},
+			ExpectedMessage:  "upgrade blocked: 1 node(s) in transient provision states: node-1 (inspecting)",
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "API unavailable",
+			InstalledVersion: "37.0",
+			APIError:         true,
+			ExpectedMessage:  "upgrade blocked: cannot check node states: connection refused",
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "client certificates required",
+			InstalledVersion: "37.0",
+			Upgrade:          &metal3api.UpgradeStatus{FromVersion: "37.0", ToVersion: "38.0", IronicImage: newImage, RequestTime: recently},
+			ClientCA:         true,
+			ExpectedMessage: "upgrade blocked: node states cannot be checked because the Ironic API requires client certificates; " +
+				"waiting until " + recently.Add(time.Hour).UTC().Format(time.RFC3339) + " or for the ironic.metal3.io/force-upgrade annotation",
+			ExpectedUpgrade: true,
+		},
+		{
+			Scenario:         "client certificates required after timeout",
+			InstalledVersion: "37.0",
+			Upgrade:          &metal3api.UpgradeStatus{FromVersion: "37.0", ToVersion: "38.0", IronicImage: newImage, RequestTime: longAgo},
+			ClientCA:         true,
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "timeout",
+			InstalledVersion: "37.0",
+			Upgrade:          &metal3api.UpgradeStatus{FromVersion: "37.0", ToVersion: "38.0", IronicImage: newImage, RequestTime: longAgo},
+			APIError:         true,
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "forced",
+			InstalledVersion: "37.0",
+			Annotations:      map[string]string{metal3api.IronicForceUpgradeAnnotation: ""},
+			APIError:         true,
+			ExpectedReady:    true,
+			ExpectedStarted:  true,
+			ExpectedUpgrade:  true,
+		},
+		{
+			Scenario:         "already started",
+			InstalledVersion: "37.0",
+			Upgrade: &metal3api.UpgradeStatus{
+				FromVersion: "37.0", ToVersion: "38.0", IronicImage: newImage,
+				RequestTime: longAgo, StartTime: &recently,
+			},
+			BusyNodes:       map[string][]string{"deploying": {"node-1"}},
+			ExpectedReady:   true,
+			ExpectedStarted: true,
+			ExpectedUpgrade: true,
+		},
+		{
+			Scenario:         "different upgrade requested",
+			InstalledVersion: "37.0",
+			Upgrade: &metal3api.UpgradeStatus{
+				FromVersion: "37.0", ToVersion: "38.0", IronicImage: "quay.io/metal3-io/ironic:other",
+				RequestTime: longAgo, StartTime: &recently,
+			},
+			BusyNodes:       map[string][]string{"deploying": {"node-1"}},
+			ExpectedMessage: "upgrade blocked: 1 node(s) in transient provision states: node-1 (deploying)",
+			ExpectedUpgrade: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Annotations: tc.Annotations},
+				Spec: metal3api.IronicSpec{
+					Version: "38.0",
+					Images:  metal3api.Images{Ironic: newImage},
+				},
+				Status: metal3api.IronicStatus{
+					InstalledVersion: tc.InstalledVersion,
+					Upgrade:          tc.Upgrade,
+				},
+			}
+			if tc.Image != "" {
+				ironic.Spec.Images.Ironic = tc.Image
+			}
+			if tc.Database {
+				ironic.Spec.Database = &metal3api.Database{CredentialsName: "db", Host: "db.example.com", Name: "ironic"}
+			}
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if !tc.NoDeployment {
+				builder = builder.WithObjects(deployment.DeepCopy())
+			}
+			cctx := ControllerContext{Context: t.Context(), Client: builder.Build(), Scheme: scheme, Logger: logr.Discard()}
+			version, err := cctx.VersionInfo.WithIronicOverrides(ironic)
+			require.NoError(t, err)
+			cctx.VersionInfo = version
+
+			server := newServer(t, tc.BusyNodes)
+			oldNewIronicClient := newIronicClient
+			t.Cleanup(func() { newIronicClient = oldNewIronicClient })
+			newIronicClient = func(ControllerContext, Resources) (*gophercloud.ServiceClient, error) {
+				if tc.ClientCA {
+					t.Error("the Ironic API must not be queried when client certificates are required")
+				}
+				if tc.APIError {
+					return nil, errors.New("connection refused")
+				}
+				return noauth.NewBareMetalNoAuth(noauth.EndpointOpts{IronicEndpoint: server.URL + "/v1"})
+			}
+
+			resources := Resources{Ironic: ironic}
+			if tc.ClientCA {
+				ironic.Spec.TLS = metal3api.TLS{
+					CertificateName: "tls",
+					ClientCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindConfigMap},
+						Key:               "ca.crt",
+					},
+				}
+				resources.TLSSecret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test"}}
+				resources.ClientCAConfigMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "client-ca", Namespace: "test"}}
+			}
+
+			status, err := ensureUpgradeAllowed(cctx, resources)
+			require.NoError(t, err)
+			if tc.ExpectedError != "" {
+				require.Error(t, status.Fatal)
+				assert.Equal(t, tc.ExpectedError, status.Fatal.Error())
+				return
+			}
+			assert.Equal(t, tc.ExpectedReady, status.IsReady())
+			if !tc.ExpectedReady {
+				assert.Equal(t, tc.ExpectedMessage, status.Message)
+				assert.Equal(t, metal3api.IronicReasonUpgradeBlocked, status.Reason)
+				assert.True(t, status.NeedsRequeue())
+			}
+
+			if !tc.ExpectedUpgrade {
+				assert.Nil(t, status.Upgrade)
+				return
+			}
+			require.NotNil(t, status.Upgrade)
+			assert.Equal(t, tc.InstalledVersion, status.Upgrade.FromVersion)
+			assert.Equal(t, "38.0", status.Upgrade.ToVersion)
+			assert.Equal(t, newImage, status.Upgrade.IronicImage)
+			assert.False(t, status.Upgrade.RequestTime.IsZero())
+			assert.Equal(t, tc.ExpectedStarted, status.Upgrade.StartTime != nil)
+			var steps []string
+			for _, step := range status.Upgrade.Steps {
+				steps = append(steps, step.Version)
+				assert.Equal(t, "quay.io/metal3-io/ironic:release-"+step.Version, step.IronicImage)
+				assert.Nil(t, step.CompletionTime)
+			}
+			assert.Equal(t, tc.ExpectedSteps, steps)
+		})
+	}
+}
+
+func TestBusyNodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := []map[string]string{}
+		if state := r.URL.Query().Get("provision_state"); state == "cleaning" {
+			result = append(result, map[string]string{"name": "node-1", "provision_state": state})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"nodes": result})
+	}))
+	t.Cleanup(server.Close)
+
+	oldNewIronicClient := newIronicClient
+	t.Cleanup(func() { newIronicClient = oldNewIronicClient })
+	newIronicClient = func(ControllerContext, Resources) (*gophercloud.ServiceClient, error) {
+		return noauth.NewBareMetalNoAuth(noauth.EndpointOpts{IronicEndpoint: server.URL + "/v1"})
+	}
+
+	cctx := ControllerContext{Context: t.Context(), Logger: logr.Discard()}
+	ironic := &metal3api.Ironic{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+
+	busyNodes, err := BusyNodes(cctx, Resources{Ironic: ironic})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1 (cleaning)", busyNodes)
+
+	ironic.Spec.TLS.ClientCA = &metal3api.ResourceReferenceWithKey{
+		ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindSecret},
+		Key:               "ca.crt",
+	}
+	_, err = BusyNodes(cctx, Resources{Ironic: ironic, TLSSecret: &corev1.Secret{}, ClientCASecret: &corev1.Secret{}})
+	require.EqualError(t, err, "node states cannot be checked because the Ironic API requires client certificates")
+}
+
+func TestTLSServerName(t *testing.T) {
+	newCert := func(dnsNames []string, ips []net.IP) []byte {
+		certPEM, _, err := generateCertificate(&x509.Certificate{
+			Subject:     pkix.Name{CommonName: "ironic"},
+			NotBefore:   time.Now(),
+			NotAfter:    time.Now().Add(time.Hour),
+			DNSNames:    dnsNames,
+			IPAddresses: ips,
+		}, nil, nil)
+		require.NoError(t, err)
+		return certPEM
+	}
+
+	const host = "test.test.svc.cluster.local"
+	assert.Equal(t, host, tlsServerName(newCert([]string{"ironic.example.com", host}, nil), host))
+	assert.Equal(t, "ironic.example.com", tlsServerName(newCert([]string{"ironic.example.com"}, nil), host))
+	assert.Equal(t, "192.0.2.1", tlsServerName(newCert(nil, []net.IP{net.ParseIP("192.0.2.1")}), host))
+	assert.Equal(t, host, tlsServerName([]byte("garbage"), host))
+}
+
+func TestGetIronicTransport(t *testing.T) {
+	ironic := &metal3api.Ironic{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	t.Cleanup(func() { forgetIronicTransport(ironic) })
+
+	transport := getIronicTransport(ironic, []byte("ca"), "ironic.example.com")
+	assert.Equal(t, "ironic.example.com", transport.TLSClientConfig.ServerName)
+	assert.Same(t, transport, getIronicTransport(ironic, []byte("ca"), "ironic.example.com"))
+	assert.NotSame(t, transport, getIronicTransport(ironic, []byte("new ca"), "ironic.example.com"))
+
+	forgetIronicTransport(ironic)
+	assert.NotContains(t, ironicTransports, types.NamespacedName{Namespace: "test", Name: "test"})
+}
+
+func TestRunningIronicImage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "httpd", Image: "httpd"},
+						{Name: "ironic", Image: "ironic"},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(daemonSet).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec:       metal3api.IronicSpec{HighAvailability: true},
+	}
+	image, err := runningIronicImage(cctx, ironic)
+	require.NoError(t, err)
+	assert.Equal(t, "ironic", image)
+
+	ironic.Spec.HighAvailability = false
+	image, err = runningIronicImage(cctx, ironic)
+	require.NoError(t, err)
+	assert.Empty(t, image)
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/go-logr/logr"
@@ -127,6 +128,94 @@ func UpdateSecret(secret *corev1.Secret, logger logr.Logger) (bool, error) {
 	return true, nil
 }
 
+// rotatedAtAnnotation records the time of the last automatic rotation of the API credentials.
+const rotatedAtAnnotation = metal3api.IronicLabelPrefix + "/rotated-at"
+
+// lastRotationTime returns the time of the last rotation or the creation
+// time of the secret if it has never been rotated.
+func lastRotationTime(secret *corev1.Secret) (lastTime time.Time, rotated bool) {
+	if value := secret.Annotations[rotatedAtAnnotation]; value != "" {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed, true
+		}
+	}
+	return secret.CreationTimestamp.Time, false
+}
+
+// rotationDue returns true if the rotation interval has passed.
+func rotationDue(secret *corev1.Secret, rotation *metal3api.CredentialsRotation, now time.Time) bool {
+	if rotation == nil {
+		return false
+	}
+	lastTime, _ := lastRotationTime(secret)
+	return !now.Before(lastTime.Add(rotation.Interval.Duration))
+}
+
+// PendingCredentialChanges lists the changes to the API secret that are due
+// but have not been applied yet.
+func PendingCredentialChanges(secret *corev1.Secret, rotation *metal3api.CredentialsRotation, now time.Time) []string {
+	if secret == nil || !rotationDue(secret, rotation, now) {
+		return nil
+	}
+	return []string{"rotation of the API credentials in secret " + secret.Name}
+}
+
+// CredentialChangesDueSince returns when the pending rotation of the API
+// credentials became due, zero if there is none.
+func CredentialChangesDueSince(secret *corev1.Secret, rotation *metal3api.CredentialsRotation, now time.Time) time.Time {
+	if !rotationDue(secret, rotation, now) {
+		return time.Time{}
+	}
+	lastTime, _ := lastRotationTime(secret)
+	return lastTime.Add(rotation.Interval.Duration)
+}
+
+// RotateSecret generates a new password if the rotation interval has
+// passed. The user name is kept: Ironic and httpd only check the first
+// htpasswd entry with a matching user name, so the previous password stops
+// working as soon as the mounted htpasswd file is updated.
+// Returns true if the credentials have been rotated.
+func RotateSecret(secret *corev1.Secret, rotation *metal3api.CredentialsRotation, now time.Time) (bool, error) {
+	if !rotationDue(secret, rotation, now) {
+		return false, nil
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return false, err
+	}
+
+	htpasswd, err := generateHtpasswd(secret.Data[corev1.BasicAuthUsernameKey], password)
+	if err != nil {
+		return false, err
+	}
+
+	secret.Data[corev1.BasicAuthPasswordKey] = password
+	secret.Data[htpasswdKey] = []byte(htpasswd)
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, 1)
+	}
+	secret.Annotations[rotatedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	return true, nil
+}
+
+// GetAPICredentialsStatus returns the rotation state of the API secret.
+func GetAPICredentialsStatus(secret *corev1.Secret, rotation *metal3api.CredentialsRotation) *metal3api.APICredentialsStatus {
+	lastTime, rotated := lastRotationTime(secret)
+	if !rotated && rotation == nil {
+		return nil
+	}
+
+	result := &metal3api.APICredentialsStatus{}
+	if rotated {
+		result.LastRotationTime = &metav1.Time{Time: lastTime}
+	}
+	if rotation != nil {
+		result.NextRotationTime = &metav1.Time{Time: lastTime.Add(rotation.Interval.Duration)}
+	}
+	return result
+}
+
 func GenerateSecret(owner *metav1.ObjectMeta, name string, extraFields bool) (*corev1.Secret, error) {
 	pwd, err := generatePassword()
 	if err != nil {
@@ -159,21 +248,36 @@ func GenerateSecret(owner *metav1.ObjectMeta, name string, extraFields bool) (*c
 	return secret, nil
 }
 
-func secretVersionAnnotations(secretType string, secret *corev1.Secret) map[string]string {
+func versionAnnotation(objectType string, data map[string][]byte) map[string]string {
 	// Hash all data fields to detect changes
 	h := fnv.New64a()
 
 	// Sort keys for consistent hash ordering
-	keys := slices.Sorted(maps.Keys(secret.Data))
+	keys := slices.Sorted(maps.Keys(data))
 
 	for _, k := range keys {
 		h.Write([]byte(k))
-		h.Write(secret.Data[k])
+		h.Write(data[k])
 	}
 
 	hash := hex.EncodeToString(h.Sum(nil))
 
 	return map[string]string{
-		fmt.Sprintf("ironic.metal3.io/%s-version", secretType): hash,
+		fmt.Sprintf("ironic.metal3.io/%s-version", objectType): hash,
+	}
+}
+
+func secretVersionAnnotations(secretType string, secret *corev1.Secret) map[string]string {
+	return versionAnnotation(secretType, secret.Data)
+}
+
+func configMapVersionAnnotations(configMapType string, configMap *corev1.ConfigMap) map[string]string {
+	data := maps.Clone(configMap.BinaryData)
+	if data == nil {
+		data = make(map[string][]byte, len(configMap.Data))
+	}
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
 	}
+	return versionAnnotation(configMapType, data)
 }
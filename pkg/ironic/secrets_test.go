@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,8 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
 )
 
 func TestIsValidUser(t *testing.T) {
@@ -128,6 +131,13 @@ func TestSecretNeedsUpdating(t *testing.T) {
 			CurrentHtpasswd: "admin:$2y$05$CJozjmp4SHJjNWcJn1vVsOx4OEBQTDTVTdNFc0I.CVt5xpEZMK4pW",
 			ExpectedChanged: true,
 		},
+		{
+			Scenario: "previous-credentials",
+
+			User:            "admin",
+			Password:        "password",
+			CurrentHtpasswd: "admin:$2y$05$CJozjmp4SHJjNWcJn1vVsOx4OEBQTDTVTdNFc0I.CVt5xpEZMK4pW\nold:$2y$05$CJozjmp4SHJjNWcJn1vVsOx4OEBQTDTVTdNFc0I.CVt5xpEZMK4pW",
+		},
 		{
 			Scenario: "missing-user",
 
@@ -184,6 +194,60 @@ func TestGenerateSecret(t *testing.T) {
 	}
 }
 
+func TestRotateSecret(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rotation := &metal3api.CredentialsRotation{
+		Interval: metav1.Duration{Duration: 24 * time.Hour},
+	}
+	secret, err := GenerateSecret(&metav1.ObjectMeta{Name: "my-ironic", Namespace: "test"}, "service", true)
+	require.NoError(t, err)
+	secret.Name = "my-ironic-service"
+	secret.CreationTimestamp = metav1.Time{Time: created}
+	oldPassword := string(secret.Data["password"])
+
+	rotated, err := RotateSecret(secret, nil, created.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	assert.Nil(t, GetAPICredentialsStatus(secret, nil))
+	assert.Zero(t, CredentialChangesDueSince(secret, nil, created.Add(48*time.Hour)))
+
+	rotated, err = RotateSecret(secret, rotation, created.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	assert.Empty(t, PendingCredentialChanges(secret, rotation, created.Add(time.Hour)))
+
+	status := GetAPICredentialsStatus(secret, rotation)
+	require.NotNil(t, status)
+	assert.Nil(t, status.LastRotationTime)
+	assert.Equal(t, created.Add(24*time.Hour), status.NextRotationTime.Time)
+
+	assert.Equal(t, []string{"rotation of the API credentials in secret my-ironic-service"},
+		PendingCredentialChanges(secret, rotation, created.Add(25*time.Hour)))
+	assert.Equal(t, created.Add(24*time.Hour), CredentialChangesDueSince(secret, rotation, created.Add(25*time.Hour)))
+
+	now := created.Add(25 * time.Hour)
+	rotated, err = RotateSecret(secret, rotation, now)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, "my-ironic", string(secret.Data["username"]))
+	assert.NotEqual(t, oldPassword, string(secret.Data["password"]))
+	// Only the new password is accepted
+	assert.NotContains(t, string(secret.Data[htpasswdKey]), "\n")
+	assert.False(t, secretNeedsUpdating(secret, logr.Discard()))
+
+	status = GetAPICredentialsStatus(secret, rotation)
+	require.NotNil(t, status)
+	assert.Equal(t, now, status.LastRotationTime.Time)
+	assert.Equal(t, now.Add(24*time.Hour), status.NextRotationTime.Time)
+	assert.Empty(t, PendingCredentialChanges(secret, rotation, now.Add(time.Hour)))
+
+	// The rotation time is kept when rotation is disabled
+	status = GetAPICredentialsStatus(secret, nil)
+	require.NotNil(t, status)
+	assert.Equal(t, now, status.LastRotationTime.Time)
+	assert.Nil(t, status.NextRotationTime)
+}
+
 func TestSecretVersionAnnotations(t *testing.T) {
 	testCases := []struct {
 		Scenario string
@@ -270,3 +334,20 @@ func TestSecretVersionAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapVersionAnnotations(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{"tls.crt": "certificate-data"},
+	}
+	annotations := configMapVersionAnnotations("trusted-ca-configmap", configMap)
+	assert.Len(t, annotations, 1)
+	hash := annotations["ironic.metal3.io/trusted-ca-configmap-version"]
+	// String and binary data with the same content produce the same hash
+	assert.Equal(t, secretVersionAnnotations("test", &corev1.Secret{
+		Data: map[string][]byte{"tls.crt": []byte("certificate-data")},
+	})["ironic.metal3.io/test-version"], hash)
+
+	configMap.BinaryData = map[string][]byte{"extra.crt": []byte("more-data")}
+	annotations = configMapVersionAnnotations("trusted-ca-configmap", configMap)
+	assert.NotEqual(t, hash, annotations["ironic.metal3.io/trusted-ca-configmap-version"])
+}
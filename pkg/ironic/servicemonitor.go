@@ -77,7 +77,7 @@ func removeServiceMonitor(cctx ControllerContext, ironic *metal3api.Ironic) (Sta
 	err := cctx.Client.Delete(context.Background(), sm)
 	// Ignore NotFound errors and NoMatchError (API not available)
 	if err == nil || k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
-		return ready()
+		return notRequired("service monitor is not enabled")
 	}
 	return transientError(err)
 }
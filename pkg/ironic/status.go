@@ -1,5 +1,12 @@
 package ironic
 
+import (
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// Components maps component condition types to their individual status.
+type Components map[metal3api.IronicStatusConditionType]Status
+
 type Status struct {
 	// Object is reconciled and all resources are ready.
 	Ready bool
@@ -7,8 +14,25 @@ type Status struct {
 	Fatal error
 	// Message explaining what is not ready.
 	Message string
+	// Condition reason to use instead of the generic one when not ready.
+	Reason string
+	// Status of individual components, keyed by their condition type.
+	// Components that have not been evaluated are missing.
+	Components Components
+	// Effective endpoints, only set once the service has been created.
+	Endpoints *metal3api.IronicEndpoints
+	// Rotation state of the API credentials.
+	APICredentials *metal3api.APICredentialsStatus
+	// Expiration of the certificates in use.
+	Certificates []metal3api.CertificateStatus
+	// Database backup completed during this reconciliation.
+	DatabaseBackup *metal3api.DatabaseBackupStatus
+	// Upgrade in progress, if any.
+	Upgrade *metal3api.UpgradeStatus
 	// Whether a requeue will be needed.
 	requeue bool
+	// The component is not configured, nothing has been deployed.
+	notRequired bool
 }
 
 func (status Status) IsError() bool {
@@ -27,6 +51,11 @@ func (status Status) NeedsRequeue() bool {
 	return status.requeue
 }
 
+// IsNotRequired returns true if the component is not configured.
+func (status Status) IsNotRequired() bool {
+	return status.notRequired && status.IsReady()
+}
+
 func (status Status) String() string {
 	if status.Fatal != nil {
 		return status.Fatal.Error()
@@ -39,6 +68,10 @@ func (status Status) String() string {
 		return "resources are not ready yet"
 	}
 
+	if status.notRequired && status.Message != "" {
+		return status.Message
+	}
+
 	return "resources are available"
 }
 
@@ -47,6 +80,11 @@ func ready() (Status, error) {
 	return Status{Ready: true}, nil
 }
 
+// The component is not configured, nothing to do.
+func notRequired(message string) (Status, error) {
+	return Status{Ready: true, Message: message, notRequired: true}, nil
+}
+
 // We have updated dependent resources.
 func updated() (Status, error) {
 	return Status{Message: "dependent resources are being updated", requeue: true}, nil
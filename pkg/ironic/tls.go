@@ -0,0 +1,87 @@
+package ironic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+// opensslCipherNames maps the IANA names of secure TLS 1.2 cipher suites
+// accepted by the operator's --tls-cipher-suites flag to the OpenSSL names
+// used by the Ironic image. Suites that Go considers insecure are not listed:
+// RC4 and 3DES are not available in the OpenSSL 3 builds used by the image,
+// and compliance scans flag the rest (e.g. RSA key exchange).
+var opensslCipherNames = map[string]string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":          "ECDHE-ECDSA-AES128-SHA",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":          "ECDHE-ECDSA-AES256-SHA",
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":            "ECDHE-RSA-AES128-SHA",
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":            "ECDHE-RSA-AES256-SHA",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         "ECDHE-RSA-AES128-GCM-SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256":       "ECDHE-ECDSA-AES128-GCM-SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         "ECDHE-RSA-AES256-GCM-SHA384",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       "ECDHE-ECDSA-AES256-GCM-SHA384",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   "ECDHE-RSA-CHACHA20-POLY1305",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": "ECDHE-ECDSA-CHACHA20-POLY1305",
+	// Legacy names accepted by Go
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":   "ECDHE-RSA-CHACHA20-POLY1305",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305": "ECDHE-ECDSA-CHACHA20-POLY1305",
+}
+
+// validateCipherSuite checks that the cipher suite is known to Go (the same
+// check as for the operator's --tls-cipher-suites flag), is not insecure
+// and can be passed to the Ironic image.
+func validateCipherSuite(name string) error {
+	if _, err := cliflag.TLSCipherSuites([]string{name}); err != nil {
+		return err
+	}
+	if slices.Contains(cliflag.InsecureTLSCipherNames(), name) {
+		return fmt.Errorf("cipher suite %s is insecure and cannot be used", name)
+	}
+	if _, ok := opensslCipherNames[name]; !ok {
+		return fmt.Errorf("cipher suite %s cannot be configured, only TLS 1.2 cipher suites are supported", name)
+	}
+	return nil
+}
+
+func sslProtocol(version metal3api.TLSVersion) string {
+	if version == metal3api.TLSVersion13 {
+		return "-ALL +TLSv1.3"
+	}
+	return "-ALL +TLSv1.2 +TLSv1.3"
+}
+
+// buildTLSOptionsEnvVars returns the TLS protocol and cipher settings for
+// httpd and the internal RPC.
+func buildTLSOptionsEnvVars(tls *metal3api.TLS) []corev1.EnvVar {
+	if tls.MinVersion == "" && len(tls.CipherSuites) == 0 {
+		return nil
+	}
+
+	result := []corev1.EnvVar{
+		{
+			Name:  "IRONIC_SSL_PROTOCOL",
+			Value: sslProtocol(tls.MinVersion),
+		},
+	}
+
+	// Cipher suites are not configurable for TLS 1.3.
+	if tls.MinVersion != metal3api.TLSVersion13 && len(tls.CipherSuites) > 0 {
+		ciphers := make([]string, 0, len(tls.CipherSuites))
+		for _, name := range tls.CipherSuites {
+			if opensslName, ok := opensslCipherNames[name]; ok {
+				ciphers = append(ciphers, opensslName)
+			}
+		}
+		result = append(result, corev1.EnvVar{
+			Name:  "IRONIC_SSL_CIPHER_SUITE",
+			Value: strings.Join(ciphers, ":"),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package ironic
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestBuildTLSOptionsEnvVars(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+
+		TLS metal3api.TLS
+
+		Expected []corev1.EnvVar
+	}{
+		{
+			Scenario: "defaults",
+		},
+		{
+			Scenario: "TLS 1.3",
+			TLS: metal3api.TLS{
+				MinVersion:   metal3api.TLSVersion13,
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			},
+			Expected: []corev1.EnvVar{
+				{Name: "IRONIC_SSL_PROTOCOL", Value: "-ALL +TLSv1.3"},
+			},
+		},
+		{
+			Scenario: "cipher suites",
+			TLS: metal3api.TLS{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"},
+			},
+			Expected: []corev1.EnvVar{
+				{Name: "IRONIC_SSL_PROTOCOL", Value: "-ALL +TLSv1.2 +TLSv1.3"},
+				{Name: "IRONIC_SSL_CIPHER_SUITE", Value: "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-CHACHA20-POLY1305"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, buildTLSOptionsEnvVars(&tc.TLS))
+		})
+	}
+}
+
+func TestOpenSSLCipherNames(t *testing.T) {
+	// All secure TLS 1.2 cipher suites known to Go must be mapped
+	for _, suite := range tls.CipherSuites() {
+		if len(suite.SupportedVersions) == 1 && suite.SupportedVersions[0] == tls.VersionTLS13 {
+			continue
+		}
+		assert.Contains(t, opensslCipherNames, suite.Name)
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		assert.NotContains(t, opensslCipherNames, suite.Name)
+	}
+}
@@ -2,18 +2,27 @@ package ironic
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
 )
 
-// Leave enough time for potential debugging but don't hold jobs forever.
-const jobTTLSeconds int32 = 24 * 3600
+const (
+	// Leave enough time for potential debugging but don't hold jobs forever.
+	jobTTLSeconds int32 = 24 * 3600
+
+	defaultUpgradeHistoryLimit = 10
+	maxUpgradeHistoryLimit     = 100
+)
 
 type upgradePhase string
 
@@ -34,8 +43,81 @@ func upgradeJobRequired(cctx ControllerContext, ironic *metal3api.Ironic) bool {
 	return ironic.Spec.Database != nil && cctx.VersionInfo.InstalledVersion.String() != ironic.Status.InstalledVersion
 }
 
-func newMigrationTemplate(cctx ControllerContext, ironic *metal3api.Ironic, phase upgradePhase) corev1.PodTemplateSpec {
+// listUpgradeJobs returns all jobs related to the upgrade to the version.
+func listUpgradeJobs(cctx ControllerContext, ironic *metal3api.Ironic, version string) ([]batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	err := cctx.Client.List(cctx.Context, jobs, client.InNamespace(ironic.Namespace), client.MatchingLabels{
+		metal3api.IronicServiceLabel: ironic.Name,
+		metal3api.IronicVersionLabel: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs.Items, nil
+}
+
+func deleteJobs(cctx ControllerContext, jobs []batchv1.Job) error {
+	for i := range jobs {
+		cctx.Logger.Info("deleting job", "Job", jobs[i].Name)
+		err := cctx.Client.Delete(cctx.Context, &jobs[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// RetryUpgrade removes the failed jobs of the upgrade to the version so that
+// they are started again on the next reconciliation.
+func RetryUpgrade(cctx ControllerContext, ironic *metal3api.Ironic, version string) error {
+	jobs, err := listUpgradeJobs(cctx, ironic, version)
+	if err != nil {
+		return err
+	}
+	return deleteJobs(cctx, slices.DeleteFunc(jobs, func(job batchv1.Job) bool {
+		return !hasJobCondition(&job, batchv1.JobFailed)
+	}))
+}
+
+// UpgradeJobNames returns the names of all jobs related to the upgrade to the
+// version, in the order of their creation.
+func UpgradeJobNames(cctx ControllerContext, ironic *metal3api.Ironic, version string) ([]string, error) {
+	jobs, err := listUpgradeJobs(cctx, ironic, version)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(jobs, func(a, b batchv1.Job) int {
+		if result := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); result != 0 {
+			return result
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	return names, nil
+}
+
+// RecordUpgrade adds a finished upgrade to the history and drops the oldest
+// upgrades beyond the limit.
+func RecordUpgrade(history []metal3api.UpgradeStatus, upgrade metal3api.UpgradeStatus, limit int32) []metal3api.UpgradeStatus {
+	if limit <= 0 {
+		limit = defaultUpgradeHistoryLimit
+	}
+	limit = min(limit, maxUpgradeHistoryLimit)
+
+	result := append(slices.Clone(history), upgrade)
+	if excess := len(result) - int(limit); excess > 0 {
+		result = result[excess:]
+	}
+	return result
+}
+
+func newMigrationTemplate(cctx ControllerContext, resources Resources, phase upgradePhase) corev1.PodTemplateSpec {
 	script := commandPerPhase[phase]
+	ironic := resources.Ironic
 	database := ironic.Spec.Database
 
 	volumes, mounts := databaseClientMounts(database)
@@ -94,6 +176,7 @@ func newMigrationTemplate(cctx ControllerContext, ironic *metal3api.Ironic, phas
 				metal3api.IronicServiceLabel: ironic.Name,
 				metal3api.IronicVersionLabel: cctx.VersionInfo.InstalledVersion.String(),
 			},
+			Annotations: databaseVersionAnnotations(resources),
 		},
 		Spec: corev1.PodSpec{
 			Containers: containers,
@@ -104,6 +187,17 @@ func newMigrationTemplate(cctx ControllerContext, ironic *metal3api.Ironic, phas
 	}))
 }
 
+// databaseCredentialsChanged checks if the database secrets have changed
+// since the job was created.
+func databaseCredentialsChanged(job *batchv1.Job, template corev1.PodTemplateSpec) bool {
+	for key, value := range template.Annotations {
+		if job.Spec.Template.Annotations[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
 func ensureIronicUpgradeJob(cctx ControllerContext, resources Resources, phase upgradePhase) (Status, error) {
 	if !upgradeJobRequired(cctx, resources.Ironic) {
 		return ready()
@@ -113,20 +207,45 @@ func ensureIronicUpgradeJob(cctx ControllerContext, resources Resources, phase u
 	if fromVersion == "" {
 		fromVersion = "none"
 	}
-	toVersion := cctx.VersionInfo.InstalledVersion
+	return ensureMigrationJob(cctx, resources, phase, fromVersion, cctx.VersionInfo.InstalledVersion.String(),
+		fmt.Sprintf("%s-upgrade", phase))
+}
 
+// ensureMigrationJob runs the migration job of the phase using the Ironic
+// image from the context. The versions are only used in the job name: jobs
+// are always labeled with the requested version.
+func ensureMigrationJob(cctx ControllerContext, resources Resources, phase upgradePhase, fromVersion, toVersion, jobType string) (Status, error) {
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s-%s-to-%s", resources.Ironic.Name, phase, fromVersion, cctx.VersionInfo.InstalledVersion),
+			Name:      fmt.Sprintf("%s-%s-%s-to-%s", resources.Ironic.Name, phase, fromVersion, toVersion),
 			Namespace: resources.Ironic.Namespace,
 		},
 	}
 
-	template := newMigrationTemplate(cctx, resources.Ironic, phase)
+	template := newMigrationTemplate(cctx, resources, phase)
+
+	existing := &batchv1.Job{}
+	err := cctx.Client.Get(cctx.Context, client.ObjectKeyFromObject(job), existing)
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return transientError(err)
+	case hasJobCondition(existing, batchv1.JobComplete):
+		// Pod templates of jobs are immutable, nothing to update
+		return getJobStatus(cctx, existing, jobType)
+	case databaseCredentialsChanged(existing, template):
+		// The job may be stuck with the old credentials: restart it
+		cctx.Logger.Info("database credentials changed, restarting the upgrade job", "Job", job.Name, "Phase", phase)
+		err = cctx.Client.Delete(cctx.Context, existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return transientError(err)
+		}
+		return updated()
+	}
 
 	result, err := controllerutil.CreateOrUpdate(cctx.Context, cctx.Client, job, func() error {
 		if job.Labels == nil {
-			cctx.Logger.Info("creating a new upgrade job", "Phase", phase, "From", fromVersion, "To", toVersion.String())
+			cctx.Logger.Info("creating a new upgrade job", "Phase", phase, "From", fromVersion, "To", toVersion)
 			job.Labels = make(map[string]string, 2)
 		}
 		job.Labels[metal3api.IronicServiceLabel] = resources.Ironic.Name
@@ -140,17 +259,115 @@ func ensureIronicUpgradeJob(cctx ControllerContext, resources Resources, phase u
 	})
 	if result != controllerutil.OperationResultNone {
 		cctx.Logger.Info("ironic upgrade job", "Job", job.Name, "Status", result,
-			"Phase", phase, "From", fromVersion, "To", toVersion.String())
+			"Phase", phase, "From", fromVersion, "To", toVersion)
 		return updated()
 	}
 	if err != nil {
 		return transientError(err)
 	}
 
-	status, err := getJobStatus(cctx, job, fmt.Sprintf("%s-upgrade", phase))
+	status, err := getJobStatus(cctx, job, jobType)
 	if status.IsReady() && err == nil {
-		cctx.Logger.Info("upgrade job succeeded", "Phase", phase, "From", fromVersion, "To", toVersion.String())
+		cctx.Logger.Info("upgrade job succeeded", "Phase", phase, "From", fromVersion, "To", toVersion)
 	}
 
 	return status, err
 }
+
+// intermediateVersions returns the supported versions between the two, in the
+// order of upgrading.
+func intermediateVersions(from, to metal3api.Version) []metal3api.Version {
+	var result []metal3api.Version
+	for version := range metal3api.SupportedVersions {
+		// Compare only handles "latest" on the receiver side.
+		if !version.IsLatest() && from.Compare(version) < 0 && to.Compare(version) > 0 {
+			result = append(result, version)
+		}
+	}
+	slices.SortFunc(result, metal3api.Version.Compare)
+	return result
+}
+
+// upgradeSteps returns the intermediate steps of an upgrade with an external
+// database, nil if the upgrade does not skip any supported versions.
+func upgradeSteps(cctx ControllerContext, ironic *metal3api.Ironic) ([]metal3api.UpgradeStep, error) {
+	if ironic.Spec.Database == nil {
+		return nil, nil
+	}
+
+	fromVersion, err := metal3api.ParseVersion(ironic.Status.InstalledVersion)
+	if err != nil {
+		return nil, err
+	}
+	toVersion := cctx.VersionInfo.InstalledVersion
+	versions := intermediateVersions(fromVersion, toVersion)
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	var intermediateImages map[string]string
+	if policy := ironic.Spec.UpgradePolicy; policy != nil {
+		intermediateImages = policy.IntermediateImages
+	}
+	// The default images of the intermediate versions are only used with
+	// the default image of the target version, e.g. not with a mirror.
+	customImage := cctx.VersionInfo.IronicImage != defaultIronicImage(toVersion)
+
+	steps := make([]metal3api.UpgradeStep, 0, len(versions))
+	var missing []string
+	for _, version := range versions {
+		image := intermediateImages[version.String()]
+		if image == "" {
+			if customImage {
+				missing = append(missing, version.String())
+				continue
+			}
+			image = defaultIronicImage(version)
+		}
+		steps = append(steps, metal3api.UpgradeStep{
+			Version:     version.String(),
+			IronicImage: image,
+		})
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("cannot upgrade from %s to %s with a custom Ironic image: "+
+			"provide the images of %s in upgradePolicy.intermediateImages or upgrade to these versions first",
+			fromVersion, toVersion, strings.Join(missing, ", "))
+	}
+	return steps, nil
+}
+
+// ensureUpgradeSteps migrates the database through each intermediate version
+// of the upgrade, recording the completed steps in it.
+func ensureUpgradeSteps(cctx ControllerContext, resources Resources, upgrade *metal3api.UpgradeStatus) (Status, error) {
+	if upgrade == nil {
+		return ready()
+	}
+
+	fromVersion := upgrade.FromVersion
+	for idx := range upgrade.Steps {
+		step := &upgrade.Steps[idx]
+		if step.CompletionTime != nil {
+			fromVersion = step.Version
+			continue
+		}
+
+		stepCctx := cctx
+		stepCctx.VersionInfo.IronicImage = step.IronicImage
+		// Unlike the final migration, the online data migrations have to
+		// finish before the next schema upgrade.
+		for _, phase := range []upgradePhase{preUpgrade, postUpgrade} {
+			status, err := ensureMigrationJob(stepCctx, resources, phase, fromVersion, step.Version,
+				fmt.Sprintf("%s-upgrade to intermediate version %s", phase, step.Version))
+			if err != nil || !status.IsReady() {
+				return status, err
+			}
+		}
+
+		cctx.Logger.Info("intermediate upgrade step completed", "From", fromVersion, "To", step.Version)
+		step.CompletionTime = ptr.To(metav1.Now())
+		fromVersion = step.Version
+	}
+
+	return ready()
+}
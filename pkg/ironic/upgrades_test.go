@@ -0,0 +1,399 @@
+package ironic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
+)
+
+func TestEnsureIronicUpgradeJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	newResources := func(password string) Resources {
+		return Resources{
+			Ironic: &metal3api.Ironic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "abc-123"},
+				Spec: metal3api.IronicSpec{
+					Database: &metal3api.Database{
+						CredentialsName:       "db-credentials",
+						Host:                  "db.example.com",
+						Name:                  "ironic",
+						TLSCertificateName:    "db-tls",
+						ClientCertificateName: "db-client",
+					},
+				},
+			},
+			DatabaseSecret:       &corev1.Secret{Data: map[string][]byte{"password": []byte(password)}},
+			DatabaseTLSSecret:    &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("db-ca")}},
+			DatabaseClientSecret: &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}},
+		}
+	}
+
+	setup := func(t *testing.T) (ControllerContext, client.Client) {
+		t.Helper()
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+		version, err := cctx.VersionInfo.WithIronicOverrides(newResources("").Ironic)
+		require.NoError(t, err)
+		cctx.VersionInfo = version
+		return cctx, c
+	}
+
+	getJob := func(t *testing.T, c client.Client) (*batchv1.Job, error) {
+		t.Helper()
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(t.Context(), jobs, client.InNamespace("test")))
+		if len(jobs.Items) == 0 {
+			return nil, k8serrors.NewNotFound(batchv1.Resource("jobs"), "")
+		}
+		require.Len(t, jobs.Items, 1)
+		return &jobs.Items[0], nil
+	}
+
+	t.Run("mounts the client certificate", func(t *testing.T) {
+		cctx, c := setup(t)
+
+		status, err := ensureIronicUpgradeJob(cctx, newResources("password1"), preUpgrade)
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+
+		job, err := getJob(t, c)
+		require.NoError(t, err)
+		template := job.Spec.Template
+		assert.Contains(t, template.Annotations, "ironic.metal3.io/database-client-secret-version")
+		volumeSecrets := make(map[string]string, len(template.Spec.Volumes))
+		for _, volume := range template.Spec.Volumes {
+			if volume.Secret != nil {
+				volumeSecrets[volume.Name] = volume.Secret.SecretName
+			}
+		}
+		assert.Equal(t, "db-client", volumeSecrets["client-cert-mariadb"])
+		assert.Contains(t, template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "client-cert-mariadb", MountPath: "/certs/mariadb"})
+		assert.Contains(t, template.Spec.Containers[0].Env, corev1.EnvVar{Name: "MARIADB_CERT_FILE", Value: "/certs/mariadb/tls.crt"})
+		assert.Contains(t, template.Spec.Containers[0].Env, corev1.EnvVar{Name: "MARIADB_KEY_FILE", Value: "/certs/mariadb/tls.key"})
+	})
+
+	t.Run("restarts a running job on credentials change", func(t *testing.T) {
+		cctx, c := setup(t)
+
+		_, err := ensureIronicUpgradeJob(cctx, newResources("password1"), preUpgrade)
+		require.NoError(t, err)
+
+		status, err := ensureIronicUpgradeJob(cctx, newResources("password1"), preUpgrade)
+		require.NoError(t, err)
+		assert.Equal(t, "pre-upgrade job not complete yet", status.Message)
+
+		status, err = ensureIronicUpgradeJob(cctx, newResources("password2"), preUpgrade)
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+		_, err = getJob(t, c)
+		require.True(t, k8serrors.IsNotFound(err))
+
+		_, err = ensureIronicUpgradeJob(cctx, newResources("password2"), preUpgrade)
+		require.NoError(t, err)
+		_, err = getJob(t, c)
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps a complete job", func(t *testing.T) {
+		cctx, c := setup(t)
+
+		_, err := ensureIronicUpgradeJob(cctx, newResources("password1"), preUpgrade)
+		require.NoError(t, err)
+		job, err := getJob(t, c)
+		require.NoError(t, err)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(t.Context(), job))
+
+		status, err := ensureIronicUpgradeJob(cctx, newResources("password2"), preUpgrade)
+		require.NoError(t, err)
+		assert.True(t, status.IsReady())
+		_, err = getJob(t, c)
+		require.NoError(t, err)
+	})
+}
+
+func TestRetryUpgrade(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironic := &metal3api.Ironic{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	newJob := func(name, version string, condType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels: map[string]string{
+					metal3api.IronicServiceLabel: "test",
+					metal3api.IronicVersionLabel: version,
+				},
+			},
+		}
+		if condType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: condType, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newJob("test-pre-37.0-to-38.0", "38.0", batchv1.JobFailed),
+		newJob("test-database-check-38.0", "38.0", batchv1.JobComplete),
+		newJob("test-post-none-to-38.0", "38.0", ""),
+		newJob("test-pre-36.0-to-37.0", "37.0", batchv1.JobFailed),
+	).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	require.NoError(t, RetryUpgrade(cctx, ironic, "38.0"))
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(t.Context(), jobs))
+	var names []string
+	for _, job := range jobs.Items {
+		names = append(names, job.Name)
+	}
+	assert.ElementsMatch(t, []string{"test-database-check-38.0", "test-post-none-to-38.0", "test-pre-36.0-to-37.0"}, names)
+}
+
+func TestIntermediateVersions(t *testing.T) {
+	testCases := []struct {
+		From, To string
+		Expected []metal3api.Version
+	}{
+		{From: "37.0", To: "38.0"},
+		{From: "35.0", To: "38.0", Expected: []metal3api.Version{metal3api.Version370}},
+		{From: "35.0", To: "latest", Expected: []metal3api.Version{metal3api.Version370, metal3api.Version380}},
+		{From: "latest", To: "38.0"},
+		{From: "latest", To: "latest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.From+"-to-"+tc.To, func(t *testing.T) {
+			result := intermediateVersions(metal3api.MustParseVersion(tc.From), metal3api.MustParseVersion(tc.To))
+			assert.Equal(t, tc.Expected, result)
+		})
+	}
+}
+
+func TestUpgradeSteps(t *testing.T) {
+	mirrored := map[string]string{
+		"37.0": "mirror.example.com/ironic:release-37.0",
+		"38.0": "mirror.example.com/ironic:release-38.0",
+	}
+
+	testCases := []struct {
+		Scenario string
+
+		Image              string
+		IntermediateImages map[string]string
+
+		ExpectedImages []string
+		ExpectedError  string
+	}{
+		{
+			Scenario:       "default images",
+			ExpectedImages: []string{"quay.io/metal3-io/ironic:release-37.0", "quay.io/metal3-io/ironic:release-38.0"},
+		},
+		{
+			Scenario:      "custom image",
+			Image:         "mirror.example.com/ironic:latest",
+			ExpectedError: "cannot upgrade from 35.0 to latest with a custom Ironic image: provide the images of 37.0, 38.0 in upgradePolicy.intermediateImages or upgrade to these versions first",
+		},
+		{
+			Scenario:           "custom image with some intermediate images",
+			Image:              "mirror.example.com/ironic:latest",
+			IntermediateImages: map[string]string{"38.0": mirrored["38.0"]},
+			ExpectedError:      "cannot upgrade from 35.0 to latest with a custom Ironic image: provide the images of 37.0 in upgradePolicy.intermediateImages or upgrade to these versions first",
+		},
+		{
+			Scenario:           "custom image with intermediate images",
+			Image:              "mirror.example.com/ironic:latest",
+			IntermediateImages: mirrored,
+			ExpectedImages:     []string{mirrored["37.0"], mirrored["38.0"]},
+		},
+		{
+			Scenario:           "default image with an intermediate image",
+			IntermediateImages: map[string]string{"37.0": mirrored["37.0"]},
+			ExpectedImages:     []string{mirrored["37.0"], "quay.io/metal3-io/ironic:release-38.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ironic := &metal3api.Ironic{
+				Spec: metal3api.IronicSpec{
+					Database: &metal3api.Database{CredentialsName: "db-credentials", Host: "db.example.com", Name: "ironic"},
+					Images:   metal3api.Images{Ironic: tc.Image},
+				},
+				Status: metal3api.IronicStatus{InstalledVersion: "35.0"},
+			}
+			if tc.IntermediateImages != nil {
+				ironic.Spec.UpgradePolicy = &metal3api.UpgradePolicy{IntermediateImages: tc.IntermediateImages}
+			}
+			defaults, err := NewVersionInfo(metal3api.Images{}, "")
+			require.NoError(t, err)
+			version, err := defaults.WithIronicOverrides(ironic)
+			require.NoError(t, err)
+			cctx := ControllerContext{VersionInfo: version}
+
+			steps, err := upgradeSteps(cctx, ironic)
+			if tc.ExpectedError != "" {
+				require.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			var images []string
+			for _, step := range steps {
+				images = append(images, step.IronicImage)
+			}
+			assert.Equal(t, tc.ExpectedImages, images)
+		})
+	}
+}
+
+func TestEnsureUpgradeSteps(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, metal3api.AddToScheme(scheme))
+
+	ironic := &metal3api.Ironic{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: metal3api.IronicSpec{
+			Database: &metal3api.Database{CredentialsName: "db-credentials", Host: "db.example.com", Name: "ironic"},
+		},
+		Status: metal3api.IronicStatus{InstalledVersion: "35.0"},
+	}
+	upgrade := &metal3api.UpgradeStatus{
+		FromVersion: "35.0",
+		ToVersion:   "latest",
+		Steps: []metal3api.UpgradeStep{
+			{Version: "37.0", IronicImage: "quay.io/metal3-io/ironic:release-37.0"},
+			{Version: "38.0", IronicImage: "quay.io/metal3-io/ironic:release-38.0"},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+	version, err := cctx.VersionInfo.WithIronicOverrides(ironic)
+	require.NoError(t, err)
+	cctx.VersionInfo = version
+	resources := Resources{Ironic: ironic}
+
+	completeJob := func(t *testing.T, name string) *batchv1.Job {
+		t.Helper()
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "test", Name: name}, job))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(t.Context(), job))
+		return job
+	}
+
+	// Each step runs both phases before moving to the next one
+	expectedJobs := []struct {
+		Name, Image string
+	}{
+		{"test-pre-35.0-to-37.0", "quay.io/metal3-io/ironic:release-37.0"},
+		{"test-post-35.0-to-37.0", "quay.io/metal3-io/ironic:release-37.0"},
+		{"test-pre-37.0-to-38.0", "quay.io/metal3-io/ironic:release-38.0"},
+		{"test-post-37.0-to-38.0", "quay.io/metal3-io/ironic:release-38.0"},
+	}
+	for _, expected := range expectedJobs {
+		status, err := ensureUpgradeSteps(cctx, resources, upgrade)
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+
+		status, err = ensureUpgradeSteps(cctx, resources, upgrade)
+		require.NoError(t, err)
+		assert.False(t, status.IsReady())
+		assert.Contains(t, status.Message, "intermediate version")
+
+		job := completeJob(t, expected.Name)
+		assert.Equal(t, expected.Image, job.Spec.Template.Spec.Containers[0].Image)
+		// Labeled with the requested version for retries and rollbacks
+		assert.Equal(t, "latest", job.Labels[metal3api.IronicVersionLabel])
+	}
+
+	status, err := ensureUpgradeSteps(cctx, resources, upgrade)
+	require.NoError(t, err)
+	assert.True(t, status.IsReady())
+	for _, step := range upgrade.Steps {
+		assert.NotNil(t, step.CompletionTime)
+	}
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(t.Context(), jobs))
+	assert.Len(t, jobs.Items, len(expectedJobs))
+}
+
+func TestRecordUpgrade(t *testing.T) {
+	var history []metal3api.UpgradeStatus
+	for _, version := range []string{"35.0", "37.0", "38.0"} {
+		history = RecordUpgrade(history, metal3api.UpgradeStatus{ToVersion: version, Outcome: metal3api.UpgradeSucceeded}, 2)
+	}
+	require.Len(t, history, 2)
+	assert.Equal(t, "37.0", history[0].ToVersion)
+	assert.Equal(t, "38.0", history[1].ToVersion)
+
+	for range 15 {
+		history = RecordUpgrade(history, metal3api.UpgradeStatus{ToVersion: "latest"}, 0)
+	}
+	assert.Len(t, history, defaultUpgradeHistoryLimit)
+
+	for range maxUpgradeHistoryLimit + 1 {
+		history = RecordUpgrade(history, metal3api.UpgradeStatus{ToVersion: "latest"}, 1000)
+	}
+	assert.Len(t, history, maxUpgradeHistoryLimit)
+}
+
+func TestUpgradeJobNames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	newJob := func(name, version string, created time.Time) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "test",
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					metal3api.IronicServiceLabel: "test",
+					metal3api.IronicVersionLabel: version,
+				},
+			},
+		}
+	}
+	now := time.Now()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newJob("test-post-37.0-to-38.0", "38.0", now),
+		newJob("test-backup-37.0-to-38.0", "38.0", now.Add(-2*time.Minute)),
+		newJob("test-pre-37.0-to-38.0", "38.0", now.Add(-time.Minute)),
+		newJob("test-database-check-38.0", "38.0", now.Add(-2*time.Minute)),
+		newJob("test-pre-35.0-to-37.0", "37.0", now.Add(-time.Hour)),
+	).Build()
+	cctx := ControllerContext{Context: t.Context(), Client: c, Scheme: scheme, Logger: logr.Discard()}
+	ironic := &metal3api.Ironic{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+
+	names, err := UpgradeJobNames(cctx, ironic, "38.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"test-backup-37.0-to-38.0",
+		"test-database-check-38.0",
+		"test-pre-37.0-to-38.0",
+		"test-post-37.0-to-38.0",
+	}, names)
+}
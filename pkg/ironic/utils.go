@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -59,6 +60,11 @@ type Resources struct {
 	TrustedCAConfigMap      *corev1.ConfigMap
 	SwitchConfigSecret      *corev1.Secret
 	SwitchCredentialsSecret *corev1.Secret
+	ClientCASecret          *corev1.Secret
+	ClientCAConfigMap       *corev1.ConfigMap
+	DatabaseSecret          *corev1.Secret
+	DatabaseTLSSecret       *corev1.Secret
+	DatabaseClientSecret    *corev1.Secret
 }
 
 func mergeContainers(target, source []corev1.Container) []corev1.Container {
@@ -195,15 +201,44 @@ func getServiceStatus(service *corev1.Service) (Status, error) {
 	return ready()
 }
 
+func hasJobCondition(job *batchv1.Job, condType batchv1.JobConditionType) bool {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobFailureTime returns when the job has failed, the creation time if this
+// is not known.
+func jobFailureTime(job *batchv1.Job) time.Time {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue && !cond.LastTransitionTime.IsZero() {
+			return cond.LastTransitionTime.Time
+		}
+	}
+	return job.CreationTimestamp.Time
+}
+
 func getJobStatus(cctx ControllerContext, job *batchv1.Job, jobType string) (Status, error) {
 	for _, cond := range job.Status.Conditions {
 		if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
 			return ready()
 		}
 		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
-			cctx.Logger.Info(cond.Message, "Job", job.Name)
-			err := fmt.Errorf("%s job failed: %s", jobType, cond.Message)
-			return Status{Fatal: err}, nil
+			// The job condition rarely explains anything, look at the pods
+			output, err := jobFailureOutput(cctx, job)
+			if err != nil {
+				cctx.Logger.Error(err, "cannot get the output of the failed job", "Job", job.Name)
+			}
+			cctx.Logger.Info(cond.Message, "Job", job.Name, "Output", output)
+			return Status{Fatal: &JobFailedError{
+				JobName: job.Name,
+				JobType: jobType,
+				Reason:  cond.Message,
+				Output:  output,
+			}}, nil
 		}
 	}
 
@@ -3,13 +3,17 @@ package ironic
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/netip"
 	"net/url"
-	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
 	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
 )
 
@@ -52,73 +56,77 @@ func validateIPinPrefix(ip string, prefix netip.Prefix, cidrField string) error
 	return nil
 }
 
-func validateURL(urlStr string, fieldName string) error {
+func validateURL(urlStr string) error {
 	urlStr = strings.TrimSpace(urlStr)
 	if urlStr == "" {
-		return fmt.Errorf("%s: URL must not be empty", fieldName)
+		return errors.New("URL must not be empty")
 	}
 
 	parsed, err := url.Parse(urlStr)
 	if err != nil {
-		return fmt.Errorf("%s: invalid URL format: %w", fieldName, err)
+		return fmt.Errorf("invalid URL format: %w", err)
 	}
 
 	switch parsed.Scheme {
 	case protoFile:
 		if parsed.Host != "" {
-			return fmt.Errorf("%s: file URL must use an absolute path (file:///...)", fieldName)
+			return errors.New("file URL must use an absolute path (file:///...)")
 		}
 		if parsed.Path == "" || parsed.Path[0] != '/' {
-			return fmt.Errorf("%s: file URL must use an absolute path (file:///...)", fieldName)
+			return errors.New("file URL must use an absolute path (file:///...)")
 		}
 	case protoHTTP, protoHTTPS:
 		if parsed.Host == "" {
-			return fmt.Errorf("%s: %s URL must include a host", fieldName, parsed.Scheme)
+			return fmt.Errorf("%s URL must include a host", parsed.Scheme)
 		}
 	case protoOCI:
 		if parsed.Host == "" {
-			return fmt.Errorf("%s: oci URL must include a registry host", fieldName)
+			return errors.New("oci URL must include a registry host")
 		}
 	default:
-		return fmt.Errorf("%s: unsupported protocol %q (must be file://, http://, https://, or oci://)", fieldName, parsed.Scheme)
+		return fmt.Errorf("unsupported protocol %q (must be file://, http://, https://, or oci://)", parsed.Scheme)
 	}
 
 	return nil
 }
 
-func validateAgentImages(images []metal3api.AgentImages) error {
-	if len(images) == 0 {
-		return nil
+// validateIPField validates an optional IP address field.
+func validateIPField(ip string, fldPath *field.Path) field.ErrorList {
+	if err := validateIP(ip); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, ip, err.Error())}
 	}
+	return nil
+}
 
+func validateAgentImages(images []metal3api.AgentImages, fldPath *field.Path) (errs field.ErrorList) {
 	seenArchitectures := make(map[metal3api.CPUArchitecture]bool)
 
 	for i, img := range images {
-		if strings.TrimSpace(img.Kernel) == "" {
-			return fmt.Errorf("overrides.agentImages[%d]: kernel is required", i)
-		}
-		if strings.TrimSpace(img.Initramfs) == "" {
-			return fmt.Errorf("overrides.agentImages[%d]: initramfs is required", i)
-		}
+		imgPath := fldPath.Index(i)
 
-		if err := validateURL(img.Kernel, fmt.Sprintf("overrides.agentImages[%d].kernel", i)); err != nil {
-			return err
+		if strings.TrimSpace(img.Kernel) == "" {
+			errs = append(errs, field.Required(imgPath.Child("kernel"), "kernel is required"))
+		} else if err := validateURL(img.Kernel); err != nil {
+			errs = append(errs, field.Invalid(imgPath.Child("kernel"), img.Kernel, err.Error()))
 		}
 
-		if err := validateURL(img.Initramfs, fmt.Sprintf("overrides.agentImages[%d].initramfs", i)); err != nil {
-			return err
+		if strings.TrimSpace(img.Initramfs) == "" {
+			errs = append(errs, field.Required(imgPath.Child("initramfs"), "initramfs is required"))
+		} else if err := validateURL(img.Initramfs); err != nil {
+			errs = append(errs, field.Invalid(imgPath.Child("initramfs"), img.Initramfs, err.Error()))
 		}
 
 		if seenArchitectures[img.Architecture] {
 			if img.Architecture == "" {
-				return errors.New("overrides.agentImages: duplicate default (empty architecture) entry")
+				errs = append(errs, field.Invalid(imgPath.Child("architecture"), img.Architecture, "duplicate default (empty architecture) entry"))
+			} else {
+				errs = append(errs, field.Duplicate(imgPath.Child("architecture"), img.Architecture))
 			}
-			return fmt.Errorf("overrides.agentImages: duplicate architecture %q", img.Architecture)
 		}
 		seenArchitectures[img.Architecture] = true
 	}
 
-	return nil
+	return errs
 }
 
 // validateRangeOrder rejects reversed DHCP ranges, which dnsmasq refuses at
@@ -132,69 +140,69 @@ func validateRangeOrder(beginStr, endStr string) error {
 	return nil
 }
 
-func validateDHCPRange(r metal3api.DHCPRange, idx int) error {
-	prefix := fmt.Sprintf("networking.dhcp.extraRanges[%d]", idx)
+func validateDHCPRange(r metal3api.DHCPRange, fldPath *field.Path) (errs field.ErrorList) {
+	cidrPath := fldPath.Child("networkCIDR")
 
 	if r.NetworkCIDR == "" {
-		return fmt.Errorf("%s.networkCIDR is required", prefix)
+		return append(errs, field.Required(cidrPath, "networkCIDR is required"))
 	}
 
 	cidr, err := netip.ParsePrefix(r.NetworkCIDR)
 	if err != nil {
-		return fmt.Errorf("%s.networkCIDR is invalid: %w", prefix, err)
+		return append(errs, field.Invalid(cidrPath, r.NetworkCIDR, err.Error()))
 	}
 
 	if cidr.Bits() == 0 {
-		return fmt.Errorf("%s.networkCIDR must have a non-zero prefix length", prefix)
-	}
-
-	if r.RangeBegin == "" || r.RangeEnd == "" {
-		return fmt.Errorf("%s: rangeBegin and rangeEnd are required", prefix)
+		return append(errs, field.Invalid(cidrPath, r.NetworkCIDR, "networkCIDR must have a non-zero prefix length"))
 	}
 
-	cidrField := prefix + ".networkCIDR"
-
-	if err := validateIPinPrefix(r.RangeBegin, cidr, cidrField); err != nil {
-		return fmt.Errorf("%s.rangeBegin: %w", prefix, err)
+	if r.RangeBegin == "" {
+		errs = append(errs, field.Required(fldPath.Child("rangeBegin"), "rangeBegin and rangeEnd are required"))
+	} else if err := validateIPinPrefix(r.RangeBegin, cidr, cidrPath.String()); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("rangeBegin"), r.RangeBegin, err.Error()))
 	}
 
-	if err := validateIPinPrefix(r.RangeEnd, cidr, cidrField); err != nil {
-		return fmt.Errorf("%s.rangeEnd: %w", prefix, err)
+	if r.RangeEnd == "" {
+		errs = append(errs, field.Required(fldPath.Child("rangeEnd"), "rangeBegin and rangeEnd are required"))
+	} else if err := validateIPinPrefix(r.RangeEnd, cidr, cidrPath.String()); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("rangeEnd"), r.RangeEnd, err.Error()))
 	}
 
 	if err := validateRangeOrder(r.RangeBegin, r.RangeEnd); err != nil {
-		return fmt.Errorf("%s: %w", prefix, err)
+		errs = append(errs, field.Invalid(fldPath.Child("rangeBegin"), r.RangeBegin, err.Error()))
 	}
 
 	if r.GatewayAddress != "" {
+		gatewayPath := fldPath.Child("gatewayAddress")
 		if cidr.Addr().Is6() {
-			return fmt.Errorf("%s.gatewayAddress: IPv6 per-range gateway is not supported", prefix)
-		}
-		if err := validateIPinPrefix(r.GatewayAddress, cidr, cidrField); err != nil {
-			return fmt.Errorf("%s.gatewayAddress: %w", prefix, err)
+			errs = append(errs, field.Invalid(gatewayPath, r.GatewayAddress, "IPv6 per-range gateway is not supported"))
+		} else if err := validateIPinPrefix(r.GatewayAddress, cidr, cidrPath.String()); err != nil {
+			errs = append(errs, field.Invalid(gatewayPath, r.GatewayAddress, err.Error()))
 		}
 	}
 
-	return nil
+	return errs
 }
 
+// ValidateDHCP validates the DHCP settings of the provided Ironic.
 func ValidateDHCP(ironic *metal3api.IronicSpec) error {
+	return validateDHCP(ironic, field.NewPath("spec", "networking")).ToAggregate()
+}
+
+func validateDHCP(ironic *metal3api.IronicSpec, netPath *field.Path) (errs field.ErrorList) {
 	dhcp := ironic.Networking.DHCP
+	fldPath := netPath.Child("dhcp")
+
 	hasNetworking := ironic.Networking.IPAddress != "" || ironic.Networking.Interface != "" || len(ironic.Networking.MACAddresses) > 0
 	if !hasNetworking {
-		return errors.New("networking: at least one of ipAddress, interface or macAddresses is required when DHCP is used")
+		errs = append(errs, field.Required(netPath, "at least one of ipAddress, interface or macAddresses is required when DHCP is used"))
 	}
 	if dhcp.ServeDNS && dhcp.DNSAddress != "" {
-		return errors.New("networking.dhcp.dnsAddress cannot set together with serveDNS")
+		errs = append(errs, field.Forbidden(fldPath.Child("dnsAddress"), "dnsAddress cannot set together with serveDNS"))
 	}
 
-	if err := validateIP(dhcp.DNSAddress); err != nil {
-		return err
-	}
-
-	if err := validateIP(dhcp.GatewayAddress); err != nil {
-		return err
-	}
+	errs = append(errs, validateIPField(dhcp.DNSAddress, fldPath.Child("dnsAddress"))...)
+	errs = append(errs, validateIPField(dhcp.GatewayAddress, fldPath.Child("gatewayAddress"))...)
 
 	// The main range fields are all-or-nothing: leaving networkCIDR,
 	// rangeBegin and rangeEnd unset disables the main range so only
@@ -210,60 +218,59 @@ func ValidateDHCP(ironic *metal3api.IronicSpec) error {
 
 	switch {
 	case mainFieldsSet != 0 && !hasMainRange:
-		return errors.New("networking.dhcp: networkCIDR, rangeBegin and rangeEnd must be set together")
+		errs = append(errs, field.Invalid(fldPath, "", "networkCIDR, rangeBegin and rangeEnd must be set together"))
 	case !hasMainRange && len(dhcp.ExtraRanges) == 0:
-		return errors.New("networking.dhcp: networkCIDR, rangeBegin and rangeEnd are required unless extraRanges is set")
+		errs = append(errs, field.Required(fldPath, "networkCIDR, rangeBegin and rangeEnd are required unless extraRanges is set"))
 	}
 
 	if hasMainRange {
+		cidrPath := fldPath.Child("networkCIDR")
 		provCIDR, err := netip.ParsePrefix(dhcp.NetworkCIDR)
 		if err != nil {
-			return fmt.Errorf("networking.dhcp.networkCIDR is invalid: %w", err)
-		}
-
-		if err := validateIPinPrefix(dhcp.RangeBegin, provCIDR, "networking.dhcp.networkCIDR"); err != nil {
-			return err
-		}
+			errs = append(errs, field.Invalid(cidrPath, dhcp.NetworkCIDR, err.Error()))
+		} else {
+			if err := validateIPinPrefix(dhcp.RangeBegin, provCIDR, cidrPath.String()); err != nil {
+				errs = append(errs, field.Invalid(fldPath.Child("rangeBegin"), dhcp.RangeBegin, err.Error()))
+			}
 
-		if err := validateIPinPrefix(dhcp.RangeEnd, provCIDR, "networking.dhcp.networkCIDR"); err != nil {
-			return err
-		}
+			if err := validateIPinPrefix(dhcp.RangeEnd, provCIDR, cidrPath.String()); err != nil {
+				errs = append(errs, field.Invalid(fldPath.Child("rangeEnd"), dhcp.RangeEnd, err.Error()))
+			}
 
-		if err := validateRangeOrder(dhcp.RangeBegin, dhcp.RangeEnd); err != nil {
-			return fmt.Errorf("networking.dhcp: %w", err)
-		}
+			if err := validateRangeOrder(dhcp.RangeBegin, dhcp.RangeEnd); err != nil {
+				errs = append(errs, field.Invalid(fldPath.Child("rangeBegin"), dhcp.RangeBegin, err.Error()))
+			}
 
-		// The main range is a direct-attached subnet by definition, so the
-		// provisioning IP must live in it. Subnets reached via a DHCP relay
-		// belong in extraRanges.
-		if ironic.Networking.IPAddress != "" {
-			provIP, _ := netip.ParseAddr(ironic.Networking.IPAddress)
-			if !provCIDR.Contains(provIP) {
-				return errors.New("networking.dhcp.networkCIDR must contain networking.ipAddress")
+			// The main range is a direct-attached subnet by definition, so the
+			// provisioning IP must live in it. Subnets reached via a DHCP relay
+			// belong in extraRanges.
+			// An invalid IP address is reported separately.
+			if provIP, err := netip.ParseAddr(ironic.Networking.IPAddress); err == nil {
+				if !provCIDR.Contains(provIP) {
+					errs = append(errs, field.Invalid(cidrPath, dhcp.NetworkCIDR, "networkCIDR must contain "+netPath.Child("ipAddress").String()))
+				}
 			}
 		}
 	}
 
 	for i, r := range dhcp.ExtraRanges {
-		if err := validateDHCPRange(r, i); err != nil {
-			return err
-		}
+		errs = append(errs, validateDHCPRange(r, fldPath.Child("extraRanges").Index(i))...)
 	}
 
-	return validateNoPoolOverlap(dhcp)
+	return append(errs, validateNoPoolOverlap(dhcp, fldPath)...)
 }
 
 // validateNoPoolOverlap rejects DHCP address pools (main and extra ranges)
 // that overlap each other: dnsmasq either refuses to start or allocates
 // leases ambiguously between the overlapping pools.
-func validateNoPoolOverlap(dhcp *metal3api.DHCP) error {
+func validateNoPoolOverlap(dhcp *metal3api.DHCP, fldPath *field.Path) (errs field.ErrorList) {
 	type pool struct {
 		begin, end netip.Addr
-		field      string
+		field      *field.Path
 	}
 
 	pools := make([]pool, 0, len(dhcp.ExtraRanges)+1)
-	addPool := func(beginStr, endStr, field string) {
+	addPool := func(beginStr, endStr string, fldPath *field.Path) {
 		begin, err := netip.ParseAddr(beginStr)
 		if err != nil {
 			return
@@ -272,203 +279,378 @@ func validateNoPoolOverlap(dhcp *metal3api.DHCP) error {
 		if err != nil {
 			return
 		}
-		pools = append(pools, pool{begin: begin, end: end, field: field})
+		pools = append(pools, pool{begin: begin, end: end, field: fldPath})
 	}
 
-	addPool(dhcp.RangeBegin, dhcp.RangeEnd, "networking.dhcp")
+	addPool(dhcp.RangeBegin, dhcp.RangeEnd, fldPath)
 	for i, r := range dhcp.ExtraRanges {
-		addPool(r.RangeBegin, r.RangeEnd, fmt.Sprintf("networking.dhcp.extraRanges[%d]", i))
+		addPool(r.RangeBegin, r.RangeEnd, fldPath.Child("extraRanges").Index(i))
 	}
 
 	for i := 1; i < len(pools); i++ {
 		for j := range i {
 			if pools[i].begin.Compare(pools[j].end) <= 0 && pools[j].begin.Compare(pools[i].end) <= 0 {
-				return fmt.Errorf("DHCP pools of %s and %s overlap", pools[j].field, pools[i].field)
+				errs = append(errs, field.Invalid(pools[i].field, "", fmt.Sprintf("DHCP pools of %s and %s overlap", pools[j].field, pools[i].field)))
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func ValidateIronic(ironic *metal3api.IronicSpec, old *metal3api.IronicSpec) error {
+func validateKeepalived(ironic *metal3api.IronicSpec, netPath *field.Path) (errs field.ErrorList) {
+	if ironic.Networking.IPAddressManager == metal3api.IPAddressManagerKeepalived { //nolint:staticcheck // backward compat
+		fldPath := netPath.Child("ipAddressManager")
+		if ironic.HighAvailability {
+			errs = append(errs, field.Forbidden(fldPath, "keepalived is not compatible with the highly available architecture"))
+		}
+		if ironic.Networking.IPAddress == "" || ironic.Networking.Interface == "" {
+			errs = append(errs, field.Required(netPath, "keepalived requires specifying both ipAddress and interface"))
+		}
+	}
+
+	if ironic.Networking.Keepalived != nil && ironic.Networking.Keepalived.Enabled {
+		fldPath := netPath.Child("keepalived")
+		if ironic.Networking.IPAddressManager == metal3api.IPAddressManagerKeepalived { //nolint:staticcheck // backward compat
+			errs = append(errs, field.Forbidden(fldPath, "keepalived and ipAddressManager cannot be used together"))
+			// Avoid duplicating errors reported for ipAddressManager
+			return errs
+		}
+		if ironic.HighAvailability {
+			errs = append(errs, field.Forbidden(fldPath, "keepalived is not compatible with the highly available architecture"))
+		}
+		if ironic.Networking.IPAddress == "" || ironic.Networking.Interface == "" {
+			errs = append(errs, field.Required(netPath, "keepalived requires specifying both ipAddress and interface"))
+		}
+		for i, entry := range ironic.Networking.Keepalived.AdditionalVIPs {
+			entryPath := fldPath.Child("additionalVIPs").Index(i)
+			if entry.IPAddress == "" {
+				errs = append(errs, field.Required(entryPath.Child("ipAddress"), "ipAddress is required"))
+			} else {
+				errs = append(errs, validateIPField(entry.IPAddress, entryPath.Child("ipAddress"))...)
+			}
+			if entry.Interface == "" {
+				errs = append(errs, field.Required(entryPath.Child("interface"), "interface is required"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validatePrometheusExporter(ironic *metal3api.IronicSpec, fldPath *field.Path) (errs field.ErrorList) {
+	exporter := ironic.PrometheusExporter
+	if exporter == nil {
+		return nil
+	}
+
+	if ironic.HighAvailability && !exporter.DisableServiceMonitor {
+		errs = append(errs, field.Forbidden(fldPath.Child("disableServiceMonitor"), "ServiceMonitor support is currently incompatible with the highly available architecture"))
+	}
+
+	if exporter.BindAddress != "" && net.ParseIP(exporter.BindAddress) == nil {
+		errs = append(errs, field.Invalid(fldPath.Child("bindAddress"), exporter.BindAddress,
+			fmt.Sprintf("bindAddress %q is not a valid IP address", exporter.BindAddress)))
+	}
+
+	if exporter.Enabled && !exporter.DisableServiceMonitor {
+		bindAddr := exporter.BindAddress
+		if bindAddr == "" {
+			bindAddr = defaultMetricsBindAddr
+		}
+		ip := net.ParseIP(bindAddr)
+		if ip != nil && ip.IsLoopback() {
+			errs = append(errs, field.Invalid(fldPath.Child("bindAddress"), bindAddr,
+				fmt.Sprintf("ServiceMonitor is not compatible with a loopback bindAddress %q, since the metrics endpoint is not reachable from remote Prometheus instances; set a non-loopback bindAddress or set disableServiceMonitor to true", bindAddr)))
+		}
+	}
+
+	return errs
+}
+
+func validateSelfSignedCertificate(ironic *metal3api.IronicSpec, fldPath *field.Path) (errs field.ErrorList) {
+	selfSigned := ironic.TLS.SelfSigned
+	if selfSigned == nil {
+		return nil
+	}
+
+	if ironic.HighAvailability && (ironic.TLS.InsecureRPC == nil || !*ironic.TLS.InsecureRPC) {
+		errs = append(errs, field.Forbidden(fldPath, "the generated certificate does not cover the addresses of all replicas, set insecureRPC with highAvailability"))
+	}
+
+	duration := certificateDuration(selfSigned)
+	renewBefore := certificateRenewBefore(selfSigned)
+	if duration <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("duration"), duration.String(), "duration must be positive"))
+	}
+	if renewBefore <= 0 || renewBefore >= duration {
+		errs = append(errs, field.Invalid(fldPath.Child("renewBefore"), renewBefore.String(), "renewBefore must be positive and shorter than duration"))
+	}
+
+	return errs
+}
+
+func validateCredentialsRotation(rotation *metal3api.CredentialsRotation, fldPath *field.Path) (errs field.ErrorList) {
+	if rotation == nil {
+		return nil
+	}
+
+	if rotation.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("interval"), rotation.Interval.Duration.String(), "interval must be positive"))
+	}
+
+	return errs
+}
+
+func validateUpgradePolicy(policy *metal3api.UpgradePolicy, fldPath *field.Path) (errs field.ErrorList) {
+	if policy == nil {
+		return nil
+	}
+
+	if timeout := policy.NodeOperationsTimeout; timeout != nil && timeout.Duration < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("nodeOperationsTimeout"), timeout.Duration.String(), "nodeOperationsTimeout cannot be negative"))
+	}
+
+	if policy.HistoryLimit < 0 || policy.HistoryLimit > maxUpgradeHistoryLimit {
+		errs = append(errs, field.Invalid(fldPath.Child("historyLimit"), policy.HistoryLimit, fmt.Sprintf("historyLimit must be between 1 and %d", maxUpgradeHistoryLimit)))
+	}
+
+	for _, version := range slices.Sorted(maps.Keys(policy.IntermediateImages)) {
+		imagePath := fldPath.Child("intermediateImages").Key(version)
+		parsed, err := metal3api.ParseVersion(version)
+		if err != nil {
+			errs = append(errs, field.Invalid(imagePath, version, err.Error()))
+		} else if _, ok := metal3api.SupportedVersions[parsed]; !ok || parsed.IsLatest() {
+			errs = append(errs, field.Invalid(imagePath, version, "not a supported intermediate version"))
+		}
+		if policy.IntermediateImages[version] == "" {
+			errs = append(errs, field.Required(imagePath, "image cannot be empty"))
+		}
+	}
+
+	return errs
+}
+
+func validateMaintenanceWindow(window *metal3api.MaintenanceWindow, fldPath *field.Path) (errs field.ErrorList) {
+	if window == nil {
+		return nil
+	}
+
+	if _, err := parseSchedule(window.Schedule); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("schedule"), window.Schedule, fmt.Sprintf("invalid schedule: %v", err)))
+	}
+
+	if window.Duration.Duration <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("duration"), window.Duration.Duration.String(), "duration must be positive"))
+	}
+
+	return errs
+}
+
+func validateDatabaseBackup(backup *metal3api.DatabaseBackup, fldPath *field.Path) (errs field.ErrorList) {
+	if backup == nil {
+		return nil
+	}
+
+	if (backup.PersistentVolumeClaimName == "") == (backup.ObjectStorage == nil) {
+		errs = append(errs, field.Invalid(fldPath, "", "exactly one of persistentVolumeClaimName and objectStorage must be set"))
+	}
+
+	if backup.Retain < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("retain"), backup.Retain, "retain cannot be negative"))
+	} else if backup.Retain > maxBackupRetain {
+		errs = append(errs, field.Invalid(fldPath.Child("retain"), backup.Retain, fmt.Sprintf("retain cannot be more than %d", maxBackupRetain)))
+	}
+
+	if storage := backup.ObjectStorage; storage != nil {
+		storagePath := fldPath.Child("objectStorage")
+		if parsed, err := url.Parse(storage.Endpoint); err != nil || (parsed.Scheme != protoHTTP && parsed.Scheme != protoHTTPS) || parsed.Host == "" {
+			errs = append(errs, field.Invalid(storagePath.Child("endpoint"), storage.Endpoint, "endpoint must be an http or https URL"))
+		}
+		if storage.Bucket == "" {
+			errs = append(errs, field.Required(storagePath.Child("bucket"), "bucket is required"))
+		}
+		if storage.CredentialsName == "" {
+			errs = append(errs, field.Required(storagePath.Child("credentialsName"), "credentialsName is required"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateIronic validates the Ironic specification (and optionally a
+// transition from the old one) and returns all errors found.
+func ValidateIronic(ironic *metal3api.IronicSpec, old *metal3api.IronicSpec) (errs field.ErrorList) {
+	specPath := field.NewPath("spec")
+	netPath := specPath.Child("networking")
+
 	if ironic.HighAvailability && ironic.Database == nil {
-		return errors.New("database is required for highly available architecture")
+		errs = append(errs, field.Required(specPath.Child("database"), "database is required for highly available architecture"))
 	}
 
-	if old != nil && old.Database != nil && ironic.Database != nil && !reflect.DeepEqual(old.Database, ironic.Database) {
-		return errors.New("cannot change to a new database")
+	// Secrets can be replaced (e.g. to rotate credentials), but not the database itself
+	if old != nil && old.Database != nil && ironic.Database != nil && (old.Database.Host != ironic.Database.Host || old.Database.Name != ironic.Database.Name) {
+		errs = append(errs, field.Forbidden(specPath.Child("database"), "cannot change to a new database"))
 	}
 
 	if ironic.Database != nil && (ironic.Database.CredentialsName == "" || ironic.Database.Host == "" || ironic.Database.Name == "") {
-		return errors.New("credentialsName, host and name are required on database")
+		errs = append(errs, field.Required(specPath.Child("database"), "credentialsName, host and name are required on database"))
 	}
 
-	if ironic.Networking.DisableHostNetwork &&
-		(ironic.Networking.BindInterface || ironic.Networking.DHCP != nil || ironic.Networking.Interface != "" || ironic.Networking.IPAddress != "" || len(ironic.Networking.MACAddresses) > 0 || ironic.Networking.Keepalived != nil) {
-		return errors.New("networking.disableHostNetwork cannot be set to true together with networking.bindInterface or networking.dhcp or networking.interface or networking.ipAddress or networking.macAddresses or networking.keepalived")
+	if ironic.Database != nil && ironic.Database.ClientCertificateName != "" && ironic.Database.TLSCertificateName == "" {
+		errs = append(errs, field.Forbidden(specPath.Child("database", "clientCertificateName"), "clientCertificateName requires tlsCertificateName"))
 	}
 
-	if err := validateIP(ironic.Networking.IPAddress); err != nil {
-		return err
+	if ironic.Database != nil {
+		errs = append(errs, validateDatabaseBackup(ironic.Database.Backup, specPath.Child("database", "backup"))...)
 	}
 
-	if err := validateIP(ironic.Networking.ExternalIP); err != nil {
-		return err
+	if ironic.Networking.DisableHostNetwork &&
+		(ironic.Networking.BindInterface || ironic.Networking.DHCP != nil || ironic.Networking.Interface != "" || ironic.Networking.IPAddress != "" || len(ironic.Networking.MACAddresses) > 0 || ironic.Networking.Keepalived != nil) {
+		errs = append(errs, field.Forbidden(netPath.Child("disableHostNetwork"),
+			"networking.disableHostNetwork cannot be set to true together with networking.bindInterface or networking.dhcp or networking.interface or networking.ipAddress or networking.macAddresses or networking.keepalived"))
 	}
 
+	errs = append(errs, validateIPField(ironic.Networking.IPAddress, netPath.Child("ipAddress"))...)
+	errs = append(errs, validateIPField(ironic.Networking.ExternalIP, netPath.Child("externalIP"))...)
+
 	if ironic.Networking.ExternalIP != "" &&
 		(ironic.Networking.Ingress != nil || ironic.Networking.ExternalCallbackURL != "" || ironic.Networking.ImageServerExternalURL != "") {
-		return errors.New("networking.externalIP cannot be set together with networking.ingress or networking.externalCallbackURL or networking.imageServerExternalURL")
+		errs = append(errs, field.Forbidden(netPath.Child("externalIP"),
+			"networking.externalIP cannot be set together with networking.ingress or networking.externalCallbackURL or networking.imageServerExternalURL"))
 	}
 
 	if ironic.Networking.Ingress == nil &&
 		((ironic.Networking.ImageServerExternalURL != "" && ironic.Networking.ExternalCallbackURL == "") ||
 			(ironic.Networking.ImageServerExternalURL == "" && ironic.Networking.ExternalCallbackURL != "")) {
-		return errors.New("when networking.ingress is not set, networking.externalCallbackURL and networking.imageServerExternalURL must be set together")
+		fldPath := netPath.Child("externalCallbackURL")
+		if ironic.Networking.ImageServerExternalURL == "" {
+			fldPath = netPath.Child("imageServerExternalURL")
+		}
+		errs = append(errs, field.Required(fldPath,
+			"when networking.ingress is not set, networking.externalCallbackURL and networking.imageServerExternalURL must be set together"))
 	}
 
 	if ironic.HighAvailability && ironic.Networking.IPAddress != "" {
-		return errors.New("networking.ipAddress makes no sense with highly available architecture")
+		errs = append(errs, field.Forbidden(netPath.Child("ipAddress"), "networking.ipAddress makes no sense with highly available architecture"))
 	}
 
 	if ironic.Networking.DHCP != nil {
 		if ironic.HighAvailability {
-			return errors.New("DHCP support is not implemented in the highly available architecture")
-		}
-
-		if err := ValidateDHCP(ironic); err != nil {
-			return err
+			errs = append(errs, field.Forbidden(netPath.Child("dhcp"), "DHCP support is not implemented in the highly available architecture"))
+		} else {
+			errs = append(errs, validateDHCP(ironic, netPath)...)
 		}
 	}
 
-	if ironic.Networking.IPAddressManager == metal3api.IPAddressManagerKeepalived { //nolint:staticcheck // backward compat
-		if ironic.HighAvailability {
-			return errors.New("networking: keepalived is not compatible with the highly available architecture")
-		}
-		if ironic.Networking.IPAddress == "" || ironic.Networking.Interface == "" {
-			return errors.New("networking: keepalived requires specifying both ipAddress and interface")
+	errs = append(errs, validateKeepalived(ironic, netPath)...)
+	errs = append(errs, validatePrometheusExporter(ironic, specPath.Child("prometheusExporter"))...)
+	for i, name := range ironic.ExtraAPICredentialsNames {
+		if name == "" || name == ironic.APICredentialsName {
+			errs = append(errs, field.Invalid(specPath.Child("extraAPICredentialsNames").Index(i), name,
+				"must be a non-empty secret name different from apiCredentialsName"))
 		}
 	}
+	errs = append(errs, validateCredentialsRotation(ironic.APICredentialsRotation, specPath.Child("apiCredentialsRotation"))...)
+	errs = append(errs, validateUpgradePolicy(ironic.UpgradePolicy, specPath.Child("upgradePolicy"))...)
+	errs = append(errs, validateMaintenanceWindow(ironic.MaintenanceWindow, specPath.Child("maintenanceWindow"))...)
 
-	if ironic.Networking.Keepalived != nil && ironic.Networking.Keepalived.Enabled {
-		if ironic.Networking.IPAddressManager == metal3api.IPAddressManagerKeepalived { //nolint:staticcheck // backward compat
-			return errors.New("networking: keepalived and ipAddressManager cannot be used together")
-		}
-		if ironic.HighAvailability {
-			return errors.New("networking: keepalived is not compatible with the highly available architecture")
-		}
-		if ironic.Networking.IPAddress == "" || ironic.Networking.Interface == "" {
-			return errors.New("networking: keepalived requires specifying both ipAddress and interface")
-		}
-		for i, entry := range ironic.Networking.Keepalived.AdditionalVIPs {
-			if entry.IPAddress == "" {
-				return fmt.Errorf("networking.keepalived.additionalVIPs[%d]: ipAddress is required", i)
-			}
-			if err := validateIP(entry.IPAddress); err != nil {
-				return fmt.Errorf("networking.keepalived.additionalVIPs[%d]: %w", i, err)
-			}
-			if entry.Interface == "" {
-				return fmt.Errorf("networking.keepalived.additionalVIPs[%d]: interface is required", i)
-			}
-		}
+	if ironic.HighAvailability && !metal3api.CurrentFeatureGate.Enabled(metal3api.FeatureHighAvailability) {
+		errs = append(errs, field.Forbidden(specPath.Child("highAvailability"), "highly available architecture is disabled via feature gate"))
 	}
 
-	if ironic.HighAvailability && ironic.PrometheusExporter != nil && !ironic.PrometheusExporter.DisableServiceMonitor {
-		return errors.New("ServiceMonitor support is currently incompatible with the highly available architecture")
+	if !ironic.HighAvailability && ironic.TLS.InsecureRPC != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("tls", "insecureRPC"), "insecureRPC makes no sense without highAvailability"))
 	}
 
-	if ironic.PrometheusExporter != nil && ironic.PrometheusExporter.BindAddress != "" {
-		ip := net.ParseIP(ironic.PrometheusExporter.BindAddress)
-		if ip == nil {
-			return fmt.Errorf("prometheusExporter: bindAddress %q is not a valid IP address", ironic.PrometheusExporter.BindAddress)
+	errs = append(errs, validateSelfSignedCertificate(ironic, specPath.Child("tls", "selfSigned"))...)
+	if issuer := ironic.TLS.CertificateIssuer; issuer != nil {
+		if ironic.TLS.SelfSigned != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("tls", "certificateIssuer"), "selfSigned and certificateIssuer cannot be used together"))
+		}
+		if issuer.Name == "" {
+			errs = append(errs, field.Required(specPath.Child("tls", "certificateIssuer", "name"), "issuer name is required"))
 		}
 	}
 
-	if ironic.PrometheusExporter != nil && ironic.PrometheusExporter.Enabled && !ironic.PrometheusExporter.DisableServiceMonitor {
-		bindAddr := ironic.PrometheusExporter.BindAddress
-		if bindAddr == "" {
-			bindAddr = defaultMetricsBindAddr
-		}
-		ip := net.ParseIP(bindAddr)
-		if ip != nil && ip.IsLoopback() {
-			return fmt.Errorf("ServiceMonitor is not compatible with a loopback bindAddress %q, since the metrics endpoint is not reachable from remote Prometheus instances; set a non-loopback bindAddress or set disableServiceMonitor to true", bindAddr)
+	for idx, threshold := range ironic.TLS.CertificateExpirationThresholds {
+		if threshold.Duration <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child("tls", "certificateExpirationThresholds").Index(idx), threshold.Duration.String(), "thresholds must be positive"))
 		}
 	}
 
-	if ironic.HighAvailability && !metal3api.CurrentFeatureGate.Enabled(metal3api.FeatureHighAvailability) {
-		return errors.New("highly available architecture is disabled via feature gate")
+	switch ironic.TLS.MinVersion {
+	case "", metal3api.TLSVersion12, metal3api.TLSVersion13:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("tls", "minVersion"), ironic.TLS.MinVersion,
+			[]metal3api.TLSVersion{metal3api.TLSVersion12, metal3api.TLSVersion13}))
 	}
-
-	if !ironic.HighAvailability && ironic.TLS.InsecureRPC != nil {
-		return errors.New("insecureRPC makes no sense without highAvailability")
+	for idx, name := range ironic.TLS.CipherSuites {
+		if err := validateCipherSuite(name); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("tls", "cipherSuites").Index(idx), name, err.Error()))
+		}
 	}
 
 	// Validate TLS CA settings
-	if err := validateCASettings(&ironic.TLS); err != nil {
-		return err
-	}
+	errs = append(errs, validateCASettings(&ironic.TLS, specPath.Child("tls"))...)
 
 	if ironic.Version != "" {
 		if err := metal3api.ValidateVersion(ironic.Version); err != nil {
-			return err
+			errs = append(errs, field.Invalid(specPath.Child("version"), ironic.Version, err.Error()))
 		}
 	}
 
 	if ironic.Overrides != nil {
-		if err := validateAgentImages(ironic.Overrides.AgentImages); err != nil {
-			return err
-		}
+		errs = append(errs, validateAgentImages(ironic.Overrides.AgentImages, specPath.Child("overrides", "agentImages"))...)
 	}
 
 	if ironic.NetworkingService != nil && ironic.NetworkingService.Enabled {
-		if err := validateNetworkingService(ironic); err != nil {
-			return err
-		}
+		errs = append(errs, validateNetworkingService(ironic, specPath.Child("networkingService"))...)
 	}
 
-	return nil
+	return errs
 }
 
-func validateNetworkingService(ironic *metal3api.IronicSpec) error {
+func validateNetworkingService(ironic *metal3api.IronicSpec, fldPath *field.Path) (errs field.ErrorList) {
 	// Validate provider network configs if provided
 	seenTypes := make(map[metal3api.ProviderNetworkType]bool)
 	for i := range ironic.NetworkingService.ProviderNetworks {
 		pn := &ironic.NetworkingService.ProviderNetworks[i]
+		pnPath := fldPath.Child("providerNetworks").Index(i)
 		if seenTypes[pn.Type] {
-			return fmt.Errorf("duplicate provider network type %q", pn.Type)
+			errs = append(errs, field.Duplicate(pnPath.Child("type"), pn.Type))
 		}
 		seenTypes[pn.Type] = true
-		if err := validateProviderNetwork(pn); err != nil {
-			return fmt.Errorf("networkingService.providerNetworks[%d] (%s): %w", i, pn.Type, err)
-		}
+		errs = append(errs, validateProviderNetwork(pn, pnPath)...)
 	}
 
-	return nil
+	return errs
 }
 
-func validateProviderNetwork(sn *metal3api.ProviderNetworkConfig) error {
+func validateProviderNetwork(sn *metal3api.ProviderNetworkConfig, fldPath *field.Path) (errs field.ErrorList) {
+	vlansPath := fldPath.Child("allowedVLANs")
+
 	// Validate mode-specific requirements
 	switch sn.Mode {
 	case metal3api.SwitchportModeAccess:
 		if len(sn.AllowedVLANs) > 0 {
-			return errors.New("allowedVLANs cannot be set in access mode")
+			errs = append(errs, field.Forbidden(vlansPath, "allowedVLANs cannot be set in access mode"))
 		}
 	case metal3api.SwitchportModeTrunk, metal3api.SwitchportModeHybrid:
 		if len(sn.AllowedVLANs) == 0 {
-			return fmt.Errorf("allowedVLANs required for %s mode", sn.Mode)
+			errs = append(errs, field.Required(vlansPath, fmt.Sprintf("allowedVLANs required for %s mode", sn.Mode)))
 		}
 	default:
-		return fmt.Errorf("invalid switchport mode: %s", sn.Mode)
+		errs = append(errs, field.Invalid(fldPath.Child("mode"), sn.Mode, fmt.Sprintf("invalid switchport mode: %s", sn.Mode)))
 	}
 
-	for _, entry := range sn.AllowedVLANs {
+	for i, entry := range sn.AllowedVLANs {
 		if err := validateAllowedVLANEntry(entry); err != nil {
-			return err
+			errs = append(errs, field.Invalid(vlansPath.Index(i), entry, err.Error()))
 		}
 	}
 
-	return nil
+	return errs
 }
 
 func validateVLANID(s string) (int, error) {
@@ -502,67 +684,90 @@ func validateAllowedVLANEntry(entry string) error {
 	return nil
 }
 
-func validateCASettings(tls *metal3api.TLS) error {
+func validateCASettings(tls *metal3api.TLS, fldPath *field.Path) (errs field.ErrorList) {
 	// Validate BMCCA
 	if tls.BMCCA != nil {
 		if tls.BMCCA.Name == "" {
-			return errors.New("tls.bmcCA.name is required when tls.bmcCA is set")
+			errs = append(errs, field.Required(fldPath.Child("bmcCA", "name"), "tls.bmcCA.name is required when tls.bmcCA is set"))
 		}
 		// Both old and new fields are set - validate they're consistent
 		if tls.BMCCAName != "" && (tls.BMCCA.Kind != metal3api.ResourceKindSecret || tls.BMCCA.Name != tls.BMCCAName) {
-			return errors.New("tls.bmcCA and tls.bmcCAName are both set but inconsistent; use tls.bmcCA only")
+			errs = append(errs, field.Invalid(fldPath.Child("bmcCAName"), tls.BMCCAName, "tls.bmcCA and tls.bmcCAName are both set but inconsistent; use tls.bmcCA only"))
+		}
+	}
+
+	// Validate ClientCA
+	if tls.ClientCA != nil {
+		if tls.ClientCA.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("clientCA", "name"), "tls.clientCA.name is required when tls.clientCA is set"))
+		}
+		if tls.CertificateName == "" && tls.SelfSigned == nil && tls.CertificateIssuer == nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("clientCA"), "client certificates require TLS to be enabled"))
+		}
+	} else if tls.ClientCertificateOnly {
+		errs = append(errs, field.Forbidden(fldPath.Child("clientCertificateOnly"), "clientCertificateOnly requires tls.clientCA"))
+	}
+
+	for idx, ref := range tls.AdditionalBMCCAs {
+		if ref.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("additionalBMCCAs").Index(idx).Child("name"), "name is required"))
+		}
+	}
+	for idx, ref := range tls.AdditionalTrustedCAs {
+		if ref.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("additionalTrustedCAs").Index(idx).Child("name"), "name is required"))
 		}
 	}
 
 	// Validate TrustedCA
 	if tls.TrustedCA != nil {
 		if tls.TrustedCA.Name == "" {
-			return errors.New("tls.trustedCA.name is required when tls.trustedCA is set")
+			errs = append(errs, field.Required(fldPath.Child("trustedCA", "name"), "tls.trustedCA.name is required when tls.trustedCA is set"))
 		}
 		// Both old and new fields are set - validate they're consistent
 		if tls.TrustedCAName != "" && (tls.TrustedCA.Kind != metal3api.ResourceKindConfigMap || tls.TrustedCA.Name != tls.TrustedCAName) {
-			return errors.New("tls.trustedCA and tls.trustedCAName are both set but inconsistent; use tls.trustedCA only")
+			errs = append(errs, field.Invalid(fldPath.Child("trustedCAName"), tls.TrustedCAName, "tls.trustedCA and tls.trustedCAName are both set but inconsistent; use tls.trustedCA only"))
 		}
 	}
 
-	return nil
+	return errs
 }
 
 // Validate all resources before using them. This method is a superset of
 // ValidateIronic with validations that require access to linked resources.
 func (resources *Resources) Validate() error {
-	if err := ValidateIronic(&resources.Ironic.Spec, nil); err != nil {
-		return err
-	}
+	errs := ValidateIronic(&resources.Ironic.Spec, nil)
 
-	if resources.Ironic.Spec.TLS.TrustedCA != nil {
+	// Keys of generated bundles are checked when building them
+	if resources.Ironic.Spec.TLS.TrustedCA != nil && len(resources.Ironic.Spec.TLS.AdditionalTrustedCAs) == 0 {
 		key := resources.Ironic.Spec.TLS.TrustedCA.Key
-		if key != "" && !resources.hasTrustedCAKey(key) {
-			return fmt.Errorf("resources referenced in tls.trustedCA does not contain the required key %s", key)
+		if key != "" && !hasKey(resources.TrustedCASecret, resources.TrustedCAConfigMap, key) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "tls", "trustedCA", "key"), key,
+				"resources referenced in tls.trustedCA does not contain the required key "+key))
 		}
 	}
 
-	return nil
+	if resources.Ironic.Spec.TLS.ClientCA != nil {
+		key := resources.Ironic.Spec.TLS.ClientCA.Key
+		if key != "" && !hasKey(resources.ClientCASecret, resources.ClientCAConfigMap, key) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "tls", "clientCA", "key"), key,
+				"resources referenced in tls.clientCA does not contain the required key "+key))
+		}
+	}
+
+	return errs.ToAggregate()
 }
 
-func (resources *Resources) hasTrustedCAKey(key string) bool {
+func hasKey(secret *corev1.Secret, configMap *corev1.ConfigMap, key string) bool {
 	switch {
-	case resources.TrustedCASecret != nil:
-		for found := range resources.TrustedCASecret.Data {
-			if found == key {
-				return true
-			}
-		}
-	case resources.TrustedCAConfigMap != nil:
-		for found := range resources.TrustedCAConfigMap.Data {
-			if found == key {
-				return true
-			}
-		}
+	case secret != nil:
+		_, found := secret.Data[key]
+		return found
+	case configMap != nil:
+		_, found := configMap.Data[key]
+		return found
 	default:
 		// Cannot happen in reality but just in case
 		return true
 	}
-
-	return false
 }
@@ -2,9 +2,12 @@ package ironic
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	metal3api "github.com/metal3-io/ironic-standalone-operator/api/v1alpha1"
 )
@@ -406,7 +409,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.keepalived.additionalVIPs[0]: ipAddress is required",
+			ExpectedError: "spec.networking.keepalived.additionalVIPs[0].ipAddress: Required value: ipAddress is required",
 		},
 		{
 			Scenario: "Keepalived additionalVIPs entry with invalid IP",
@@ -422,7 +425,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.keepalived.additionalVIPs[0]: not-an-ip is not a valid IP address",
+			ExpectedError: "spec.networking.keepalived.additionalVIPs[0].ipAddress: Invalid value: \"not-an-ip\": not-an-ip is not a valid IP address",
 		},
 		{
 			Scenario: "Keepalived additionalVIPs entry missing interface",
@@ -438,7 +441,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.keepalived.additionalVIPs[0]: interface is required",
+			ExpectedError: "spec.networking.keepalived.additionalVIPs[0].interface: Required value: interface is required",
 		},
 
 		{
@@ -479,6 +482,92 @@ func TestValidateIronic(t *testing.T) {
 			},
 			ExpectedError: "cannot change to a new database",
 		},
+		{
+			Scenario: "rotate database secrets",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName:       "newtest",
+					Host:                  "example.com",
+					Name:                  "ironic",
+					TLSCertificateName:    "tls",
+					ClientCertificateName: "client",
+				},
+			},
+			OldIronic: &metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "oldtest",
+					Host:            "example.com",
+					Name:            "ironic",
+				},
+			},
+		},
+		{
+			Scenario: "database client certificate without TLS",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName:       "test",
+					Host:                  "example.com",
+					Name:                  "ironic",
+					ClientCertificateName: "client",
+				},
+			},
+			ExpectedError: "clientCertificateName requires tlsCertificateName",
+		},
+		{
+			Scenario: "database backup to object storage",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "test",
+					Host:            "example.com",
+					Name:            "ironic",
+					Backup: &metal3api.DatabaseBackup{
+						ObjectStorage: &metal3api.ObjectStorage{
+							Endpoint:        "http://minio.example.com:9000",
+							Bucket:          "backups",
+							CredentialsName: "minio",
+						},
+						Retain: 5,
+					},
+				},
+			},
+		},
+		{
+			Scenario: "database backup with two targets",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "test",
+					Host:            "example.com",
+					Name:            "ironic",
+					Backup: &metal3api.DatabaseBackup{
+						PersistentVolumeClaimName: "backups",
+						ObjectStorage: &metal3api.ObjectStorage{
+							Endpoint:        "http://minio.example.com:9000",
+							Bucket:          "backups",
+							CredentialsName: "minio",
+						},
+					},
+				},
+			},
+			ExpectedError: "exactly one of persistentVolumeClaimName and objectStorage must be set",
+		},
+		{
+			Scenario: "database backup with invalid endpoint",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "test",
+					Host:            "example.com",
+					Name:            "ironic",
+					Backup: &metal3api.DatabaseBackup{
+						ObjectStorage: &metal3api.ObjectStorage{
+							Endpoint:        "minio.example.com",
+							Bucket:          "backups",
+							CredentialsName: "minio",
+						},
+					},
+				},
+			},
+			ExpectedError: "endpoint must be an http or https URL",
+		},
 		{
 			Scenario: "incomplete database config",
 			Ironic: metal3api.IronicSpec{
@@ -510,7 +599,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking: at least one of ipAddress, interface or macAddresses is required when DHCP is used",
+			ExpectedError: "spec.networking: Required value: at least one of ipAddress, interface or macAddresses is required when DHCP is used",
 		},
 		{
 			Scenario: "serveDNS and dnsAddress configured simultaneously",
@@ -526,7 +615,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.dhcp.dnsAddress cannot set together with serveDNS",
+			ExpectedError: "spec.networking.dhcp.dnsAddress: Forbidden: dnsAddress cannot set together with serveDNS",
 		},
 		{
 			Scenario: "DHCP rangeBegin outside CDIR",
@@ -540,7 +629,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "10.0.0.10 is not in networking.dhcp.networkCIDR",
+			ExpectedError: "10.0.0.10 is not in spec.networking.dhcp.networkCIDR",
 		},
 		{
 			Scenario: "Provisioning IP address not in the CIDR",
@@ -554,7 +643,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.dhcp.networkCIDR must contain networking.ipAddress",
+			ExpectedError: "spec.networking.dhcp.networkCIDR: Invalid value: \"192.168.1.0/24\": networkCIDR must contain spec.networking.ipAddress",
 		},
 		{
 			Scenario: "invalid IP provided for dnsAddress",
@@ -707,7 +796,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "networking.dhcp.networkCIDR must contain networking.ipAddress",
+			ExpectedError: "spec.networking.dhcp.networkCIDR: Invalid value: \"192.168.14.0/24\": networkCIDR must contain spec.networking.ipAddress",
 		},
 		{
 			Scenario: "DHCP with no ranges at all",
@@ -750,7 +839,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "extraRanges[0]: rangeBegin must not be after rangeEnd",
+			ExpectedError: "extraRanges[0].rangeBegin: Invalid value: \"192.168.1.200\": rangeBegin must not be after rangeEnd",
 		},
 		{
 			Scenario: "extra ranges: /0 networkCIDR is rejected",
@@ -872,6 +961,272 @@ func TestValidateIronic(t *testing.T) {
 				},
 			},
 		},
+		{
+			Scenario: "self-signed certificate",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					SelfSigned: &metal3api.SelfSignedCertificate{},
+				},
+			},
+		},
+		{
+			Scenario: "self-signed certificate renewed too late",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					SelfSigned: &metal3api.SelfSignedCertificate{
+						Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+						RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+			},
+			ExpectedError: "renewBefore must be positive and shorter than duration",
+		},
+		{
+			Scenario: "self-signed certificate and issuer",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					SelfSigned:        &metal3api.SelfSignedCertificate{},
+					CertificateIssuer: &metal3api.IssuerReference{Name: "issuer"},
+				},
+			},
+			ExpectedError: "selfSigned and certificateIssuer cannot be used together",
+		},
+		{
+			Scenario: "self-signed certificate with HA",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "test",
+					Host:            "example.com",
+					Name:            "ironic",
+				},
+				HighAvailability: true,
+				TLS: metal3api.TLS{
+					SelfSigned: &metal3api.SelfSignedCertificate{},
+				},
+			},
+			ExpectedError: "set insecureRPC with highAvailability",
+		},
+		{
+			Scenario: "certificate expiration thresholds",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CertificateExpirationThresholds: []metav1.Duration{{Duration: 14 * 24 * time.Hour}, {Duration: time.Hour}},
+				},
+			},
+		},
+		{
+			Scenario: "negative certificate expiration threshold",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CertificateExpirationThresholds: []metav1.Duration{{Duration: -time.Hour}},
+				},
+			},
+			ExpectedError: "thresholds must be positive",
+		},
+		{
+			Scenario: "client CA",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CertificateName: "tls",
+					ClientCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindSecret},
+					},
+					ClientCertificateOnly: true,
+				},
+			},
+		},
+		{
+			Scenario: "client CA without TLS",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					ClientCA: &metal3api.ResourceReferenceWithKey{
+						ResourceReference: metal3api.ResourceReference{Name: "client-ca", Kind: metal3api.ResourceKindSecret},
+					},
+				},
+			},
+			ExpectedError: "client certificates require TLS to be enabled",
+		},
+		{
+			Scenario: "client certificate only without client CA",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CertificateName:       "tls",
+					ClientCertificateOnly: true,
+				},
+			},
+			ExpectedError: "clientCertificateOnly requires tls.clientCA",
+		},
+		{
+			Scenario: "TLS version and ciphers",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					MinVersion:   metal3api.TLSVersion12,
+					CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"},
+				},
+			},
+		},
+		{
+			Scenario: "invalid TLS version",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					MinVersion: "TLS12",
+				},
+			},
+			ExpectedError: "spec.tls.minVersion: Unsupported value",
+		},
+		{
+			Scenario: "unknown cipher suite",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CipherSuites: []string{"TLS_NOT_A_CIPHER"},
+				},
+			},
+			ExpectedError: "Cipher suite TLS_NOT_A_CIPHER not supported or doesn't exist",
+		},
+		{
+			Scenario: "TLS 1.3 cipher suite",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CipherSuites: []string{"TLS_AES_128_GCM_SHA256"},
+				},
+			},
+			ExpectedError: "only TLS 1.2 cipher suites are supported",
+		},
+		{
+			Scenario: "insecure cipher suite",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_3DES_EDE_CBC_SHA"},
+				},
+			},
+			ExpectedError: "cipher suite TLS_RSA_WITH_3DES_EDE_CBC_SHA is insecure",
+		},
+		{
+			Scenario: "additional CAs without names",
+			Ironic: metal3api.IronicSpec{
+				TLS: metal3api.TLS{
+					AdditionalTrustedCAs: []metal3api.ResourceReferenceWithKey{
+						{ResourceReference: metal3api.ResourceReference{Kind: metal3api.ResourceKindConfigMap}},
+					},
+				},
+			},
+			ExpectedError: "spec.tls.additionalTrustedCAs[0].name: Required value",
+		},
+		{
+			Scenario: "extra API credentials",
+			Ironic: metal3api.IronicSpec{
+				APICredentialsName:       "admin",
+				ExtraAPICredentialsNames: []string{"monitoring", "bmo"},
+			},
+		},
+		{
+			Scenario: "extra API credentials same as main",
+			Ironic: metal3api.IronicSpec{
+				APICredentialsName:       "admin",
+				ExtraAPICredentialsNames: []string{"admin"},
+			},
+			ExpectedError: "different from apiCredentialsName",
+		},
+		{
+			Scenario: "credentials rotation",
+			Ironic: metal3api.IronicSpec{
+				APICredentialsRotation: &metal3api.CredentialsRotation{
+					Interval: metav1.Duration{Duration: 720 * time.Hour},
+				},
+			},
+		},
+		{
+			Scenario: "credentials rotation without interval",
+			Ironic: metal3api.IronicSpec{
+				APICredentialsRotation: &metal3api.CredentialsRotation{},
+			},
+			ExpectedError: "interval must be positive",
+		},
+		{
+			Scenario: "intermediate images",
+			Ironic: metal3api.IronicSpec{
+				UpgradePolicy: &metal3api.UpgradePolicy{
+					IntermediateImages: map[string]string{"37.0": "mirror.example.com/ironic:release-37.0"},
+				},
+			},
+		},
+		{
+			Scenario: "intermediate image of an unsupported version",
+			Ironic: metal3api.IronicSpec{
+				UpgradePolicy: &metal3api.UpgradePolicy{
+					IntermediateImages: map[string]string{"36.0": "mirror.example.com/ironic:release-36.0"},
+				},
+			},
+			ExpectedError: "not a supported intermediate version",
+		},
+		{
+			Scenario: "empty intermediate image",
+			Ironic: metal3api.IronicSpec{
+				UpgradePolicy: &metal3api.UpgradePolicy{
+					IntermediateImages: map[string]string{"37.0": ""},
+				},
+			},
+			ExpectedError: "image cannot be empty",
+		},
+		{
+			Scenario: "negative node operations timeout",
+			Ironic: metal3api.IronicSpec{
+				UpgradePolicy: &metal3api.UpgradePolicy{
+					NodeOperationsTimeout: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			ExpectedError: "nodeOperationsTimeout cannot be negative",
+		},
+		{
+			Scenario: "too long upgrade history",
+			Ironic: metal3api.IronicSpec{
+				UpgradePolicy: &metal3api.UpgradePolicy{HistoryLimit: 1000},
+			},
+			ExpectedError: "historyLimit must be between 1 and 100",
+		},
+		{
+			Scenario: "too many database backups",
+			Ironic: metal3api.IronicSpec{
+				Database: &metal3api.Database{
+					CredentialsName: "test",
+					Host:            "example.com",
+					Name:            "ironic",
+					Backup: &metal3api.DatabaseBackup{
+						PersistentVolumeClaimName: "backups",
+						Retain:                    100,
+					},
+				},
+			},
+			ExpectedError: "retain cannot be more than 50",
+		},
+		{
+			Scenario: "valid maintenance window",
+			Ironic: metal3api.IronicSpec{
+				MaintenanceWindow: &metal3api.MaintenanceWindow{
+					Schedule: "CRON_TZ=Europe/Prague 0 2 * * 6",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+		},
+		{
+			Scenario: "invalid maintenance window schedule",
+			Ironic: metal3api.IronicSpec{
+				MaintenanceWindow: &metal3api.MaintenanceWindow{
+					Schedule: "every saturday",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+			ExpectedError: "invalid schedule",
+		},
+		{
+			Scenario: "zero maintenance window duration",
+			Ironic: metal3api.IronicSpec{
+				MaintenanceWindow: &metal3api.MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+				},
+			},
+			ExpectedError: "duration must be positive",
+		},
 		{
 			Scenario: "ServiceMonitor incompatible with explicit loopback bindAddress",
 			Ironic: metal3api.IronicSpec{
@@ -1060,7 +1415,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages: duplicate default (empty architecture) entry",
+			ExpectedError: "spec.overrides.agentImages[1].architecture: Invalid value: \"\": duplicate default (empty architecture) entry",
 		},
 		{
 			Scenario: "agent images empty kernel",
@@ -1074,7 +1429,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0]: kernel is required",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Required value: kernel is required",
 		},
 		{
 			Scenario: "agent images empty initramfs",
@@ -1088,7 +1443,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0]: initramfs is required",
+			ExpectedError: "spec.overrides.agentImages[0].initramfs: Required value: initramfs is required",
 		},
 		{
 			Scenario: "agent images duplicate architecture",
@@ -1108,7 +1463,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages: duplicate architecture \"x86_64\"",
+			ExpectedError: "spec.overrides.agentImages[1].architecture: Duplicate value: \"x86_64\"",
 		},
 		{
 			Scenario: "agent images with http URL",
@@ -1165,7 +1520,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0]: kernel is required",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Required value: kernel is required",
 		},
 		{
 			Scenario: "agent images with whitespace-only initramfs",
@@ -1180,7 +1535,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0]: initramfs is required",
+			ExpectedError: "spec.overrides.agentImages[0].initramfs: Required value: initramfs is required",
 		},
 		{
 			Scenario: "agent images with whitespace-padded URL",
@@ -1209,7 +1564,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].kernel: invalid URL format",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Invalid value: \"://invalid-url\": invalid URL format",
 		},
 		{
 			Scenario: "agent images with invalid initramfs URL",
@@ -1224,7 +1579,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].initramfs: unsupported protocol",
+			ExpectedError: "spec.overrides.agentImages[0].initramfs: Invalid value: \"not a url\": unsupported protocol",
 		},
 		{
 			Scenario: "agent images with unsupported kernel protocol",
@@ -1239,7 +1594,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].kernel: unsupported protocol \"ftp\"",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Invalid value: \"ftp://example.com/ipa.kernel\": unsupported protocol \"ftp\"",
 		},
 		{
 			Scenario: "agent images with non-absolute file URL kernel",
@@ -1254,7 +1609,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].kernel: file URL must use an absolute path",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Invalid value: \"file://relative/path\": file URL must use an absolute path",
 		},
 		{
 			Scenario: "agent images with http URL missing host",
@@ -1269,7 +1624,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].kernel: http URL must include a host",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Invalid value: \"http:///path/only\": http URL must include a host",
 		},
 		{
 			Scenario: "agent images with oci URL missing host",
@@ -1284,7 +1639,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].kernel: oci URL must include a registry host",
+			ExpectedError: "spec.overrides.agentImages[0].kernel: Invalid value: \"oci:///path/only\": oci URL must include a registry host",
 		},
 		{
 			Scenario: "agent images with unsupported initramfs protocol",
@@ -1299,7 +1654,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "overrides.agentImages[0].initramfs: unsupported protocol \"ssh\"",
+			ExpectedError: "spec.overrides.agentImages[0].initramfs: Invalid value: \"ssh://example.com/ipa.initramfs\": unsupported protocol \"ssh\"",
 		},
 		{
 			Scenario: "networking service with access mode provider network",
@@ -1416,7 +1771,7 @@ func TestValidateIronic(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: "duplicate provider network type",
+			ExpectedError: "spec.networkingService.providerNetworks[1].type: Duplicate value: \"idle\"",
 		},
 	}
 
@@ -1426,7 +1781,7 @@ func TestValidateIronic(t *testing.T) {
 				tc.OldIronic = &tc.Ironic
 			}
 
-			err := ValidateIronic(&tc.Ironic, tc.OldIronic)
+			err := ValidateIronic(&tc.Ironic, tc.OldIronic).ToAggregate()
 			if tc.ExpectedError == "" {
 				assert.NoError(t, err)
 			} else {
@@ -1559,7 +1914,7 @@ func TestValidateCASettings(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Scenario, func(t *testing.T) {
-			err := validateCASettings(&tc.TLS)
+			err := validateCASettings(&tc.TLS, field.NewPath("spec", "tls")).ToAggregate()
 			if tc.ExpectedError == "" {
 				assert.NoError(t, err)
 			} else {
@@ -1831,7 +2186,7 @@ func TestValidateProviderNetwork(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Scenario, func(t *testing.T) {
-			err := validateProviderNetwork(tc.Config)
+			err := validateProviderNetwork(tc.Config, field.NewPath("spec", "networkingService", "providerNetworks").Index(0)).ToAggregate()
 			if tc.ExpectedError == "" {
 				assert.NoError(t, err)
 			} else {
@@ -1840,3 +2195,35 @@ func TestValidateProviderNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIronicFieldPaths(t *testing.T) {
+	spec := &metal3api.IronicSpec{
+		Networking: metal3api.Networking{
+			IPAddress:  "banana",
+			ExternalIP: "192.0.2.1",
+			Ingress:    &metal3api.Ingress{Host: "example.com"},
+			DHCP: &metal3api.DHCP{
+				NetworkCIDR: "192.168.1.0/24",
+				RangeBegin:  "192.168.1.200",
+				RangeEnd:    "192.168.1.100",
+				ServeDNS:    true,
+				DNSAddress:  "192.168.1.1",
+			},
+		},
+		Version: "banana",
+	}
+
+	errs := ValidateIronic(spec, nil)
+
+	paths := make([]string, 0, len(errs))
+	for _, err := range errs {
+		paths = append(paths, err.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"spec.networking.ipAddress",
+		"spec.networking.externalIP",
+		"spec.networking.dhcp.dnsAddress",
+		"spec.networking.dhcp.rangeBegin",
+		"spec.version",
+	}, paths)
+}
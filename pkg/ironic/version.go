@@ -16,6 +16,7 @@ var (
 	defaultVersion                = metal3api.VersionLatest
 	defaultRamdiskDownloaderImage = defaultRegistry + "/ironic-ipa-downloader:latest"
 	defaultKeepalivedImage        = defaultRegistry + "/keepalived:latest"
+	defaultDatabaseBackupImage    = defaultRegistry + "/mariadb:latest"
 
 	// versionMultiRangeDHCP gates ExtraRanges: split DHCP_RANGE and
 	// DHCP_OPTIONS support lands in Ironic 37.0.
@@ -28,6 +29,11 @@ type VersionInfo struct {
 	RamdiskDownloaderImage string
 	AgentBranch            string
 	KeepalivedImage        string
+	DatabaseBackupImage    string
+}
+
+func defaultIronicImage(version metal3api.Version) string {
+	return fmt.Sprintf("%s/ironic:%s", defaultRegistry, metal3api.SupportedVersions[version])
 }
 
 // Creates a version info from images and version.
@@ -41,12 +47,11 @@ func NewVersionInfo(ironicImages metal3api.Images, ironicVersion string) (result
 	} else {
 		result.InstalledVersion = defaultVersion
 	}
-	tag := metal3api.SupportedVersions[result.InstalledVersion]
 
 	if ironicImages.Ironic != "" {
 		result.IronicImage = ironicImages.Ironic
 	} else {
-		result.IronicImage = fmt.Sprintf("%s/ironic:%s", defaultRegistry, tag)
+		result.IronicImage = defaultIronicImage(result.InstalledVersion)
 	}
 
 	if ironicImages.DeployRamdiskDownloader != "" {
@@ -61,6 +66,12 @@ func NewVersionInfo(ironicImages metal3api.Images, ironicVersion string) (result
 		result.KeepalivedImage = defaultKeepalivedImage
 	}
 
+	if ironicImages.DatabaseBackup != "" {
+		result.DatabaseBackupImage = ironicImages.DatabaseBackup
+	} else {
+		result.DatabaseBackupImage = defaultDatabaseBackupImage
+	}
+
 	if ironicImages.DeployRamdiskBranch != "" {
 		result.AgentBranch = ironicImages.DeployRamdiskBranch
 	}
@@ -82,8 +93,7 @@ func (versionInfo VersionInfo) WithIronicOverrides(ironic *metal3api.Ironic) (Ve
 
 		// NOTE(dtantsur): a non-default version requires a different default image
 		if images.Ironic == "" {
-			tag := metal3api.SupportedVersions[parsedVersion]
-			versionInfo.IronicImage = fmt.Sprintf("%s/ironic:%s", defaultRegistry, tag)
+			versionInfo.IronicImage = defaultIronicImage(parsedVersion)
 		}
 	}
 
@@ -103,6 +113,10 @@ func (versionInfo VersionInfo) WithIronicOverrides(ironic *metal3api.Ironic) (Ve
 		versionInfo.KeepalivedImage = images.Keepalived
 	}
 
+	if images.DatabaseBackup != "" {
+		versionInfo.DatabaseBackupImage = images.DatabaseBackup
+	}
+
 	return versionInfo, nil
 }
 
@@ -30,6 +30,7 @@ func TestWithIronicOverrides(t *testing.T) {
 				InstalledVersion:       metal3api.VersionLatest,
 				IronicImage:            "quay.io/metal3-io/ironic:latest",
 				KeepalivedImage:        "quay.io/metal3-io/keepalived:latest",
+				DatabaseBackupImage:    "quay.io/metal3-io/mariadb:latest",
 				RamdiskDownloaderImage: "quay.io/metal3-io/ironic-ipa-downloader:latest",
 			},
 		},
@@ -43,6 +44,7 @@ func TestWithIronicOverrides(t *testing.T) {
 						DeployRamdiskDownloader: "myorg/ramdisk-downloader:tag",
 						Ironic:                  "myorg/ironic:tag",
 						Keepalived:              "myorg/keepalived:tag",
+						DatabaseBackup:          "myorg/mariadb:tag",
 					},
 				},
 			},
@@ -53,6 +55,7 @@ func TestWithIronicOverrides(t *testing.T) {
 				InstalledVersion:       metal3api.VersionLatest,
 				IronicImage:            "myorg/ironic:tag",
 				KeepalivedImage:        "myorg/keepalived:tag",
+				DatabaseBackupImage:    "myorg/mariadb:tag",
 				RamdiskDownloaderImage: "myorg/ramdisk-downloader:tag",
 			},
 		},
@@ -69,6 +72,7 @@ func TestWithIronicOverrides(t *testing.T) {
 				InstalledVersion:       metal3api.Version380,
 				IronicImage:            "quay.io/metal3-io/ironic:release-38.0",
 				KeepalivedImage:        "quay.io/metal3-io/keepalived:latest",
+				DatabaseBackupImage:    "quay.io/metal3-io/mariadb:latest",
 				RamdiskDownloaderImage: "quay.io/metal3-io/ironic-ipa-downloader:latest",
 			},
 		},
@@ -85,6 +89,7 @@ func TestWithIronicOverrides(t *testing.T) {
 				InstalledVersion:       metal3api.Version370,
 				IronicImage:            "quay.io/metal3-io/ironic:release-37.0",
 				KeepalivedImage:        "quay.io/metal3-io/keepalived:latest",
+				DatabaseBackupImage:    "quay.io/metal3-io/mariadb:latest",
 				RamdiskDownloaderImage: "quay.io/metal3-io/ironic-ipa-downloader:latest",
 			},
 		},
@@ -1,6 +1,7 @@
 package fuzz
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -10,8 +11,16 @@ import (
 
 // FuzzValidateIronic detects panics in ValidateIronic for arbitrary IP
 // addresses, CIDR ranges, DHCP ranges, and image URLs. Validation errors
-// are expected; the only invariant is that no panic occurs.
+// are expected; the invariants are that no panic occurs and that every
+// error points to one of the fuzzed fields.
 func FuzzValidateIronic(f *testing.F) {
+	fuzzedPaths := []string{
+		"spec.networking.ipAddress",
+		"spec.networking.externalIP",
+		"spec.networking.dhcp",
+		"spec.overrides.agentImages",
+	}
+
 	type seed struct {
 		ipAddress    string
 		externalIP   string
@@ -52,7 +61,7 @@ func FuzzValidateIronic(f *testing.F) {
 		f.Add(s.ipAddress, s.externalIP, s.networkCIDR, s.rangeBegin, s.rangeEnd, s.dnsAddress, s.kernelURL, s.initramfsURL)
 	}
 
-	f.Fuzz(func(_ *testing.T,
+	f.Fuzz(func(t *testing.T,
 		ipAddress string,
 		externalIP string,
 		networkCIDR string,
@@ -89,6 +98,16 @@ func FuzzValidateIronic(f *testing.F) {
 			}
 		}
 
-		_ = ironic.ValidateIronic(spec, nil)
+		for _, err := range ironic.ValidateIronic(spec, nil) {
+			// Reported when DHCP is used without an IP address
+			if err.Field == "spec.networking" {
+				continue
+			}
+			if !slices.ContainsFunc(fuzzedPaths, func(prefix string) bool {
+				return strings.HasPrefix(err.Field, prefix)
+			}) {
+				t.Errorf("unexpected field path %q in error: %v", err.Field, err)
+			}
+		}
 	})
 }